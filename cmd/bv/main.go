@@ -181,6 +181,8 @@ func main() {
 	pagesIncludeHistory := flag.Bool("pages-include-history", true, "Include git history for time-travel (default: true)")
 	previewPages := flag.String("preview-pages", "", "Preview existing static site bundle")
 	pagesWizard := flag.Bool("pages", false, "Launch interactive Pages deployment wizard")
+	pagesConfig := flag.String("pages-config", "", "Run the Pages wizard non-interactively from a JSON/YAML config file")
+	pagesDryRun := flag.Bool("pages-dry-run", false, "With --pages/--pages-config, print the deploy commands instead of running them")
 	// Debug rendering flag (for diagnosing TUI issues)
 	debugRender := flag.String("debug-render", "", "Render a view and output to file (views: insights, board)")
 	debugWidth := flag.Int("debug-width", 180, "Width for debug render")
@@ -197,6 +199,8 @@ func main() {
 	_ = pagesIncludeHistory
 	_ = previewPages
 	_ = pagesWizard
+	_ = pagesConfig
+	_ = pagesDryRun
 	_ = debugRender
 	_ = debugWidth
 	_ = debugHeight
@@ -689,6 +693,16 @@ func main() {
 		fmt.Println("          Guides you through export -> preview -> deploy to GitHub Pages.")
 		fmt.Println("          Handles gh CLI authentication and repository creation.")
 		fmt.Println("")
+		fmt.Println("      --pages-config <file>")
+		fmt.Println("          Run the Pages wizard without prompts, for CI pipelines.")
+		fmt.Println("          Reads wizard settings from JSON (or YAML for .yaml/.yml);")
+		fmt.Println("          fails if the deploy target's required fields are missing.")
+		fmt.Println("          Example: bv --pages-config pages.yaml")
+		fmt.Println("")
+		fmt.Println("      --pages-dry-run")
+		fmt.Println("          Build the bundle but only print the gh/git/wrangler commands")
+		fmt.Println("          the deploy would run. Also settable as dry_run in --pages-config.")
+		fmt.Println("")
 		fmt.Println("      --export-pages <dir>")
 		fmt.Println("          Export static HTML site to directory.")
 		fmt.Println("          Creates self-contained bundle viewable in any browser.")
@@ -1266,8 +1280,8 @@ func main() {
 	}
 
 	// Handle --pages wizard (bv-10g)
-	if *pagesWizard {
-		if err := runPagesWizard(issues, beadsPath); err != nil {
+	if *pagesWizard || *pagesConfig != "" {
+		if err := runPagesWizard(issues, beadsPath, *pagesConfig, *pagesDryRun); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -1378,6 +1392,15 @@ func main() {
 			os.Exit(1)
 		}
 
+		// Record exactly which issues were published
+		manifestConfig := &export.WizardConfig{
+			IncludeClosed:  *pagesIncludeClosed,
+			IncludeHistory: *pagesIncludeHistory,
+		}
+		if _, err := export.WriteExportManifest(*exportPages, exportIssues, manifestConfig); err != nil {
+			fmt.Printf("  → Warning: %v\n", err)
+		}
+
 		// Generate README.md with project stats (useful for GitHub Pages deployment)
 		fmt.Println("  → Generating README.md...")
 		if err := generateREADME(*exportPages, *pagesTitle, "", exportIssues, &triage, stats); err != nil {
@@ -2199,6 +2222,8 @@ func main() {
 			cfg.ComputeCriticalPath = false
 			cfg.ComputeCycles = false
 			cfg.CyclesSkipReason = skipReason
+			cfg.ComputeCriticality = false
+			cfg.CriticalitySkipReason = skipReason
 		}
 
 		plan := analyzer.GetExecutionPlan()
@@ -4942,6 +4967,7 @@ func printProfileReport(profile *analysis.StartupProfile, loadDuration, totalWit
 	printMetricLine("HITS", profile.HITS, profile.HITSTO, profile.Config.ComputeHITS)
 	printMetricLine("Critical Path", profile.CriticalPath, false, profile.Config.ComputeCriticalPath)
 	printCyclesLine(profile)
+	printMetricLine("Criticality", profile.Criticality, profile.CriticalityTO, profile.Config.ComputeCriticality)
 	fmt.Printf("  Total Phase 2:   %v\n\n", formatDuration(profile.Phase2))
 
 	// Total
@@ -5057,6 +5083,9 @@ func generateProfileRecommendations(profile *analysis.StartupProfile, loadDurati
 	if profile.CyclesTO {
 		recs = append(recs, "⚠ Cycle detection timed out - graph may have many overlapping cycles")
 	}
+	if profile.CriticalityTO {
+		recs = append(recs, "⚠ Criticality timed out - reachability is costly on deep or dense graphs")
+	}
 
 	// Check which metric is taking longest
 	if profile.Config.ComputeBetweenness && profile.Betweenness > 0 {
@@ -5709,33 +5738,87 @@ func isCommandAvailable(name string) bool {
 	return err == nil
 }
 
-// runPagesWizard runs the interactive deployment wizard (bv-10g).
-func runPagesWizard(issues []model.Issue, beadsPath string) error {
+// runPagesWizard runs the deployment wizard (bv-10g). With a configPath the
+// wizard runs non-interactively from that file; dryRun builds into a scratch
+// directory that is removed afterwards, leaves the configured output path
+// untouched and only prints the deploy commands.
+func runPagesWizard(issues []model.Issue, beadsPath, configPath string, dryRun bool) error {
 	wizard := export.NewWizard(beadsPath)
+	wizard.DryRun = dryRun
 
-	// Run interactive wizard to collect configuration
-	_, err := wizard.Run()
-	if err != nil {
-		return err
+	if configPath != "" {
+		cfg, err := export.LoadWizardConfigFile(configPath)
+		if err != nil {
+			return err
+		}
+		if _, err := wizard.RunWithConfig(cfg); err != nil {
+			return err
+		}
+	} else {
+		// Run interactive wizard to collect configuration
+		if _, err := wizard.Run(); err != nil {
+			return err
+		}
 	}
 
 	config := wizard.GetConfig()
+	dryRun = dryRun || config.DryRun
+
+	// A dry run never writes to config.OutputPath
+	scratchDir := ""
+	if dryRun {
+		tmpDir, err := os.MkdirTemp("", "bv-pages-dry-run-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		scratchDir = tmpDir
+	}
 
 	// Filter issues based on config
-	exportIssues := issues
-	if !config.IncludeClosed {
-		var openIssues []model.Issue
-		for _, issue := range issues {
-			if issue.Status != model.StatusClosed {
-				openIssues = append(openIssues, issue)
-			}
+	exportIssues := export.FilterExportIssues(issues, config)
+
+	// Single-file export: one self-contained .html, no bundle directory
+	if config.DeployTarget == "single-file" {
+		wizard.PerformExport(config.OutputPath)
+		htmlPath := config.OutputPath
+		if dryRun {
+			htmlPath = filepath.Join(scratchDir, "index.html")
+		}
+
+		fmt.Printf("  -> Loading %d issues\n", len(exportIssues))
+		fmt.Println("  -> Running graph analysis...")
+		stats := analysis.NewAnalyzer(exportIssues).AnalyzeAsync(context.Background())
+		stats.WaitForPhase2()
+
+		fmt.Println("  -> Writing self-contained HTML...")
+		if err := export.WriteSingleFileHTML(htmlPath, export.SingleFileOptions{
+			Issues: exportIssues,
+			Stats:  stats,
+			Title:  config.Title,
+		}); err != nil {
+			return fmt.Errorf("single-file export failed: %w", err)
+		}
+		fmt.Println("")
+
+		result, err := wizard.PerformDeploy()
+		if err != nil {
+			return err
+		}
+		result.PublishedCount = len(exportIssues)
+		wizard.PrintSuccess(result)
+
+		if configPath == "" && !dryRun {
+			export.SaveWizardConfig(config)
 		}
-		exportIssues = openIssues
+		return nil
 	}
 
 	// Create temp directory for bundle
 	bundlePath := config.OutputPath
-	if bundlePath == "" {
+	if dryRun {
+		bundlePath = scratchDir
+	} else if bundlePath == "" {
 		tmpDir, err := os.MkdirTemp("", "bv-pages-*")
 		if err != nil {
 			return fmt.Errorf("failed to create temp directory: %w", err)
@@ -5750,57 +5833,12 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 
 	// Perform export
 	wizard.PerformExport(bundlePath)
-
-	fmt.Println("Exporting static site...")
-	fmt.Printf("  -> Loading %d issues\n", len(exportIssues))
-
-	// Build graph and compute stats
-	fmt.Println("  -> Running graph analysis...")
-	analyzer := analysis.NewAnalyzer(exportIssues)
-	stats := analyzer.AnalyzeAsync(context.Background())
-	stats.WaitForPhase2()
-
-	// Compute triage
-	fmt.Println("  -> Generating triage data...")
-	triage := analysis.ComputeTriage(exportIssues)
-
-	// Extract dependencies
-	var deps []*model.Dependency
-	for i := range exportIssues {
-		issue := &exportIssues[i]
-		for _, dep := range issue.Dependencies {
-			if dep == nil || !dep.Type.IsBlocking() {
-				continue
-			}
-			deps = append(deps, &model.Dependency{
-				IssueID:     issue.ID,
-				DependsOnID: dep.DependsOnID,
-				Type:        dep.Type,
-			})
-		}
-	}
-
-	// Create exporter
-	issuePointers := make([]*model.Issue, len(exportIssues))
-	for i := range exportIssues {
-		issuePointers[i] = &exportIssues[i]
-	}
-	exporter := export.NewSQLiteExporter(issuePointers, deps, stats, &triage)
-	if config.Title != "" {
-		exporter.Config.Title = config.Title
-	}
-
-	// Export SQLite database
-	fmt.Println("  -> Writing database and JSON files...")
-	if err := exporter.Export(bundlePath); err != nil {
-		return fmt.Errorf("export failed: %w", err)
-	}
-
-	// Copy viewer assets
-	fmt.Println("  -> Copying viewer assets...")
-	if err := copyViewerAssets(bundlePath, config.Title); err != nil {
-		return fmt.Errorf("failed to copy assets: %w", err)
+	wizard.SetAssetCopier(copyViewerAssets)
+	build, err := wizard.BuildBundle(exportIssues)
+	if err != nil {
+		return err
 	}
+	stats, triage := build.Stats, build.Triage
 
 	// Generate README.md with project stats (for GitHub Pages)
 	if config.DeployTarget == "github" {
@@ -5808,7 +5846,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 		// Compute the GitHub Pages URL from username and repo name
 		pagesURL := ""
 		if ghStatus, err := export.CheckGHStatus(); err == nil && ghStatus.Authenticated && ghStatus.Username != "" {
-			repoName := config.RepoName
+			repoName := export.QualifyRepoName(config.RepoOrg, config.RepoName)
 			// Handle repo names that already include owner (e.g., "owner/repo")
 			if strings.Contains(repoName, "/") {
 				parts := strings.Split(repoName, "/")
@@ -5826,7 +5864,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 				}
 			}
 		}
-		if err := generateREADME(bundlePath, config.Title, pagesURL, exportIssues, &triage, stats); err != nil {
+		if err := generateREADME(bundlePath, config.Title, pagesURL, exportIssues, triage, stats); err != nil {
 			fmt.Printf("  -> Warning: failed to generate README: %v\n", err)
 		}
 	}
@@ -5848,7 +5886,11 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 		}
 	}
 
-	fmt.Printf("  -> Bundle created: %s\n", bundlePath)
+	if dryRun {
+		fmt.Printf("  -> Bundle built in %s (dry run, removed on exit)\n", bundlePath)
+	} else {
+		fmt.Printf("  -> Bundle created: %s\n", bundlePath)
+	}
 	fmt.Println("")
 
 	// Offer preview and deploy (for GitHub and Cloudflare)
@@ -5861,11 +5903,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 		if action == "cancel" {
 			// User cancelled after preview - show local result instead
 			fmt.Println("Deployment cancelled. Bundle available at:", bundlePath)
-			result := &export.WizardResult{
-				BundlePath:   bundlePath,
-				DeployTarget: "local",
-			}
-			wizard.PrintSuccess(result)
+			wizard.PrintSuccess(wizard.CancelDeploy())
 		} else {
 			// Perform deployment
 			result, err := wizard.PerformDeploy()
@@ -5875,17 +5913,21 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 
 			wizard.PrintSuccess(result)
 		}
+	} else if dryRun {
+		fmt.Println("Dry run: nothing written to", config.OutputPath)
 	} else {
-		// Local export - just show success
-		result := &export.WizardResult{
-			BundlePath:   bundlePath,
-			DeployTarget: "local",
+		// Local export - nothing to push, but record it like any deploy
+		result, err := wizard.PerformDeploy()
+		if err != nil {
+			return err
 		}
 		wizard.PrintSuccess(result)
 	}
 
-	// Save config for next run
-	export.SaveWizardConfig(config)
+	// Save config for next run; a config file is already the saved form
+	if configPath == "" && !dryRun {
+		export.SaveWizardConfig(config)
+	}
 
 	return nil
 }
@@ -54,7 +54,7 @@ func TestRobotPlanAndPriorityIncludeMetadata(t *testing.T) {
 		}
 
 		// Ensure the status contract is usable at process exit (no pending/empty states).
-		expected := []string{"PageRank", "Betweenness", "Eigenvector", "HITS", "Critical", "Cycles", "KCore", "Articulation", "Slack"}
+		expected := []string{"PageRank", "Betweenness", "Eigenvector", "HITS", "Critical", "Cycles", "KCore", "Articulation", "Slack", "Criticality"}
 		for _, metric := range expected {
 			entryAny, ok := status[metric]
 			if !ok {
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRunPagesWizardDryRunLeavesOutputPathUntouched(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "B", Title: "Child", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+	}
+
+	tests := []struct {
+		target string
+		output string
+	}{
+		{"local", "site"},
+		{"single-file", "dashboard.html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			dir := t.TempDir()
+			outputPath := filepath.Join(dir, tt.output)
+			configPath := filepath.Join(dir, "pages.json")
+			data, err := json.Marshal(map[string]any{
+				"deploy_target":   tt.target,
+				"output_path":     outputPath,
+				"title":           "Dry run",
+				"include_history": false,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(configPath, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := runPagesWizard(issues, filepath.Join(dir, ".beads"), configPath, true); err != nil {
+				t.Fatalf("runPagesWizard: %v", err)
+			}
+			if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+				t.Errorf("dry run wrote %s (stat err %v)", outputPath, err)
+			}
+		})
+	}
+}
+
+func TestRunPagesWizardLocalRecordsDeploy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "pages.json")
+	data, err := json.Marshal(map[string]any{
+		"deploy_target":   "local",
+		"output_path":     filepath.Join(dir, "site"),
+		"title":           "Local",
+		"include_history": false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []model.Issue{{ID: "A", Title: "Root", Status: model.StatusOpen, IssueType: model.TypeTask}}
+	if err := runPagesWizard(issues, filepath.Join(dir, ".beads"), configPath, false); err != nil {
+		t.Fatalf("runPagesWizard: %v", err)
+	}
+
+	records, err := export.ReadDeployRecords(export.DeployLogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Target != "local" || !records[0].Success || records[0].PublishedCount != 1 {
+		t.Fatalf("deploy log = %+v, want one successful local deploy of 1 issue", records)
+	}
+	if records[0].URL != filepath.Join(dir, "site") {
+		t.Errorf("recorded URL = %q, want the bundle path", records[0].URL)
+	}
+}
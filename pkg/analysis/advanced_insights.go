@@ -441,25 +441,16 @@ func (a *Analyzer) computeMarginalUnblocks(issueID string, alreadyCompleted map[
 		wouldBeBlocked := false
 		hasThisBlocker := false
 
-		for _, dep := range issue.Dependencies {
-			if dep == nil {
-				continue
-			}
-			if dep.Type != model.DepBlocks {
-				continue
-			}
-
-			if dep.DependsOnID == issueID {
+		for _, blockerID := range a.blockerIDs(issue) {
+			if blockerID == issueID {
 				hasThisBlocker = true
 				continue
 			}
 
 			// Check if there's another open blocker (not already completed)
-			if blocker, exists := a.issueMap[dep.DependsOnID]; exists {
-				if blocker.Status != model.StatusClosed && !alreadyCompleted[dep.DependsOnID] {
-					wouldBeBlocked = true
-					break
-				}
+			if blocker := a.issueMap[blockerID]; blocker.Status != model.StatusClosed && !alreadyCompleted[blockerID] {
+				wouldBeBlocked = true
+				break
 			}
 		}
 
@@ -487,12 +478,9 @@ func (a *Analyzer) generateCoverageSet(limit int) *CoverageSetResult {
 		if issue.Status == model.StatusClosed {
 			continue
 		}
-		for _, dep := range issue.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
-				continue
-			}
-			if target, ok := a.issueMap[dep.DependsOnID]; ok && target.Status != model.StatusClosed {
-				edges = append(edges, edge{from: id, to: dep.DependsOnID})
+		for _, blockerID := range a.blockerIDs(issue) {
+			if a.issueMap[blockerID].Status != model.StatusClosed {
+				edges = append(edges, edge{from: id, to: blockerID})
 			}
 		}
 	}
@@ -635,12 +623,9 @@ func (a *Analyzer) generateKPaths(k int, pathLengthCap int) *KPathsResult {
 
 	for _, node := range nodes {
 		issue := a.issueMap[node.id]
-		for _, dep := range issue.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
-				continue
-			}
-			// dep.DependsOnID blocks node.id
-			fromIdx, ok := idToIndex[dep.DependsOnID]
+		for _, blockerID := range a.blockerIDs(issue) {
+			// blockerID blocks node.id
+			fromIdx, ok := idToIndex[blockerID]
 			if !ok {
 				continue // blocker is closed or not in graph
 			}
@@ -837,13 +822,10 @@ func (a *Analyzer) generateParallelCut(limit int) *ParallelCutResult {
 		if !openIssues[id] {
 			continue
 		}
-		for _, dep := range issue.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
-				continue
-			}
-			if openIssues[dep.DependsOnID] {
-				blockerOf[dep.DependsOnID] = append(blockerOf[dep.DependsOnID], id)
-				blockedBy[id] = append(blockedBy[id], dep.DependsOnID)
+		for _, blockerID := range a.blockerIDs(issue) {
+			if openIssues[blockerID] {
+				blockerOf[blockerID] = append(blockerOf[blockerID], id)
+				blockedBy[id] = append(blockedBy[id], blockerID)
 			}
 		}
 	}
@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -1130,3 +1131,46 @@ func TestParallelCutDiamondNoGain(t *testing.T) {
 		t.Errorf("expected gain 1, got %d", insights.ParallelCut.Suggestions[0].ParallelGain)
 	}
 }
+
+func TestGenerateAdvancedInsightsBlocksDirection(t *testing.T) {
+	// A blocks B and C, which both block D; recorded once from the dependent's
+	// side and once from the blocker's side.
+	dependsOn := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+	}
+	blocks := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "D", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "D", Type: model.DepBlocks}}},
+		{ID: "D", Status: model.StatusOpen},
+	}
+
+	config := DefaultAdvancedInsightsConfig()
+	want := NewAnalyzer(dependsOn).GenerateAdvancedInsights(config)
+	got := NewAnalyzer(blocks, WithDependencyDirection(Blocks)).GenerateAdvancedInsights(config)
+
+	if len(got.TopKSet.Items) == 0 || got.TopKSet.Items[0].ID != "A" {
+		t.Fatalf("TopKSet should start with A, got %+v", got.TopKSet.Items)
+	}
+	if !reflect.DeepEqual(got.TopKSet, want.TopKSet) {
+		t.Errorf("TopKSet = %+v, want %+v", got.TopKSet, want.TopKSet)
+	}
+	if !reflect.DeepEqual(got.CoverageSet, want.CoverageSet) {
+		t.Errorf("CoverageSet = %+v, want %+v", got.CoverageSet, want.CoverageSet)
+	}
+	if !reflect.DeepEqual(got.KPaths, want.KPaths) {
+		t.Errorf("KPaths = %+v, want %+v", got.KPaths, want.KPaths)
+	}
+	if !reflect.DeepEqual(got.ParallelCut, want.ParallelCut) {
+		t.Errorf("ParallelCut = %+v, want %+v", got.ParallelCut, want.ParallelCut)
+	}
+}
@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Risk weights for the composite at-risk score. Each signal contributes its
+// weight once; blocked issues get a small bump per additional open blocker.
+const (
+	atRiskBlockedWeight      = 0.4
+	atRiskExtraBlockerWeight = 0.05
+	atRiskCriticalWeight     = 0.3
+	atRiskCycleWeight        = 0.3
+
+	// atRiskCriticalMinDepth is the shortest chain that counts as a critical
+	// path; below this every issue would trivially be "critical".
+	atRiskCriticalMinDepth = 3
+	// atRiskCriticalFraction is how close to the deepest chain an issue's
+	// critical-path score must be to be flagged.
+	atRiskCriticalFraction = 0.9
+)
+
+// AtRiskIssue is an open issue that needs attention, with the signals that
+// flagged it.
+type AtRiskIssue struct {
+	ID     string  `json:"id"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// AtRiskIssues returns open issues that are blocked by an open prerequisite,
+// sit on the critical path, or belong to a dependency cycle, sorted by a
+// composite risk score (highest first, ties by ID). It uses only metrics that
+// are already computed, so call it after Phase 2 for critical-path and cycle
+// signals; before that only the blocked signal is available.
+func (s *GraphStats) AtRiskIssues(issues []model.Issue) []AtRiskIssue {
+	open := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		if !issue.Status.IsClosed() && !issue.Status.IsTombstone() {
+			open[issue.ID] = true
+		}
+	}
+
+	criticalPath := s.CriticalPathScore()
+	maxDepth := 0.0
+	for _, v := range criticalPath {
+		if v > maxDepth {
+			maxDepth = v
+		}
+	}
+
+	inCycle := make(map[string]bool)
+	for _, cycle := range s.Cycles() {
+		for _, id := range cycle {
+			inCycle[id] = true
+		}
+	}
+
+	var result []AtRiskIssue
+	for _, issue := range issues {
+		if !open[issue.ID] {
+			continue
+		}
+
+		var blockers []string
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && open[dep.DependsOnID] {
+				blockers = append(blockers, dep.DependsOnID)
+			}
+		}
+
+		var score float64
+		var reasons []string
+		if len(blockers) > 0 {
+			sort.Strings(blockers)
+			score += atRiskBlockedWeight + atRiskExtraBlockerWeight*float64(len(blockers)-1)
+			reasons = append(reasons, "blocked by "+strings.Join(blockers, ", "))
+		}
+		if depth := criticalPath[issue.ID]; maxDepth >= atRiskCriticalMinDepth && depth >= atRiskCriticalFraction*maxDepth {
+			score += atRiskCriticalWeight * depth / maxDepth
+			reasons = append(reasons, fmt.Sprintf("on critical path (depth %.0f)", depth))
+		}
+		if inCycle[issue.ID] {
+			score += atRiskCycleWeight
+			reasons = append(reasons, "in dependency cycle")
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+		result = append(result, AtRiskIssue{
+			ID:     issue.ID,
+			Score:  score,
+			Reason: strings.Join(reasons, "; "),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestAtRiskIssuesBlockedAndCyclic(t *testing.T) {
+	issues := []model.Issue{
+		// Blocked: one open prerequisite (gate), one closed one that no longer blocks.
+		{ID: "blocked", Status: model.StatusOpen, Dependencies: blockedBy("gate", "done")},
+		{ID: "gate", Status: model.StatusOpen},
+		{ID: "done", Status: model.StatusClosed},
+		// Cycle; only the open member is reported.
+		{ID: "cyc-a", Status: model.StatusOpen, Dependencies: blockedBy("cyc-b")},
+		{ID: "cyc-b", Status: model.StatusClosed, Dependencies: blockedBy("cyc-a")},
+		// Normal: no open prerequisites, not in a cycle.
+		{ID: "normal", Status: model.StatusOpen},
+	}
+
+	stats := NewAnalyzer(issues).Analyze()
+	atRisk := stats.AtRiskIssues(issues)
+
+	got := make(map[string]AtRiskIssue)
+	for _, r := range atRisk {
+		got[r.ID] = r
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected exactly blocked and cyc-a, got %+v", atRisk)
+	}
+	if r, ok := got["blocked"]; !ok || r.Reason != "blocked by gate" {
+		t.Errorf("blocked issue missing or wrong reason: %+v", r)
+	}
+	if r, ok := got["cyc-a"]; !ok || !strings.Contains(r.Reason, "cycle") {
+		t.Errorf("cyclic issue missing or wrong reason: %+v", r)
+	}
+	if _, ok := got["normal"]; ok {
+		t.Error("normal issue should not be at risk")
+	}
+}
@@ -1,6 +1,7 @@
 package analysis_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -243,6 +244,38 @@ func BenchmarkNewAnalyzer_Sparse1000(b *testing.B) {
 	}
 }
 
+// ============================================================================
+// Suggested Order Benchmarks
+// ============================================================================
+
+// BenchmarkSuggestedOrder_Independent10000 is the worst case for picking the
+// next ready issue: nothing blocks anything, so all 10k are ready at once.
+func BenchmarkSuggestedOrder_Independent10000(b *testing.B) {
+	issues := make([]model.Issue, 10000)
+	for i := range issues {
+		issues[i] = model.Issue{
+			ID:       fmt.Sprintf("IND-%d", i),
+			Status:   model.StatusOpen,
+			Priority: i % 5,
+		}
+	}
+	benchSuggestedOrder(b, issues)
+}
+
+func BenchmarkSuggestedOrder_Sparse1000(b *testing.B) {
+	benchSuggestedOrder(b, generateSparseGraph(1000))
+}
+
+func benchSuggestedOrder(b *testing.B, issues []model.Issue) {
+	an := analysis.NewAnalyzer(issues)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = an.SuggestedOrder()
+	}
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
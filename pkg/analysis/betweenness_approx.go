@@ -1,6 +1,8 @@
 package analysis
 
 import (
+	"container/heap"
+	"context"
 	"math/rand"
 	"runtime"
 	"sort"
@@ -9,7 +11,7 @@ import (
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/network"
-	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/path"
 )
 
 type denseIndex struct {
@@ -35,9 +37,12 @@ func buildDenseIndex(nodes []graph.Node) denseIndex {
 type cachedAdjacency struct {
 	outgoing [][]int
 	incoming [][]int
+	// weights[v][i] is the weight of the edge v -> outgoing[v][i]; nil for
+	// unweighted graphs, where every edge counts as 1.
+	weights [][]float64
 }
 
-func buildCachedAdjacency(g *simple.DirectedGraph, idx denseIndex) cachedAdjacency {
+func buildCachedAdjacency(g graph.Directed, idx denseIndex) cachedAdjacency {
 	nodeCount := len(idx.idxToID)
 	outgoing := make([][]int, nodeCount)
 	incoming := make([][]int, nodeCount)
@@ -61,6 +66,18 @@ func buildCachedAdjacency(g *simple.DirectedGraph, idx denseIndex) cachedAdjacen
 		outgoing[vIdx] = neighbors
 	}
 
+	var weights [][]float64
+	if wg, ok := g.(graph.Weighted); ok {
+		weights = make([][]float64, nodeCount)
+		for vIdx, neighbors := range outgoing {
+			vw := make([]float64, len(neighbors))
+			for i, wIdx := range neighbors {
+				vw[i], _ = wg.Weight(idx.idxToID[vIdx], idx.idxToID[wIdx])
+			}
+			weights[vIdx] = vw
+		}
+	}
+
 	// Build incoming adjacency from the already-built outgoing adjacency.
 	for vIdx, neighbors := range outgoing {
 		for _, wIdx := range neighbors {
@@ -74,6 +91,7 @@ func buildCachedAdjacency(g *simple.DirectedGraph, idx denseIndex) cachedAdjacen
 	return cachedAdjacency{
 		outgoing: outgoing,
 		incoming: incoming,
+		weights:  weights,
 	}
 }
 
@@ -82,19 +100,21 @@ func buildCachedAdjacency(g *simple.DirectedGraph, idx denseIndex) cachedAdjacen
 //
 // Memory characteristics (n = number of nodes):
 //   - sigma: stores shortest path counts, O(n)
-//   - dist: stores BFS distances (-1 = unvisited), O(n)
+//   - dist: stores shortest-path distances (-1 = unvisited), O(n)
 //   - delta: stores dependency accumulation, O(n)
 //   - pred: stores predecessor lists as dense indices, O(n) slices + O(E) total capacity
 //   - queue: BFS frontier, up to O(n) capacity
+//   - pq: Dijkstra frontier for weighted graphs, up to O(E) capacity
 //   - stack: reverse order for accumulation, up to O(n) capacity
 //   - neighbors: temporary slice for iterator results, typically small
 //   - bc: per-source betweenness contributions, O(n)
 type brandesBuffers struct {
 	sigma     []float64 // σ_s(v)
-	dist      []int     // d_s(v) (-1 = infinity/unvisited)
+	dist      []float64 // d_s(v) (-1 = infinity/unvisited)
 	delta     []float64 // δ_s(v)
 	pred      [][]int   // P_s(v) = predecessors as dense indices
 	queue     []int     // BFS queue (FIFO)
+	pq        distQueue // Dijkstra priority queue (weighted graphs only)
 	stack     []int     // Visited nodes in BFS order (LIFO for backprop)
 	neighbors []int     // Temp slice to collect neighbor indices from iterator
 	bc        []float64 // Per-source betweenness contributions
@@ -114,7 +134,7 @@ var brandesPool = sync.Pool{
 	New: func() interface{} {
 		return &brandesBuffers{
 			sigma:     make([]float64, 0, 256),
-			dist:      make([]int, 0, 256),
+			dist:      make([]float64, 0, 256),
 			delta:     make([]float64, 0, 256),
 			pred:      make([][]int, 0, 256),
 			queue:     make([]int, 0, 256),
@@ -173,7 +193,7 @@ func (b *brandesBuffers) reset(nodeCount int) {
 	// - Shrink when previous capacity is >2x node count (avoid unbounded retention)
 	if cap(b.sigma) < nodeCount || cap(b.sigma) > nodeCount*2 {
 		b.sigma = make([]float64, 0, nodeCount)
-		b.dist = make([]int, 0, nodeCount)
+		b.dist = make([]float64, 0, nodeCount)
 		b.delta = make([]float64, 0, nodeCount)
 		b.pred = make([][]int, 0, nodeCount)
 		b.queue = make([]int, 0, nodeCount)
@@ -211,6 +231,7 @@ func (b *brandesBuffers) reset(nodeCount int) {
 
 	// Reset auxiliary slices (retain capacity)
 	b.queue = b.queue[:0]
+	b.pq = b.pq[:0]
 	b.stack = b.stack[:0]
 	b.neighbors = b.neighbors[:0]
 }
@@ -232,6 +253,21 @@ const (
 	BetweennessSkip BetweennessMode = "skip"
 )
 
+// BetweennessStrategy selects the sampling estimator used in approximate mode.
+type BetweennessStrategy string
+
+const (
+	// BetweennessStrategyPivot samples k source nodes and runs a full Brandes
+	// pass from each (Bader et al.). This is the default.
+	BetweennessStrategyPivot BetweennessStrategy = "pivot"
+
+	// BetweennessStrategyPairs samples k ordered source-target pairs and adds
+	// the pair dependency σ_sv·σ_vt/σ_st of every intermediate node. Each sample
+	// is cheaper to aggregate and, on graphs where a few sources dominate the
+	// path structure, gives better accuracy per sample than pivots.
+	BetweennessStrategyPairs BetweennessStrategy = "pairs"
+)
+
 // BetweennessResult contains the result of betweenness computation.
 type BetweennessResult struct {
 	// Scores maps node IDs to their betweenness centrality scores
@@ -240,9 +276,12 @@ type BetweennessResult struct {
 	// Mode indicates how the result was computed
 	Mode BetweennessMode
 
-	// SampleSize is the number of pivot nodes used (only for approximate mode)
+	// SampleSize is the number of pivots or pairs sampled (only for approximate mode)
 	SampleSize int
 
+	// Strategy is the sampling estimator used (only for approximate mode)
+	Strategy BetweennessStrategy
+
 	// TotalNodes is the total number of nodes in the graph
 	TotalNodes int
 
@@ -253,6 +292,27 @@ type BetweennessResult struct {
 	TimedOut bool
 }
 
+// NormalizedScores returns Scores divided by (n-1)(n-2), the most shortest
+// paths between other node pairs that one node of an n-node directed graph
+// can lie on. Approximate scores estimate the raw Brandes scores, so after
+// normalizing they are directly comparable with exact ones, including across
+// graphs of different sizes. Exact scores always fall in [0, 1]; approximate
+// ones are scaled up by n/k from k samples and can overshoot, so they are
+// clamped to 1. Graphs with fewer than three nodes have no intermediate nodes
+// and normalize to zero.
+func (r BetweennessResult) NormalizedScores() map[int64]float64 {
+	scores := make(map[int64]float64, len(r.Scores))
+	maxPaths := float64(r.TotalNodes-1) * float64(r.TotalNodes-2)
+	for id, score := range r.Scores {
+		if r.TotalNodes < 3 {
+			scores[id] = 0
+			continue
+		}
+		scores[id] = min(score/maxPaths, 1)
+	}
+	return scores
+}
+
 // ApproxBetweenness computes approximate betweenness centrality using sampling.
 //
 // Instead of computing shortest paths from ALL nodes (O(V*E)), we sample k pivot
@@ -268,7 +328,27 @@ type BetweennessResult struct {
 // References:
 //   - "A Faster Algorithm for Betweenness Centrality" (Brandes, 2001)
 //   - "Approximating Betweenness Centrality" (Bader et al., 2007)
-func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) BetweennessResult {
+func ApproxBetweenness(g graph.Directed, sampleSize int, seed int64) BetweennessResult {
+	return ApproxBetweennessCtx(context.Background(), g, sampleSize, seed)
+}
+
+// ApproxBetweennessCtx is ApproxBetweenness that stops starting new pivots
+// once ctx is done. The pivots already processed are scaled by their own
+// count rather than sampleSize, so a partial result is still a usable
+// ranking; it has TimedOut set and SampleSize reporting the pivots used. The
+// exact fallback for small graphs cannot be interrupted, but is skipped if ctx
+// is already done.
+func ApproxBetweennessCtx(ctx context.Context, g graph.Directed, sampleSize int, seed int64) BetweennessResult {
+	return ApproxBetweennessWorkers(ctx, g, sampleSize, seed, 0)
+}
+
+// ApproxBetweennessWorkers is ApproxBetweennessCtx with the number of pivot
+// workers set explicitly; 0 means GOMAXPROCS. Pivots are dealt out to the
+// workers round-robin, each worker sums into its own buffer, and the buffers
+// are merged in worker order, so for a given seed and worker count the
+// result is bit-for-bit reproducible. Different worker counts agree up to
+// floating-point rounding.
+func ApproxBetweennessWorkers(ctx context.Context, g graph.Directed, sampleSize int, seed int64, workers int) BetweennessResult {
 	start := time.Now()
 	nodes := pooledNodesOf(g.Nodes())
 	defer putPooledNodes(nodes)
@@ -285,6 +365,7 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 		Scores:     make(map[int64]float64),
 		Mode:       BetweennessApproximate,
 		SampleSize: sampleSize,
+		Strategy:   BetweennessStrategyPivot,
 		TotalNodes: n,
 	}
 
@@ -293,12 +374,20 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 		return result
 	}
 
+	if ctx.Err() != nil {
+		result.SampleSize = 0
+		result.TimedOut = true
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
 	// For small graphs or when sample size >= node count, use exact algorithm
 	if sampleSize >= n {
-		exact := network.Betweenness(g)
+		exact := exactBetweenness(g)
 		result.Scores = exact
 		result.Mode = BetweennessExact
 		result.SampleSize = n
+		result.Strategy = ""
 		result.Elapsed = time.Since(start)
 		return result
 	}
@@ -314,40 +403,155 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 	// Sample k random pivot indices
 	pivots := sampleIndices(n, sampleSize, seed)
 
-	// Compute partial betweenness from sampled pivots in parallel
-	partialBC := make([]float64, n)
-	var mu sync.Mutex
+	// Compute partial betweenness from sampled pivots in parallel. Each
+	// worker takes every workers-th pivot and sums into its own buffer.
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(pivots))
+	partials := make([][]float64, workers)
+	counts := make([]int, workers)
 	var wg sync.WaitGroup
-
-	// Limit concurrency to avoid excessive goroutines
-	sem := make(chan struct{}, runtime.NumCPU())
-
-	for _, pivot := range pivots {
+	for w := 0; w < workers; w++ {
 		wg.Add(1)
-		go func(sourceIdx int) {
+		go func(w int) {
 			defer wg.Done()
-			sem <- struct{}{} // Acquire token
-			defer func() { <-sem }()
-
 			buf := brandesPool.Get().(*brandesBuffers)
 			defer brandesPool.Put(buf)
-
-			// Compute local contribution into pooled buffers (buf.bc)
-			singleSourceBetweennessDense(adj, sourceIdx, buf)
-
-			// Merge into global result using visited nodes only.
-			mu.Lock()
-			for _, w := range buf.stack {
-				partialBC[w] += buf.bc[w]
+			partial := make([]float64, n)
+			for i := w; i < len(pivots); i += workers {
+				if ctx.Err() != nil {
+					break
+				}
+				// Compute local contribution into pooled buffers (buf.bc),
+				// then add it using visited nodes only.
+				singleSourceBetweennessDense(adj, pivots[i], buf)
+				for _, v := range buf.stack {
+					partial[v] += buf.bc[v]
+				}
+				counts[w]++
 			}
-			mu.Unlock()
-		}(pivot)
+			partials[w] = partial
+		}(w)
 	}
 	wg.Wait()
 
+	partialBC := partials[0]
+	processed := counts[0]
+	for w := 1; w < workers; w++ {
+		for i, val := range partials[w] {
+			partialBC[i] += val
+		}
+		processed += counts[w]
+	}
+
+	if processed < len(pivots) {
+		result.TimedOut = true
+		result.SampleSize = processed
+		if processed == 0 {
+			result.Elapsed = time.Since(start)
+			return result
+		}
+	}
+
 	// Scale up: BC_approx = BC_partial * (n / k)
 	// This extrapolates from the sample to the full graph
-	scale := float64(n) / float64(sampleSize)
+	scale := float64(n) / float64(processed)
+	scores := make(map[int64]float64, n)
+	for i, val := range partialBC {
+		if val == 0 {
+			continue
+		}
+		scores[idx.idxToID[i]] = val * scale
+	}
+	result.Scores = scores
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// ApproxBetweennessPairs computes approximate betweenness centrality by sampling
+// ordered source-target pairs instead of pivots.
+//
+// For each sampled pair (s, t) we run one BFS from s, then walk the shortest-path
+// DAG backwards from t to count σ_vt for every intermediate v. The pair dependency
+// δ_st(v) = σ_sv·σ_vt/σ_st is an unbiased sample of BC(v)/(n·(n-1)), so the sum is
+// scaled by n·(n-1)/k. Unreachable pairs contribute zero.
+//
+// Falls back to exact computation when k ≥ n·(n-1) (every pair would be sampled).
+func ApproxBetweennessPairs(g graph.Directed, sampleSize int, seed int64) BetweennessResult {
+	start := time.Now()
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+
+	result := BetweennessResult{
+		Scores:     make(map[int64]float64),
+		Mode:       BetweennessApproximate,
+		SampleSize: sampleSize,
+		Strategy:   BetweennessStrategyPairs,
+		TotalNodes: n,
+	}
+
+	if n < 2 {
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	totalPairs := n * (n - 1)
+	if sampleSize >= totalPairs {
+		result.Scores = exactBetweenness(g)
+		result.Mode = BetweennessExact
+		result.SampleSize = totalPairs
+		result.Strategy = ""
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+	if idx.idToIdx != nil {
+		denseIndexMapPool.Put(idx.idToIdx)
+		idx.idToIdx = nil
+	}
+
+	// Group sampled targets by source so each source needs only one BFS.
+	rng := rand.New(rand.NewSource(seed))
+	targetsBySource := make(map[int][]int)
+	for i := 0; i < sampleSize; i++ {
+		s := rng.Intn(n)
+		t := rng.Intn(n - 1)
+		if t >= s {
+			t++ // Skip s itself so (s, t) is a uniform ordered pair with s != t
+		}
+		targetsBySource[s] = append(targetsBySource[s], t)
+	}
+	sources := make([]int, 0, len(targetsBySource))
+	for s := range targetsBySource {
+		sources = append(sources, s)
+	}
+	sort.Ints(sources)
+
+	partialBC := make([]float64, n)
+	buf := brandesPool.Get().(*brandesBuffers)
+	defer brandesPool.Put(buf)
+	pathsToTarget := make([]float64, n)
+
+	for _, s := range sources {
+		singleSourceBetweennessDense(adj, s, buf)
+		for _, t := range targetsBySource[s] {
+			if buf.dist[t] < 0 {
+				continue // t unreachable from s
+			}
+			accumulatePairDependency(buf, s, t, pathsToTarget, partialBC)
+		}
+	}
+
+	scale := float64(totalPairs) / float64(sampleSize)
 	scores := make(map[int64]float64, n)
 	for i, val := range partialBC {
 		if val == 0 {
@@ -360,6 +564,31 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 	return result
 }
 
+// accumulatePairDependency adds δ_st(v) for every node strictly between s and t
+// on a shortest path, using the BFS state (sigma, dist, pred, stack) left in buf
+// by singleSourceBetweennessDense(s). pathsToTarget is scratch space of length n.
+func accumulatePairDependency(buf *brandesBuffers, s, t int, pathsToTarget, bc []float64) {
+	// Nodes in buf.stack are in non-decreasing distance order; walk them in
+	// reverse so every successor's path count is final before its predecessors.
+	for _, v := range buf.stack {
+		pathsToTarget[v] = 0
+	}
+	pathsToTarget[t] = 1
+	sigmaST := buf.sigma[t]
+	for i := len(buf.stack) - 1; i >= 0; i-- {
+		w := buf.stack[i]
+		if pathsToTarget[w] == 0 {
+			continue
+		}
+		for _, v := range buf.pred[w] {
+			pathsToTarget[v] += pathsToTarget[w]
+		}
+		if w != s && w != t && sigmaST > 0 {
+			bc[w] += buf.sigma[w] * pathsToTarget[w] / sigmaST
+		}
+	}
+}
+
 // sampleIndices returns a random sample of k indices from [0,n).
 // Uses Fisher-Yates shuffle for unbiased sampling.
 func sampleIndices(n, k int, seed int64) []int {
@@ -387,16 +616,31 @@ func sampleIndices(n, k int, seed int64) []int {
 	return shuffled[:k]
 }
 
+// exactBetweenness runs gonum's exact betweenness, using weighted shortest
+// paths when g carries edge weights.
+func exactBetweenness(g graph.Directed) map[int64]float64 {
+	if wg, ok := g.(graph.Weighted); ok {
+		return network.BetweennessWeighted(wg, path.DijkstraAllPaths(wg))
+	}
+	return network.Betweenness(g)
+}
+
 // singleSourceBetweennessDense computes the betweenness contribution from a single source index.
 // This is the core of Brandes' algorithm, run once per pivot, using dense indexing.
 //
 // The algorithm performs BFS from the source and accumulates dependency scores
-// in a reverse topological order traversal.
+// in a reverse topological order traversal. When adj carries edge weights the
+// search is Dijkstra instead, and v is a predecessor of w when
+// dist(v) + weight(v, w) == dist(w). Weights must be positive.
 func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers) {
 	nodeCount := len(adj.outgoing)
 	if nodeCount == 0 {
 		return
 	}
+	if adj.weights != nil {
+		singleSourceBetweennessWeighted(adj, sourceIdx, buf)
+		return
+	}
 
 	// Initialize buffer for this source (clears previous state while retaining capacity).
 	buf.reset(nodeCount)
@@ -404,7 +648,6 @@ func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brand
 	// Use pooled data structures (aliases for readability)
 	sigma := buf.sigma
 	dist := buf.dist
-	delta := buf.delta
 	pred := buf.pred
 
 	sigma[sourceIdx] = 1
@@ -413,10 +656,10 @@ func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brand
 	// Queue for BFS (reuse pooled slice)
 	buf.queue = append(buf.queue, sourceIdx)
 
-	// BFS phase
-	for len(buf.queue) > 0 {
-		v := buf.queue[0]
-		buf.queue = buf.queue[1:]
+	// BFS phase. Advance a head index rather than re-slicing buf.queue so the
+	// pooled backing array keeps its full capacity across sources.
+	for head := 0; head < len(buf.queue); head++ {
+		v := buf.queue[head]
 		buf.stack = append(buf.stack, v)
 
 		for _, w := range adj.outgoing[v] {
@@ -434,7 +677,57 @@ func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brand
 		}
 	}
 
-	// Accumulation phase
+	accumulateDependencies(sourceIdx, buf)
+}
+
+// singleSourceBetweennessWeighted is singleSourceBetweennessDense over
+// weighted edges. Nodes are pushed onto buf.stack as Dijkstra settles them,
+// so the stack is in non-decreasing distance order just like the BFS case.
+func singleSourceBetweennessWeighted(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers) {
+	buf.reset(len(adj.outgoing))
+
+	sigma := buf.sigma
+	dist := buf.dist
+	pred := buf.pred
+
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+	heap.Push(&buf.pq, distItem{node: sourceIdx})
+
+	for buf.pq.Len() > 0 {
+		item := heap.Pop(&buf.pq).(distItem)
+		v := item.node
+		if item.dist > dist[v] {
+			continue // Stale entry; v was already settled at a shorter distance
+		}
+		buf.stack = append(buf.stack, v)
+
+		for i, w := range adj.outgoing[v] {
+			d := dist[v] + adj.weights[v][i]
+			switch {
+			case dist[w] < 0 || d < dist[w]:
+				dist[w] = d
+				sigma[w] = sigma[v]
+				pred[w] = append(pred[w][:0], v)
+				heap.Push(&buf.pq, distItem{node: w, dist: d})
+			case d == dist[w]:
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+
+	accumulateDependencies(sourceIdx, buf)
+}
+
+// accumulateDependencies is the back-propagation phase of Brandes' algorithm:
+// it walks buf.stack in reverse, summing each node's dependency δ_s(w) into
+// buf.bc.
+func accumulateDependencies(sourceIdx int, buf *brandesBuffers) {
+	sigma := buf.sigma
+	delta := buf.delta
+	pred := buf.pred
+
 	for i := len(buf.stack) - 1; i >= 0; i-- {
 		w := buf.stack[i]
 		if w == sourceIdx {
@@ -452,6 +745,32 @@ func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brand
 	}
 }
 
+// distItem is a node and its tentative distance in the Dijkstra frontier.
+type distItem struct {
+	node int
+	dist float64
+}
+
+// distQueue is a min-heap of distItems ordered by distance, then node index
+// so that equal-distance nodes settle in a deterministic order.
+type distQueue []distItem
+
+func (q distQueue) Len() int { return len(q) }
+func (q distQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	return q[i].node < q[j].node
+}
+func (q distQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *distQueue) Push(x any)   { *q = append(*q, x.(distItem)) }
+func (q *distQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
 // RecommendSampleSize returns a recommended sample size based on graph characteristics.
 // The goal is to balance accuracy vs. speed.
 //
@@ -1,9 +1,16 @@
 package analysis
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/network"
 )
 
 func TestApproxBetweenness_SmallGraph(t *testing.T) {
@@ -58,6 +65,231 @@ func TestApproxBetweenness_LargeGraph_Approximate(t *testing.T) {
 	}
 }
 
+func TestApproxBetweenness_SameSeedReproducible(t *testing.T) {
+	// A branching graph, so the sampled pivots matter
+	issues := make([]model.Issue, 80)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("n%02d", i), Status: model.StatusOpen}
+		if i > 0 {
+			issues[i].Dependencies = []*model.Dependency{
+				{IssueID: issues[i].ID, DependsOnID: issues[(i-1)/2].ID, Type: model.DepBlocks},
+			}
+		}
+	}
+	analyzer := NewAnalyzer(issues)
+
+	for name, approx := range map[string]func(int64) BetweennessResult{
+		"pivot": func(seed int64) BetweennessResult { return ApproxBetweenness(analyzer.g, 12, seed) },
+		"pairs": func(seed int64) BetweennessResult { return ApproxBetweennessPairs(analyzer.g, 12, seed) },
+	} {
+		first := approx(99)
+		if first.Mode != BetweennessApproximate {
+			t.Fatalf("%s: expected approximate mode, got %s", name, first.Mode)
+		}
+		for run := 0; run < 3; run++ {
+			again := approx(99)
+			if fmt.Sprint(again.Scores) != fmt.Sprint(first.Scores) {
+				t.Fatalf("%s: same seed gave different scores on run %d", name, run)
+			}
+		}
+	}
+}
+
+// countdownCtx reports itself done after its first `live` Err calls.
+type countdownCtx struct {
+	context.Context
+	live atomic.Int32
+}
+
+func (c *countdownCtx) Err() error {
+	if c.live.Add(-1) < 0 {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestApproxBetweennessCtx_StopsEarlyAndRescales(t *testing.T) {
+	// Directed cycle: every pivot contributes the same total dependency,
+	// (n-1)(n-2)/2, so a correctly rescaled sum is independent of how many
+	// pivots ran.
+	const n = 40
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("c%02d", i), Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: fmt.Sprintf("c%02d", (i+1)%n), Type: model.DepBlocks}}}
+	}
+	analyzer := NewAnalyzer(issues)
+	wantSum := float64(n*(n-1)*(n-2)) / 2
+
+	// One Err call before sampling, then one per pivot: 3 pivots run
+	ctx := &countdownCtx{Context: context.Background()}
+	ctx.live.Store(4)
+	result := ApproxBetweennessCtx(ctx, analyzer.g, 20, 5)
+	if !result.TimedOut {
+		t.Fatal("expected TimedOut after the deadline passed")
+	}
+	if result.SampleSize != 3 {
+		t.Errorf("SampleSize = %d, want the 3 pivots processed", result.SampleSize)
+	}
+	sum := 0.0
+	for _, v := range result.Scores {
+		sum += v
+	}
+	if math.Abs(sum-wantSum) > 1e-6 {
+		t.Errorf("rescaled score sum = %v, want %v", sum, wantSum)
+	}
+
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result := ApproxBetweennessCtx(done, analyzer.g, 20, 5); !result.TimedOut || len(result.Scores) != 0 {
+		t.Errorf("cancelled context: TimedOut=%v with %d scores, want true and none", result.TimedOut, len(result.Scores))
+	}
+
+	if full := ApproxBetweennessCtx(context.Background(), analyzer.g, 20, 5); full.TimedOut || full.SampleSize != 20 {
+		t.Errorf("uncancelled run: TimedOut=%v SampleSize=%d", full.TimedOut, full.SampleSize)
+	}
+}
+
+func TestApproxBetweennessWorkers_ParallelMatchesSerial(t *testing.T) {
+	analyzer := NewAnalyzer(generateLatticeGraph(300))
+	serial := ApproxBetweennessWorkers(context.Background(), analyzer.g, 60, 11, 1)
+	for _, workers := range []int{0, 3, 8} {
+		parallel := ApproxBetweennessWorkers(context.Background(), analyzer.g, 60, 11, workers)
+		if parallel.SampleSize != serial.SampleSize {
+			t.Fatalf("workers=%d: SampleSize = %d, want %d", workers, parallel.SampleSize, serial.SampleSize)
+		}
+		if len(parallel.Scores) != len(serial.Scores) {
+			t.Fatalf("workers=%d: %d scores, want %d", workers, len(parallel.Scores), len(serial.Scores))
+		}
+		for id, want := range serial.Scores {
+			if got := parallel.Scores[id]; math.Abs(got-want) > 1e-9*math.Max(1, want) {
+				t.Errorf("workers=%d: score[%d] = %v, want %v", workers, id, got, want)
+			}
+		}
+
+		// A fixed worker count is reproducible bit for bit
+		again := ApproxBetweennessWorkers(context.Background(), analyzer.g, 60, 11, workers)
+		for id, v := range parallel.Scores {
+			if again.Scores[id] != v {
+				t.Fatalf("workers=%d: score[%d] changed between runs: %v vs %v", workers, id, v, again.Scores[id])
+			}
+		}
+	}
+}
+
+func TestApproxBetweenness_WeightedEdges(t *testing.T) {
+	// Diamond: top depends on fast and slow, both depend on base. With unit
+	// weights the two routes tie; weighting by the dependency's estimate
+	// sends every shortest path through fast.
+	minutes := func(m int) *int { return &m }
+	issues := []model.Issue{
+		{ID: "top", Dependencies: blockedBy("fast", "slow")},
+		{ID: "fast", EstimatedMinutes: minutes(10), Dependencies: blockedBy("base")},
+		{ID: "slow", EstimatedMinutes: minutes(90), Dependencies: blockedBy("base")},
+		{ID: "base", EstimatedMinutes: minutes(10)},
+	}
+	effort := func(_, to model.Issue) float64 { return float64(*to.EstimatedMinutes) }
+	g, ids := NewWeightedDependencyGraph(issues, effort)
+
+	unweighted := ApproxBetweenness(NewAnalyzer(issues).g, 4, 1).Scores
+	if unweighted[ids["fast"]] != 0.5 || unweighted[ids["slow"]] != 0.5 {
+		t.Fatalf("unweighted scores = %v, want fast and slow at 0.5", unweighted)
+	}
+
+	// Every pivot, so scores are exact but go through the Dijkstra pass
+	idx := buildDenseIndex(graph.NodesOf(g.Nodes()))
+	adj := buildCachedAdjacency(g, idx)
+	buf := &brandesBuffers{}
+	bc := make([]float64, len(idx.idxToID))
+	for s := range idx.idxToID {
+		singleSourceBetweennessDense(adj, s, buf)
+		for i, v := range buf.bc {
+			bc[i] += v
+		}
+	}
+	if got := bc[idx.idToIdx[ids["fast"]]]; got != 1 {
+		t.Errorf("weighted fast = %v, want 1", got)
+	}
+	if got := bc[idx.idToIdx[ids["slow"]]]; got != 0 {
+		t.Errorf("weighted slow = %v, want 0", got)
+	}
+
+	// Sampling every node falls back to gonum's weighted exact computation
+	exact := ApproxBetweenness(g, len(issues), 1)
+	if exact.Scores[ids["fast"]] != 1 || exact.Scores[ids["slow"]] != 0 {
+		t.Errorf("exact weighted scores = %v", exact.Scores)
+	}
+}
+
+func TestBetweennessNormalizedScoresComparable(t *testing.T) {
+	// Bowtie: 10 issues need the hub, which needs 10 others. Every path
+	// between the two sides runs through the hub.
+	issues := []model.Issue{{ID: "hub", Status: model.StatusOpen}}
+	for i := 0; i < 10; i++ {
+		up, down := fmt.Sprintf("up-%d", i), fmt.Sprintf("down-%d", i)
+		issues = append(issues,
+			model.Issue{ID: up, Status: model.StatusOpen},
+			model.Issue{ID: down, Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "hub", Type: model.DepBlocks}}})
+		issues[0].Dependencies = append(issues[0].Dependencies, &model.Dependency{DependsOnID: up, Type: model.DepBlocks})
+	}
+	analyzer := NewAnalyzer(issues)
+	hub := analyzer.idToNode["hub"]
+
+	exact := ApproxBetweenness(analyzer.g, len(issues), 3)
+	approx := ApproxBetweenness(analyzer.g, 12, 3)
+	if exact.Mode != BetweennessExact || approx.Mode != BetweennessApproximate {
+		t.Fatalf("modes = %s, %s", exact.Mode, approx.Mode)
+	}
+
+	top := func(scores map[int64]float64) int64 {
+		best := int64(-1)
+		for id, v := range scores {
+			if best < 0 || v > scores[best] || (v == scores[best] && id < best) {
+				best = id
+			}
+		}
+		return best
+	}
+	exactNorm, approxNorm := exact.NormalizedScores(), approx.NormalizedScores()
+	if top(exactNorm) != hub || top(approxNorm) != hub {
+		t.Errorf("top node: exact %d, approximate %d, want hub %d", top(exactNorm), top(approxNorm), hub)
+	}
+	// 10x10 paths through the hub out of (n-1)(n-2) = 20*19
+	if got, want := exactNorm[hub], 100.0/380; math.Abs(got-want) > 1e-9 {
+		t.Errorf("exact normalized hub score = %v, want %v", got, want)
+	}
+	for id, v := range approxNorm {
+		if v < 0 || v > 1 {
+			t.Errorf("normalized score for %d = %v, outside [0, 1]", id, v)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.BetweennessNormalized = true
+	stats := analyzer.AnalyzeWithConfig(cfg)
+	if got := stats.Betweenness()["hub"]; math.Abs(got-exactNorm[hub]) > 1e-9 {
+		t.Errorf("BetweennessNormalized: hub = %v, want %v", got, exactNorm[hub])
+	}
+}
+
+func TestBetweennessNormalizedScoresClampsApproximate(t *testing.T) {
+	// Five samples out of ten scale each pivot's paths by 2, which can push an
+	// estimate past the (n-1)(n-2) = 72 paths a node can actually lie on.
+	r := BetweennessResult{
+		Scores:     map[int64]float64{1: 90, 2: 36},
+		Mode:       BetweennessApproximate,
+		SampleSize: 5,
+		TotalNodes: 10,
+	}
+	norm := r.NormalizedScores()
+	if norm[1] != 1 {
+		t.Errorf("overshooting estimate normalized to %v, want clamped to 1", norm[1])
+	}
+	if norm[2] != 0.5 {
+		t.Errorf("in-range estimate normalized to %v, want 0.5", norm[2])
+	}
+}
+
 func TestApproxBetweenness_EmptyGraph(t *testing.T) {
 	issues := []model.Issue{}
 	analyzer := NewAnalyzer(issues)
@@ -174,6 +406,36 @@ func BenchmarkApproxBetweenness_500nodes_Sample50(b *testing.B) {
 	}
 }
 
+func BenchmarkApproxBetweennessWorkers_2000nodes(b *testing.B) {
+	analyzer := NewAnalyzer(generateLatticeGraph(2000))
+	for _, bc := range []struct {
+		name    string
+		workers int
+	}{{"Serial", 1}, {"GOMAXPROCS", 0}} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ApproxBetweennessWorkers(context.Background(), analyzer.g, 200, 42, bc.workers)
+			}
+		})
+	}
+}
+
+// generateLatticeGraph creates a DAG where each issue depends on the two
+// issues one and seven positions before it, giving many shortest paths
+func generateLatticeGraph(n int) []model.Issue {
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("L%d", i), Status: model.StatusOpen}
+		for _, back := range []int{1, 7} {
+			if i >= back {
+				issues[i].Dependencies = append(issues[i].Dependencies,
+					&model.Dependency{IssueID: issues[i].ID, DependsOnID: fmt.Sprintf("L%d", i-back), Type: model.DepBlocks})
+			}
+		}
+	}
+	return issues
+}
+
 // generateChainGraph creates a linear dependency chain
 func generateChainGraph(n int) []model.Issue {
 	issues := make([]model.Issue, n)
@@ -195,3 +457,155 @@ func generateChainGraph(n int) []model.Issue {
 func generateID(i int) string {
 	return string(rune('A'+i%26)) + string(rune('0'+i/26))
 }
+
+func TestApproxBetweennessPairs_ComparedToPivot(t *testing.T) {
+	// Hub-and-spoke: 30 sources all route through a two-node bottleneck to 30 sinks.
+	// Every shortest path crosses the bottleneck, so exact betweenness is known.
+	var issues []model.Issue
+	issues = append(issues,
+		model.Issue{ID: "hub-in", Status: model.StatusOpen},
+		model.Issue{ID: "hub-out", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "hub-out", DependsOnID: "hub-in", Type: model.DepBlocks},
+		}},
+	)
+	for i := 0; i < 30; i++ {
+		src := fmt.Sprintf("src-%02d", i)
+		dst := fmt.Sprintf("dst-%02d", i)
+		issues = append(issues,
+			model.Issue{ID: src, Status: model.StatusOpen},
+			model.Issue{ID: dst, Status: model.StatusOpen, Dependencies: []*model.Dependency{
+				{IssueID: dst, DependsOnID: "hub-out", Type: model.DepBlocks},
+			}},
+		)
+		issues[0].Dependencies = append(issues[0].Dependencies,
+			&model.Dependency{IssueID: "hub-in", DependsOnID: src, Type: model.DepBlocks})
+	}
+
+	analyzer := NewAnalyzer(issues)
+	exact := network.Betweenness(analyzer.g)
+
+	const samples = 40
+	pivot := ApproxBetweenness(analyzer.g, samples, 7)
+	pairs := ApproxBetweennessPairs(analyzer.g, samples*len(issues), 7)
+
+	if pivot.Strategy != BetweennessStrategyPivot {
+		t.Errorf("pivot Strategy = %q, want %q", pivot.Strategy, BetweennessStrategyPivot)
+	}
+	if pairs.Strategy != BetweennessStrategyPairs {
+		t.Errorf("pairs Strategy = %q, want %q", pairs.Strategy, BetweennessStrategyPairs)
+	}
+	if pairs.Mode != BetweennessApproximate {
+		t.Fatalf("pairs Mode = %q, want approximate", pairs.Mode)
+	}
+
+	relErr := func(approx map[int64]float64) float64 {
+		var diff, total float64
+		for id, want := range exact {
+			diff += math.Abs(approx[id] - want)
+			total += want
+		}
+		return diff / total
+	}
+
+	pivotErr := relErr(pivot.Scores)
+	pairsErr := relErr(pairs.Scores)
+	t.Logf("relative L1 error: pivot=%.3f pairs=%.3f", pivotErr, pairsErr)
+
+	if pairsErr > 0.25 {
+		t.Errorf("pair sampling error %.3f exceeds 0.25", pairsErr)
+	}
+	if pivotErr > 0.5 {
+		t.Errorf("pivot sampling error %.3f exceeds 0.5", pivotErr)
+	}
+
+	// The bottleneck must dominate under both estimators
+	hubIn := analyzer.idToNode["hub-in"]
+	for id, score := range pairs.Scores {
+		if id != hubIn && id != analyzer.idToNode["hub-out"] && score > pairs.Scores[hubIn] {
+			t.Errorf("pair sampling ranked %s above hub-in", analyzer.nodeToID[id])
+		}
+	}
+}
+
+func TestApproxBetweennessPairs_SmallGraphFallsBackToExact(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+	}
+	analyzer := NewAnalyzer(issues)
+	result := ApproxBetweennessPairs(analyzer.g, 10, 1)
+	if result.Mode != BetweennessExact {
+		t.Errorf("expected exact fallback, got %s", result.Mode)
+	}
+}
+
+// singleSourceBetweennessResliced is singleSourceBetweennessDense as it was
+// before the BFS advanced a head index: it pops by re-slicing buf.queue, so
+// the pooled queue loses capacity and is regrown. Kept as the benchmark
+// baseline.
+func singleSourceBetweennessResliced(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers) {
+	buf.reset(len(adj.outgoing))
+	sigma, dist, pred := buf.sigma, buf.dist, buf.pred
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+	buf.queue = append(buf.queue, sourceIdx)
+	for len(buf.queue) > 0 {
+		v := buf.queue[0]
+		buf.queue = buf.queue[1:]
+		buf.stack = append(buf.stack, v)
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				buf.queue = append(buf.queue, w)
+			}
+			if dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+	accumulateDependencies(sourceIdx, buf)
+}
+
+// BenchmarkSingleSourceBetweennessDense compares one Brandes pass per source
+// in the current implementation (one reused buffer; steady state should not
+// allocate) against the re-slicing baseline and against fresh buffers per
+// source. Compare allocs/op across the sub-benchmarks.
+func BenchmarkSingleSourceBetweennessDense(b *testing.B) {
+	issues := generateChainGraph(2000)
+	analyzer := NewAnalyzer(issues)
+	nodes := pooledNodesOf(analyzer.g.Nodes())
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(analyzer.g, idx)
+	n := len(nodes)
+
+	for _, bm := range []struct {
+		name  string
+		pass  func(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers)
+		fresh bool
+	}{
+		{"reused", singleSourceBetweennessDense, false},
+		{"resliced-queue", singleSourceBetweennessResliced, false},
+		{"fresh-buffers", singleSourceBetweennessDense, true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			buf := brandesPool.New().(*brandesBuffers)
+			// Warm up so first-pass buffer growth is not counted.
+			for s := 0; s < n; s++ {
+				bm.pass(adj, s, buf)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bm.fresh {
+					buf = brandesPool.New().(*brandesBuffers)
+				}
+				bm.pass(adj, i%n, buf)
+			}
+		})
+	}
+}
@@ -10,7 +10,7 @@ import (
 func createTestBuffer() *brandesBuffers {
 	return &brandesBuffers{
 		sigma:     make([]float64, 0, 256),
-		dist:      make([]int, 0, 256),
+		dist:      make([]float64, 0, 256),
 		delta:     make([]float64, 0, 256),
 		pred:      make([][]int, 0, 256),
 		queue:     make([]int, 0, 256),
@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	robotAnalysisDiskCacheVersion      = 1
+	robotAnalysisDiskCacheVersion      = 2
 	robotAnalysisDiskCacheFileName     = "analysis_cache.json"
 	robotAnalysisDiskCacheDirName      = "bv"
 	robotAnalysisDiskCacheMaxEntries   = 10
@@ -233,8 +233,13 @@ func ComputeConfigHash(config *AnalysisConfig) string {
 		return "dynamic"
 	}
 	h := sha256.New()
-	// Using %#v is stable enough for configuration struct
-	h.Write([]byte(fmt.Sprintf("%#v", *config)))
+	// Using %#v is stable enough for configuration struct; the progress
+	// callback is excluded since its address changes between runs, and the
+	// concurrency limit since it does not affect results.
+	cfg := *config
+	cfg.ProgressFunc = nil
+	cfg.MaxConcurrency = 0
+	h.Write([]byte(fmt.Sprintf("%#v", cfg)))
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
@@ -305,6 +310,7 @@ func (ca *CachedAnalyzer) Analyze() GraphStats {
 		OutDegree:         stats.OutDegree,
 		InDegree:          stats.InDegree,
 		TopologicalOrder:  stats.TopologicalOrder,
+		SCC:               stats.SCC,
 		Density:           stats.Density,
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
@@ -347,6 +353,7 @@ type graphStatsCacheBlob struct {
 	OutDegree        map[string]int `json:"out_degree"`
 	InDegree         map[string]int `json:"in_degree"`
 	TopologicalOrder []string       `json:"topological_order"`
+	SCC              [][]string     `json:"scc,omitempty"`
 	Density          float64        `json:"density"`
 	NodeCount        int            `json:"node_count"`
 	EdgeCount        int            `json:"edge_count"`
@@ -361,6 +368,7 @@ type graphStatsCacheBlob struct {
 	CoreNumber        map[string]int     `json:"core_number"`
 	Articulation      []string           `json:"articulation"`
 	Slack             map[string]float64 `json:"slack"`
+	Criticality       map[string]float64 `json:"criticality"`
 	Cycles            [][]string         `json:"cycles"`
 	Status            MetricStatus       `json:"status"`
 }
@@ -370,6 +378,7 @@ func (b graphStatsCacheBlob) toGraphStats() *GraphStats {
 		OutDegree:        b.OutDegree,
 		InDegree:         b.InDegree,
 		TopologicalOrder: b.TopologicalOrder,
+		SCC:              b.SCC,
 		Density:          b.Density,
 		NodeCount:        b.NodeCount,
 		EdgeCount:        b.EdgeCount,
@@ -386,6 +395,7 @@ func (b graphStatsCacheBlob) toGraphStats() *GraphStats {
 		criticalPathScore: b.CriticalPathScore,
 		coreNumber:        b.CoreNumber,
 		slack:             b.Slack,
+		criticality:       b.Criticality,
 		cycles:            b.Cycles,
 		status:            b.Status,
 	}
@@ -559,6 +569,7 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 		OutDegree:        stats.OutDegree,
 		InDegree:         stats.InDegree,
 		TopologicalOrder: stats.TopologicalOrder,
+		SCC:              stats.SCC,
 		Density:          stats.Density,
 		NodeCount:        stats.NodeCount,
 		EdgeCount:        stats.EdgeCount,
@@ -572,6 +583,7 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 		CriticalPathScore: stats.criticalPathScore,
 		CoreNumber:        stats.coreNumber,
 		Slack:             stats.slack,
+		Criticality:       stats.criticality,
 		Cycles:            stats.cycles,
 		Status:            stats.status,
 	}
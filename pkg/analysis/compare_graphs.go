@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// GraphComparison describes how two independent issue sets differ, e.g. a
+// feature branch's .beads against main. Unlike SnapshotDiff it compares
+// structure rather than tracking changes to issues over time.
+type GraphComparison struct {
+	OnlyInA      []string    `json:"only_in_a"`       // Issue IDs present only in a
+	OnlyInB      []string    `json:"only_in_b"`       // Issue IDs present only in b
+	EdgesOnlyInA []GraphEdge `json:"edges_only_in_a"` // Blocking dependencies only in a
+	EdgesOnlyInB []GraphEdge `json:"edges_only_in_b"` // Blocking dependencies only in b
+
+	A     GraphShape `json:"a"`
+	B     GraphShape `json:"b"`
+	Delta GraphShape `json:"delta"` // B minus A
+}
+
+// GraphEdge is a blocking dependency: From depends on To.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphShape holds the structural metrics compared by CompareGraphs.
+type GraphShape struct {
+	Nodes   int     `json:"nodes"`
+	Edges   int     `json:"edges"`
+	Cycles  int     `json:"cycles"`
+	Density float64 `json:"density"`
+	// CriticalPathLength is the number of issues on the longest dependency
+	// chain; 0 when the graph has cycles.
+	CriticalPathLength int `json:"critical_path_length"`
+}
+
+// CompareGraphs compares the dependency structure of two issue sets: which
+// issues and blocking edges appear in only one of them, and how their cycle
+// count, density and critical path length differ. All lists are sorted.
+func CompareGraphs(a, b []model.Issue) GraphComparison {
+	shapeA, edgesA := graphShape(a)
+	shapeB, edgesB := graphShape(b)
+
+	idsA := make(map[string]bool, len(a))
+	for _, issue := range a {
+		idsA[issue.ID] = true
+	}
+	idsB := make(map[string]bool, len(b))
+	for _, issue := range b {
+		idsB[issue.ID] = true
+	}
+
+	return GraphComparison{
+		OnlyInA:      sortedMissing(idsA, idsB),
+		OnlyInB:      sortedMissing(idsB, idsA),
+		EdgesOnlyInA: edgesMissing(edgesA, edgesB),
+		EdgesOnlyInB: edgesMissing(edgesB, edgesA),
+		A:            shapeA,
+		B:            shapeB,
+		Delta: GraphShape{
+			Nodes:              shapeB.Nodes - shapeA.Nodes,
+			Edges:              shapeB.Edges - shapeA.Edges,
+			Cycles:             shapeB.Cycles - shapeA.Cycles,
+			Density:            shapeB.Density - shapeA.Density,
+			CriticalPathLength: shapeB.CriticalPathLength - shapeA.CriticalPathLength,
+		},
+	}
+}
+
+// graphShape analyzes issues with only the structural metrics enabled and
+// returns them along with the set of blocking edges.
+func graphShape(issues []model.Issue) (GraphShape, map[GraphEdge]bool) {
+	an := NewAnalyzer(issues)
+
+	cfg := ConfigForSize(len(an.issueMap), an.g.Edges().Len())
+	cfg.ComputePageRank = false
+	cfg.ComputeBetweenness = false
+	cfg.ComputeEigenvector = false
+	cfg.ComputeHITS = false
+	cfg.ComputeCycles = true
+	cfg.ComputeCriticalPath = true
+	cfg.ComputeCriticality = false
+	if cfg.CyclesTimeout == 0 {
+		cfg.CyclesTimeout = DefaultConfig().CyclesTimeout
+	}
+	stats := an.AnalyzeWithConfig(cfg)
+
+	shape := GraphShape{
+		Nodes:   stats.NodeCount,
+		Edges:   stats.EdgeCount,
+		Cycles:  len(stats.Cycles()),
+		Density: stats.Density,
+	}
+	for id := range an.issueMap {
+		if l := int(stats.GetCriticalPathScore(id)); l > shape.CriticalPathLength {
+			shape.CriticalPathLength = l
+		}
+	}
+
+	edges := make(map[GraphEdge]bool)
+	it := an.g.Edges()
+	for it.Next() {
+		e := it.Edge()
+		edges[GraphEdge{From: an.nodeToID[e.From().ID()], To: an.nodeToID[e.To().ID()]}] = true
+	}
+	return shape, edges
+}
+
+// sortedMissing returns the keys of have that are not in other, sorted.
+func sortedMissing(have, other map[string]bool) []string {
+	var out []string
+	for id := range have {
+		if !other[id] {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// edgesMissing returns the edges in have that are not in other, sorted.
+func edgesMissing(have, other map[GraphEdge]bool) []GraphEdge {
+	var out []GraphEdge
+	for e := range have {
+		if !other[e] {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCompareGraphsOverlappingSets(t *testing.T) {
+
+	// main: A <- B <- C chain plus D
+	main := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blockedBy("B")},
+		{ID: "D", Status: model.StatusOpen},
+	}
+	// branch: drops D, C now depends on A directly, adds an E<->F cycle
+	branch := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: blockedBy("F")},
+		{ID: "F", Status: model.StatusOpen, Dependencies: blockedBy("E")},
+	}
+
+	cmp := CompareGraphs(main, branch)
+
+	if want := []string{"D"}; !reflect.DeepEqual(cmp.OnlyInA, want) {
+		t.Errorf("OnlyInA = %v, want %v", cmp.OnlyInA, want)
+	}
+	if want := []string{"E", "F"}; !reflect.DeepEqual(cmp.OnlyInB, want) {
+		t.Errorf("OnlyInB = %v, want %v", cmp.OnlyInB, want)
+	}
+	if want := []GraphEdge{{From: "C", To: "B"}}; !reflect.DeepEqual(cmp.EdgesOnlyInA, want) {
+		t.Errorf("EdgesOnlyInA = %v, want %v", cmp.EdgesOnlyInA, want)
+	}
+	wantB := []GraphEdge{{From: "C", To: "A"}, {From: "E", To: "F"}, {From: "F", To: "E"}}
+	if !reflect.DeepEqual(cmp.EdgesOnlyInB, wantB) {
+		t.Errorf("EdgesOnlyInB = %v, want %v", cmp.EdgesOnlyInB, wantB)
+	}
+
+	if cmp.A.Cycles != 0 || cmp.B.Cycles != 1 || cmp.Delta.Cycles != 1 {
+		t.Errorf("cycles a=%d b=%d delta=%d, want 0, 1, 1", cmp.A.Cycles, cmp.B.Cycles, cmp.Delta.Cycles)
+	}
+	if cmp.A.CriticalPathLength != 3 {
+		t.Errorf("A critical path = %d, want 3", cmp.A.CriticalPathLength)
+	}
+	if cmp.B.CriticalPathLength != 0 {
+		t.Errorf("B critical path = %d, want 0 for a cyclic graph", cmp.B.CriticalPathLength)
+	}
+	if cmp.A.Nodes != 4 || cmp.B.Nodes != 5 || cmp.Delta.Nodes != 1 {
+		t.Errorf("nodes a=%d b=%d delta=%d", cmp.A.Nodes, cmp.B.Nodes, cmp.Delta.Nodes)
+	}
+	if cmp.Delta.Density != cmp.B.Density-cmp.A.Density || cmp.B.Density <= cmp.A.Density {
+		t.Errorf("density a=%v b=%v delta=%v", cmp.A.Density, cmp.B.Density, cmp.Delta.Density)
+	}
+}
+
+func TestCompareGraphsIdentical(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	cmp := CompareGraphs(issues, issues)
+	if len(cmp.OnlyInA)+len(cmp.OnlyInB)+len(cmp.EdgesOnlyInA)+len(cmp.EdgesOnlyInB) != 0 {
+		t.Errorf("expected no differences, got %+v", cmp)
+	}
+	if cmp.Delta != (GraphShape{}) {
+		t.Errorf("expected zero delta, got %+v", cmp.Delta)
+	}
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +18,8 @@ type AnalysisConfig struct {
 	BetweennessMode        BetweennessMode // "exact", "approximate", or "skip"
 	BetweennessSampleSize  int             // Sample size for approximate mode
 	BetweennessIsApproximate bool          // True if approximation was used (set after computation)
+	BetweennessStrategy    BetweennessStrategy // Sampling estimator for approximate mode ("pivot" default, or "pairs")
+	BetweennessNormalized  bool                // Store NormalizedScores (0..1) instead of raw Brandes scores
 
 	// PageRank
 	ComputePageRank    bool
@@ -39,6 +42,97 @@ type AnalysisConfig struct {
 
 	// Critical path scoring (fast, O(V+E))
 	ComputeCriticalPath bool
+
+	// Criticality scoring (reverse reachability per node: O(V*(V+E)))
+	ComputeCriticality    bool
+	CriticalityTimeout    time.Duration
+	CriticalitySkipReason string
+
+	// MaxConcurrency bounds how many phase 2 metrics compute at once.
+	// Zero means runtime.GOMAXPROCS(0); 1 runs them one after another.
+	// Excluded from config hashing since it does not change results.
+	MaxConcurrency int
+
+	// ProgressFunc, if set, is called as each analysis stage starts and once
+	// more with ProgressStageComplete when all stages finish. Excluded from
+	// JSON and config hashing.
+	ProgressFunc ProgressFunc `json:"-"`
+}
+
+// betweennessEnabled reports whether betweenness runs: ComputeBetweenness is
+// set and BetweennessMode is not BetweennessSkip.
+func (c AnalysisConfig) betweennessEnabled() bool {
+	return c.ComputeBetweenness && c.BetweennessMode != BetweennessSkip
+}
+
+// maxConcurrency resolves MaxConcurrency, defaulting to GOMAXPROCS.
+func (c AnalysisConfig) maxConcurrency() int {
+	if c.MaxConcurrency > 0 {
+		return c.MaxConcurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// ProgressFunc receives analysis progress. stage names the metric about to be
+// computed (see the ProgressStage constants) and fraction is the share of
+// enabled stages started before it, in [0, 1]. Phase 2 stages are reported
+// from the background goroutine when using AnalyzeAsync, as each one takes a
+// slot under MaxConcurrency.
+type ProgressFunc func(stage string, fraction float64)
+
+// Analysis stages reported to ProgressFunc, in execution order.
+const (
+	ProgressStageDegrees      = "degrees"
+	ProgressStagePageRank     = "pagerank"
+	ProgressStageBetweenness  = "betweenness"
+	ProgressStageEigenvector  = "eigenvector"
+	ProgressStageHITS         = "hits"
+	ProgressStageCriticalPath = "critical_path"
+	ProgressStageCycles       = "cycles"
+	ProgressStageCriticality  = "criticality"
+	ProgressStageComplete     = "complete"
+)
+
+// progressReporter tracks completed stages and forwards them to a ProgressFunc.
+// A nil fn makes every call a no-op.
+type progressReporter struct {
+	fn    ProgressFunc
+	total int
+	done  int
+}
+
+// newProgressReporter counts the stages that will actually run for config.
+func newProgressReporter(config AnalysisConfig, hasEdges bool) *progressReporter {
+	total := 1 // degrees always run
+	for _, enabled := range []bool{
+		config.ComputePageRank,
+		config.ComputeBetweenness,
+		config.ComputeEigenvector,
+		config.ComputeHITS && hasEdges,
+		config.ComputeCriticalPath,
+		config.ComputeCycles,
+		config.ComputeCriticality,
+	} {
+		if enabled {
+			total++
+		}
+	}
+	return &progressReporter{fn: config.ProgressFunc, total: total}
+}
+
+// step reports that stage is starting and counts it as finished for the next report.
+func (p *progressReporter) step(stage string) {
+	if p.fn != nil {
+		p.fn(stage, float64(p.done)/float64(p.total))
+	}
+	p.done++
+}
+
+// complete reports that all stages have finished.
+func (p *progressReporter) complete() {
+	if p.fn != nil {
+		p.fn(ProgressStageComplete, 1)
+	}
 }
 
 // DefaultConfig returns the default analysis configuration.
@@ -61,6 +155,9 @@ func DefaultConfig() AnalysisConfig {
 
 		ComputeEigenvector:  true,
 		ComputeCriticalPath: true,
+
+		ComputeCriticality: true,
+		CriticalityTimeout: 500 * time.Millisecond,
 	}
 	return ApplyEnvOverrides(cfg)
 }
@@ -71,8 +168,10 @@ func DefaultConfig() AnalysisConfig {
 // Size tiers:
 //   - Small (<100 nodes): Full analysis with exact algorithms, generous timeouts
 //   - Medium (100-500 nodes): Exact algorithms with standard timeouts
-//   - Large (500-2000 nodes): Approximate betweenness for sparse graphs, skip for dense
-//   - XL (>2000 nodes): Approximate betweenness, skip cycles and HITS for dense graphs
+//   - Large (500-2000 nodes): Approximate betweenness for sparse graphs, skip for dense;
+//     skip criticality
+//   - XL (>2000 nodes): Approximate betweenness, skip cycles and criticality, skip HITS
+//     for dense graphs
 func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 	density := 0.0
 	if nodeCount > 1 {
@@ -100,6 +199,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 
 			ComputeEigenvector:  true,
 			ComputeCriticalPath: true,
+
+			ComputeCriticality: true,
+			CriticalityTimeout: 2 * time.Second,
 		}
 
 	case nodeCount < 500:
@@ -121,6 +223,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 
 			ComputeEigenvector:  true,
 			ComputeCriticalPath: true,
+
+			ComputeCriticality: true,
+			CriticalityTimeout: 500 * time.Millisecond,
 		}
 
 	case nodeCount < 2000:
@@ -138,6 +243,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 
 			ComputeEigenvector:  true,
 			ComputeCriticalPath: true,
+
+			ComputeCriticality:    false,
+			CriticalitySkipReason: "graph too large (>500 nodes)",
 		}
 
 		// Use approximate betweenness for large sparse graphs, skip for dense
@@ -170,6 +278,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 
 			ComputeEigenvector:  true,
 			ComputeCriticalPath: true,
+
+			ComputeCriticality:    false,
+			CriticalitySkipReason: "graph too large (>2000 nodes)",
 		}
 
 		// Only compute HITS for very sparse XL graphs
@@ -204,6 +315,9 @@ func FullAnalysisConfig() AnalysisConfig {
 
 		ComputeEigenvector:  true,
 		ComputeCriticalPath: true,
+
+		ComputeCriticality: true,
+		CriticalityTimeout: 30 * time.Second,
 	}
 	return ApplyEnvOverrides(cfg)
 }
@@ -236,6 +350,12 @@ func (c AnalysisConfig) SkippedMetrics() []SkippedMetric {
 			Reason: c.CyclesSkipReason,
 		})
 	}
+	if !c.ComputeCriticality {
+		skipped = append(skipped, SkippedMetric{
+			Name:   "Criticality",
+			Reason: c.CriticalitySkipReason,
+		})
+	}
 
 	return skipped
 }
@@ -257,7 +377,7 @@ const (
 //
 // Supported:
 //   - BV_SKIP_PHASE2=1: skip expensive Phase 2 metrics (PageRank, Betweenness, HITS, Cycles,
-//     Eigenvector, Critical Path). (k-core/articulation/slack remain enabled.)
+//     Eigenvector, Critical Path, Criticality). (k-core/articulation/slack remain enabled.)
 //   - BV_PHASE2_TIMEOUT_S=N: override per-metric timeouts to N seconds (must be >0).
 func ApplyEnvOverrides(cfg AnalysisConfig) AnalysisConfig {
 	if envBool(EnvSkipPhase2) {
@@ -276,6 +396,9 @@ func ApplyEnvOverrides(cfg AnalysisConfig) AnalysisConfig {
 
 		cfg.ComputeEigenvector = false
 		cfg.ComputeCriticalPath = false
+
+		cfg.ComputeCriticality = false
+		cfg.CriticalitySkipReason = "BV_SKIP_PHASE2 set"
 	}
 
 	if seconds, ok := envPositiveInt(EnvPhase2TimeoutSeconds); ok {
@@ -292,6 +415,9 @@ func ApplyEnvOverrides(cfg AnalysisConfig) AnalysisConfig {
 		if cfg.ComputeCycles {
 			cfg.CyclesTimeout = timeout
 		}
+		if cfg.ComputeCriticality {
+			cfg.CriticalityTimeout = timeout
+		}
 	}
 
 	return cfg
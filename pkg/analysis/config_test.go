@@ -1,8 +1,11 @@
 package analysis
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 func TestConfigForSize_SmallGraph(t *testing.T) {
@@ -153,6 +156,7 @@ func TestSkippedMetrics(t *testing.T) {
 		ComputeHITS:           true,
 		ComputeCycles:         false,
 		CyclesSkipReason:      "cycles disabled",
+		ComputeCriticality:    true,
 	}
 
 	skipped := cfg.SkippedMetrics()
@@ -255,3 +259,53 @@ func TestDefaultConfig_EnvPhase2TimeoutInvalidIgnored(t *testing.T) {
 			EnvPhase2TimeoutSeconds, cfg.BetweennessTimeout, cfg.PageRankTimeout, cfg.HITSTimeout, cfg.CyclesTimeout)
 	}
 }
+
+func TestProgressFuncReportsStagesInOrder(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+
+	var stages []string
+	var fractions []float64
+	cfg := FullAnalysisConfig()
+	cfg.ProgressFunc = func(stage string, fraction float64) {
+		stages = append(stages, stage)
+		fractions = append(fractions, fraction)
+	}
+
+	NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+
+	want := []string{
+		ProgressStageDegrees,
+		ProgressStagePageRank,
+		ProgressStageBetweenness,
+		ProgressStageEigenvector,
+		ProgressStageHITS,
+		ProgressStageCriticalPath,
+		ProgressStageCycles,
+		ProgressStageCriticality,
+		ProgressStageComplete,
+	}
+	if strings.Join(stages, ",") != strings.Join(want, ",") {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+	for i := 1; i < len(fractions); i++ {
+		if fractions[i] < fractions[i-1] {
+			t.Errorf("fractions not monotonic: %v", fractions)
+		}
+	}
+	if fractions[0] != 0 || fractions[len(fractions)-1] != 1 {
+		t.Errorf("fractions should span 0..1, got %v", fractions)
+	}
+}
+
+func TestProgressFuncExcludedFromConfigHash(t *testing.T) {
+	cfg := DefaultConfig()
+	before := ComputeConfigHash(&cfg)
+	cfg.ProgressFunc = func(string, float64) {}
+	if after := ComputeConfigHash(&cfg); after != before {
+		t.Errorf("config hash changed when ProgressFunc set: %s != %s", after, before)
+	}
+}
@@ -0,0 +1,97 @@
+package analysis
+
+import "context"
+
+// Edge convention: an edge u -> v means "u depends on v" (v blocks u). Several
+// metrics read differently depending on which way you look at that edge, so
+// the legend below spells out what a high value means for each one.
+var interpretationLegend = map[string]string{
+	"in_degree":     "High = many issues directly depend on this one (it blocks them).",
+	"out_degree":    "High = this issue has many prerequisites (it is blocked by them).",
+	"pagerank":      "High = many dependency chains lead into this issue; it is a foundational blocker.",
+	"betweenness":   "High = this issue sits on many shortest paths between others; a bottleneck or bridge.",
+	"eigenvector":   "High = depended on by other heavily depended-on issues.",
+	"hubs":          "High = depends on many strong authorities (an aggregator, epic-like).",
+	"authorities":   "High = depended on by many strong hubs (a core prerequisite).",
+	"critical_path": "High = deep in the chain: the longest run of dependents stacked on top of this issue.",
+	"slack":         "0 = on the longest chain (no schedule flexibility); higher = can be delayed.",
+	"core_number":   "High = embedded in a densely connected cluster (undirected view).",
+	"criticality":   "High = many issues depend on this one, directly and transitively; hard to replace.",
+}
+
+// InterpretationLegend maps each metric name to what a high value means under
+// the analyzer's edge convention. The returned map is a copy.
+func (s *GraphStats) InterpretationLegend() map[string]string {
+	legend := make(map[string]string, len(interpretationLegend))
+	for k, v := range interpretationLegend {
+		legend[k] = v
+	}
+	return legend
+}
+
+// computeCriticality scores each issue by how much depends on it: the average
+// of its normalized in-degree (direct dependents) and its normalized reverse
+// reachability (every issue that transitively depends on it). Unlike PageRank
+// or critical-path height there is no direction ambiguity: high always means
+// "things depend on me". It reports false if ctx ends first.
+func (a *Analyzer) computeCriticality(ctx context.Context) (map[string]float64, bool) {
+	nodes := a.g.Nodes()
+	ids := make([]int64, 0, nodes.Len())
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+
+	inDegree := make(map[int64]int, len(ids))
+	reach := make(map[int64]int, len(ids))
+	maxIn, maxReach := 0, 0
+
+	visited := make(map[int64]int64, len(ids))
+	var queue []int64
+	for _, root := range ids {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		inDegree[root] = a.g.To(root).Len()
+		if inDegree[root] > maxIn {
+			maxIn = inDegree[root]
+		}
+
+		// BFS over dependents; the visited stamp is the root ID + 1 so the
+		// map never needs clearing between roots.
+		stamp := root + 1
+		visited[root] = stamp
+		queue = append(queue[:0], root)
+		count := 0
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			to := a.g.To(cur)
+			for to.Next() {
+				nid := to.Node().ID()
+				if visited[nid] == stamp {
+					continue
+				}
+				visited[nid] = stamp
+				count++
+				queue = append(queue, nid)
+			}
+		}
+		reach[root] = count
+		if count > maxReach {
+			maxReach = count
+		}
+	}
+
+	scores := make(map[string]float64, len(ids))
+	for _, nid := range ids {
+		var score float64
+		if maxIn > 0 {
+			score += 0.5 * float64(inDegree[nid]) / float64(maxIn)
+		}
+		if maxReach > 0 {
+			score += 0.5 * float64(reach[nid]) / float64(maxReach)
+		}
+		scores[a.nodeToID[nid]] = score
+	}
+	return scores, true
+}
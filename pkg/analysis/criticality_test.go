@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCriticalityScoreRanking(t *testing.T) {
+	dep := func(from, to string) model.Issue {
+		return model.Issue{ID: from, Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: from, DependsOnID: to, Type: model.DepBlocks},
+		}}
+	}
+	issues := []model.Issue{
+		// H is directly depended on by three issues.
+		{ID: "H", Status: model.StatusOpen},
+		dep("A", "H"), dep("B", "H"), dep("C", "H"),
+		dep("X", "A"),
+		// R has one direct dependent but a long chain stacked on it.
+		{ID: "R", Status: model.StatusOpen},
+		dep("S", "R"), dep("T", "S"), dep("U", "T"), dep("V", "U"),
+	}
+
+	stats := NewAnalyzer(issues).Analyze()
+	scores := stats.CriticalityScore()
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	want := []string{"H", "R", "S", "T", "A", "U"}
+	for i, id := range want {
+		if ids[i] != id {
+			t.Fatalf("ranking = %v, want prefix %v", ids, want)
+		}
+	}
+	if math.Abs(scores["H"]-1.0) > 1e-9 {
+		t.Errorf("H criticality = %f, want 1.0", scores["H"])
+	}
+	for _, leaf := range []string{"B", "C", "X", "V"} {
+		if scores[leaf] != 0 {
+			t.Errorf("%s has no dependents, criticality = %f, want 0", leaf, scores[leaf])
+		}
+	}
+	if got := stats.GetCriticalityScore("R"); got != scores["R"] {
+		t.Errorf("GetCriticalityScore(R) = %f, want %f", got, scores["R"])
+	}
+}
+
+func TestInterpretationLegendCoversCriticality(t *testing.T) {
+	stats := NewAnalyzer(nil).Analyze()
+	legend := stats.InterpretationLegend()
+	for _, key := range []string{"in_degree", "out_degree", "pagerank", "critical_path", "criticality"} {
+		if legend[key] == "" {
+			t.Errorf("legend missing %q", key)
+		}
+	}
+	legend["criticality"] = "mutated"
+	if stats.InterpretationLegend()["criticality"] == "mutated" {
+		t.Error("InterpretationLegend should return a copy")
+	}
+}
+
+func TestCriticalityFollowsConfig(t *testing.T) {
+	an := NewAnalyzer(generateChainGraph(10000))
+
+	stats := an.AnalyzeWithConfig(AnalysisConfig{})
+	if got := stats.CriticalityScore(); len(got) != 0 {
+		t.Errorf("criticality computed with an empty config: %d scores", len(got))
+	}
+	if state := stats.Status().Criticality.State; state != "skipped" {
+		t.Errorf("status = %q, want skipped", state)
+	}
+
+	for _, n := range []int{500, 5000} {
+		cfg := ConfigForSize(n, n)
+		if cfg.ComputeCriticality || cfg.CriticalitySkipReason == "" {
+			t.Errorf("ConfigForSize(%d) should skip criticality with a reason, got %+v", n, cfg)
+		}
+	}
+
+	cfg := AnalysisConfig{ComputeCriticality: true, CriticalityTimeout: time.Millisecond}
+	stats = an.AnalyzeWithConfig(cfg)
+	if state := stats.Status().Criticality.State; state != "timeout" {
+		t.Errorf("status = %q, want timeout on a 10k chain with a 1ms budget", state)
+	}
+}
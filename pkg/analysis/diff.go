@@ -1,8 +1,13 @@
 package analysis
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -51,6 +56,48 @@ func NewSnapshotAt(issues []model.Issue, timestamp time.Time, revision string) *
 	return snap
 }
 
+// SnapshotFormatVersion is the version written by Snapshot.Save. Adding
+// fields does not require a bump since unknown fields are ignored on load;
+// bump it only when existing fields change meaning.
+const SnapshotFormatVersion = 1
+
+// snapshotFile is the on-disk form of a Snapshot. Stats and counts are derived
+// from the issues, so they are recomputed on load rather than stored.
+type snapshotFile struct {
+	Version   int           `json:"version"`
+	Timestamp time.Time     `json:"timestamp"`
+	Revision  string        `json:"revision,omitempty"`
+	Issues    []model.Issue `json:"issues"`
+}
+
+// Save writes the snapshot as version-tagged JSON that LoadSnapshot can read
+// back, so diffs can span process restarts.
+func (s *Snapshot) Save(w io.Writer) error {
+	file := snapshotFile{
+		Version:   SnapshotFormatVersion,
+		Timestamp: s.Timestamp,
+		Revision:  s.Revision,
+		Issues:    s.Issues,
+	}
+	if err := json.NewEncoder(w).Encode(file); err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot written by Snapshot.Save and recomputes its
+// stats and counts. Files with a missing or unknown version are rejected.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("parsing snapshot: %w", err)
+	}
+	if file.Version != SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (this build reads version %d)", file.Version, SnapshotFormatVersion)
+	}
+	return NewSnapshotAt(file.Issues, file.Timestamp, file.Revision), nil
+}
+
 // computeCounts calculates issue counts by status
 func (s *Snapshot) computeCounts() {
 	s.TotalCount = len(s.Issues)
@@ -82,6 +129,11 @@ type SnapshotDiff struct {
 	ReopenedIssues []model.Issue   `json:"reopened_issues"` // Status changed from closed to open
 	ModifiedIssues []ModifiedIssue `json:"modified_issues"` // Changed between snapshots
 
+	// StatusTransitions maps every issue present in both snapshots whose
+	// status differs to its transition, including closes and reopens that
+	// are not listed in ModifiedIssues.
+	StatusTransitions map[string]StatusTransition `json:"status_transitions,omitempty"`
+
 	// Graph changes
 	NewCycles      [][]string `json:"new_cycles"`      // Cycles appearing in To
 	ResolvedCycles [][]string `json:"resolved_cycles"` // Cycles resolved (were in From, not in To)
@@ -91,15 +143,47 @@ type SnapshotDiff struct {
 
 	// Summary statistics
 	Summary DiffSummary `json:"summary"`
+
+	// Partial is set when CompareSnapshotsContext was cancelled before every
+	// phase ran; the lists hold only what was found up to that point.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // ModifiedIssue captures what changed in an issue
 type ModifiedIssue struct {
-	IssueID  string        `json:"issue_id"`
-	Title    string        `json:"title"`
-	Changes  []FieldChange `json:"changes"`
-	OldIssue model.Issue   `json:"-"` // Full old state (not serialized to keep diff concise)
-	NewIssue model.Issue   `json:"-"` // Full new state
+	IssueID          string             `json:"issue_id"`
+	Title            string             `json:"title"`
+	Changes          []FieldChange      `json:"changes"`
+	StatusTransition *StatusTransition  `json:"status_transition,omitempty"` // Set when status differs; "status" stays in Changes
+	AddedDeps        []model.Dependency `json:"added_deps,omitempty"`        // Dependencies only in the new state
+	RemovedDeps      []model.Dependency `json:"removed_deps,omitempty"`      // Dependencies only in the old state
+	RetypedDeps      []DependencyRetype `json:"retyped_deps,omitempty"`      // Same target, different dependency type
+	OldIssue         model.Issue        `json:"-"`                           // Full old state (not serialized to keep diff concise)
+	NewIssue         model.Issue        `json:"-"`                           // Full new state
+}
+
+// StatusTransition is a typed status change between snapshots
+type StatusTransition struct {
+	From model.Status `json:"from"`
+	To   model.Status `json:"to"`
+}
+
+// Completed reports whether the issue went from any open state to closed.
+func (t StatusTransition) Completed() bool {
+	return t.From != model.StatusClosed && t.To == model.StatusClosed
+}
+
+// Reopened reports whether the issue went from closed back to an open state.
+func (t StatusTransition) Reopened() bool {
+	return t.From == model.StatusClosed && t.To != model.StatusClosed
+}
+
+// DependencyRetype is a dependency whose target stayed the same but whose
+// type changed, e.g. related -> blocks
+type DependencyRetype struct {
+	DependsOnID string               `json:"depends_on_id"`
+	From        model.DependencyType `json:"from"`
+	To          model.DependencyType `json:"to"`
 }
 
 // FieldChange describes a single field change
@@ -138,6 +222,53 @@ type DiffSummary struct {
 
 // CompareSnapshots computes the diff between two snapshots
 func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
+	diff, _ := CompareSnapshotsContext(context.Background(), from, to)
+	return diff
+}
+
+// diffCancelCheckInterval is how many issues the per-issue phase of
+// CompareSnapshotsContext compares between cancellation checks.
+const diffCancelCheckInterval = 256
+
+// CompareSnapshotsContext is CompareSnapshots with cancellation. The context is
+// checked between phases (issue set, per-issue fields and dependencies,
+// cycles, metrics) and periodically during the per-issue phase. If it is
+// cancelled, the diff so far is returned with Partial set, alongside ctx.Err().
+// Summary and sorting are applied to partial results too.
+func CompareSnapshotsContext(ctx context.Context, from, to *Snapshot) (*SnapshotDiff, error) {
+	return compareSnapshots(ctx, from, to, nil)
+}
+
+// diffFields are the field names detectChanges reports, in report order.
+var diffFields = []string{
+	"title", "status", "priority", "assignee", "type",
+	"description", "design", "acceptance_criteria", "notes",
+	"dependencies", "labels",
+}
+
+// CompareSnapshotsFiltered is CompareSnapshots restricted to the named fields
+// (see FieldChange.Field), so an issue whose only changes are in other fields
+// is left out of ModifiedIssues. AddedDeps/RemovedDeps/RetypedDeps are only
+// filled in when "dependencies" is included. New, removed, closed and reopened
+// issues are reported regardless. An empty list compares every field; an
+// unknown name is an error.
+func CompareSnapshotsFiltered(from, to *Snapshot, fields []string) (*SnapshotDiff, error) {
+	var only map[string]bool
+	if len(fields) > 0 {
+		only = make(map[string]bool, len(fields))
+		for _, field := range fields {
+			if !slices.Contains(diffFields, field) {
+				return nil, fmt.Errorf("unknown diff field %q (known fields: %s)", field, strings.Join(diffFields, ", "))
+			}
+			only[field] = true
+		}
+	}
+	return compareSnapshots(context.Background(), from, to, only)
+}
+
+// compareSnapshots implements CompareSnapshotsContext; a non-nil only limits
+// field changes to the fields it contains.
+func compareSnapshots(ctx context.Context, from, to *Snapshot, only map[string]bool) (*SnapshotDiff, error) {
 	diff := &SnapshotDiff{
 		FromTimestamp: from.Timestamp,
 		ToTimestamp:   to.Timestamp,
@@ -145,6 +276,26 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 		ToRevision:    to.Revision,
 	}
 
+	finish := func(err error) (*SnapshotDiff, error) {
+		diff.Partial = err != nil
+
+		// Calculate summary
+		diff.Summary = calculateSummary(diff)
+
+		// Sort lists for consistent output
+		sortIssuesByID(diff.NewIssues)
+		sortIssuesByID(diff.ClosedIssues)
+		sortIssuesByID(diff.RemovedIssues)
+		sortIssuesByID(diff.ReopenedIssues)
+		sortModifiedByID(diff.ModifiedIssues)
+
+		return diff, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return finish(err)
+	}
+
 	// Build issue maps for quick lookup
 	fromMap := make(map[string]model.Issue)
 	for _, issue := range from.Issues {
@@ -156,16 +307,54 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 		toMap[issue.ID] = issue
 	}
 
-	// Find new, removed, closed, reopened, and modified issues
+	// Phase 1: new and removed issues
+	for id, toIssue := range toMap {
+		if _, existed := fromMap[id]; !existed {
+			diff.NewIssues = append(diff.NewIssues, toIssue)
+		}
+	}
+	for id, fromIssue := range fromMap {
+		if _, exists := toMap[id]; !exists {
+			diff.RemovedIssues = append(diff.RemovedIssues, fromIssue)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return finish(err)
+	}
+
+	// Phase 2: closed, reopened, and modified issues (fields and dependencies)
+	compared := 0
 	for id, toIssue := range toMap {
 		fromIssue, existed := fromMap[id]
 		if !existed {
-			diff.NewIssues = append(diff.NewIssues, toIssue)
 			continue
 		}
+		compared++
+		if compared%diffCancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return finish(err)
+			}
+		}
 
 		// Compute full change set once to reuse below.
 		changes := detectChanges(fromIssue, toIssue)
+		if only != nil {
+			changes = slices.DeleteFunc(changes, func(c FieldChange) bool { return !only[c.Field] })
+		}
+
+		var transition *StatusTransition
+		if fromIssue.Status != toIssue.Status {
+			transition = &StatusTransition{From: fromIssue.Status, To: toIssue.Status}
+			if diff.StatusTransitions == nil {
+				diff.StatusTransitions = make(map[string]StatusTransition)
+			}
+			diff.StatusTransitions[id] = *transition
+		}
+		var addedDeps, removedDeps []model.Dependency
+		var retypedDeps []DependencyRetype
+		if only == nil || only["dependencies"] {
+			addedDeps, removedDeps, retypedDeps = diffDependencies(fromIssue.Dependencies, toIssue.Dependencies)
+		}
 
 		// Check for status changes
 		isStatusChange := false
@@ -191,48 +380,45 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 			}
 			if len(nonStatusChanges) > 0 {
 				diff.ModifiedIssues = append(diff.ModifiedIssues, ModifiedIssue{
-					IssueID:  id,
-					Title:    toIssue.Title,
-					Changes:  nonStatusChanges,
-					OldIssue: fromIssue,
-					NewIssue: toIssue,
+					IssueID:          id,
+					Title:            toIssue.Title,
+					Changes:          nonStatusChanges,
+					StatusTransition: transition,
+					AddedDeps:        addedDeps,
+					RemovedDeps:      removedDeps,
+					RetypedDeps:      retypedDeps,
+					OldIssue:         fromIssue,
+					NewIssue:         toIssue,
 				})
 			}
 		} else if len(changes) > 0 {
 			diff.ModifiedIssues = append(diff.ModifiedIssues, ModifiedIssue{
-				IssueID:  id,
-				Title:    toIssue.Title,
-				Changes:  changes,
-				OldIssue: fromIssue,
-				NewIssue: toIssue,
+				IssueID:          id,
+				Title:            toIssue.Title,
+				Changes:          changes,
+				StatusTransition: transition,
+				AddedDeps:        addedDeps,
+				RemovedDeps:      removedDeps,
+				RetypedDeps:      retypedDeps,
+				OldIssue:         fromIssue,
+				NewIssue:         toIssue,
 			})
 		}
 	}
-
-	// Find removed issues
-	for id, fromIssue := range fromMap {
-		if _, exists := toMap[id]; !exists {
-			diff.RemovedIssues = append(diff.RemovedIssues, fromIssue)
-		}
+	if err := ctx.Err(); err != nil {
+		return finish(err)
 	}
 
-	// Compare cycles
+	// Phase 3: compare cycles
 	diff.NewCycles, diff.ResolvedCycles = compareCycles(from.Stats, to.Stats)
+	if err := ctx.Err(); err != nil {
+		return finish(err)
+	}
 
-	// Calculate metric deltas
+	// Phase 4: calculate metric deltas
 	diff.MetricDeltas = calculateMetricDeltas(from, to)
 
-	// Calculate summary
-	diff.Summary = calculateSummary(diff)
-
-	// Sort lists for consistent output
-	sortIssuesByID(diff.NewIssues)
-	sortIssuesByID(diff.ClosedIssues)
-	sortIssuesByID(diff.RemovedIssues)
-	sortIssuesByID(diff.ReopenedIssues)
-	sortModifiedByID(diff.ModifiedIssues)
-
-	return diff
+	return finish(nil)
 }
 
 // detectChanges identifies what fields changed between two issues
@@ -386,6 +572,11 @@ func normalizeCycle(cycle []string) string {
 		return ""
 	}
 
+	// Drop the closing repeat so [a b a] and [b a b] share a key
+	if len(cycle) > 1 && cycle[0] == cycle[len(cycle)-1] {
+		cycle = cycle[:len(cycle)-1]
+	}
+
 	// Find the smallest element to start
 	minIdx := 0
 	for i, id := range cycle {
@@ -471,6 +662,67 @@ func (d *SnapshotDiff) HasSignificantChanges() bool {
 		d.Summary.HealthTrend == "degrading"
 }
 
+// Reopened returns the sorted IDs of issues that went from closed back to an
+// open state.
+func (d *SnapshotDiff) Reopened() []string {
+	var ids []string
+	for id, t := range d.StatusTransitions {
+		if t.Reopened() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DiffStats holds headline numbers for a diff
+type DiffStats struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+	Closed   int `json:"closed"`
+	Reopened int `json:"reopened"`
+
+	// ModifiedByField counts issues per changed field name (see
+	// FieldChange.Field). "status" counts every status transition, including
+	// closes and reopens that ModifiedIssues leaves out.
+	ModifiedByField map[string]int `json:"modified_by_field"`
+
+	// NetOpenDelta is new open issues minus newly closed issues, the burndown
+	// delta for the window.
+	NetOpenDelta int `json:"net_open_delta"`
+}
+
+// Stats computes DiffStats from the diff's issue lists
+func (d *SnapshotDiff) Stats() DiffStats {
+	stats := DiffStats{
+		Added:           len(d.NewIssues),
+		Removed:         len(d.RemovedIssues),
+		Modified:        len(d.ModifiedIssues),
+		Closed:          len(d.ClosedIssues),
+		Reopened:        len(d.ReopenedIssues),
+		ModifiedByField: make(map[string]int),
+	}
+	for _, m := range d.ModifiedIssues {
+		for _, c := range m.Changes {
+			if c.Field != "status" {
+				stats.ModifiedByField[c.Field]++
+			}
+		}
+	}
+	if len(d.StatusTransitions) > 0 {
+		stats.ModifiedByField["status"] = len(d.StatusTransitions)
+	}
+
+	for _, issue := range d.NewIssues {
+		if issue.Status != model.StatusClosed {
+			stats.NetOpenDelta++
+		}
+	}
+	stats.NetOpenDelta -= len(d.ClosedIssues)
+	return stats
+}
+
 // calculateSummary generates summary statistics
 func calculateSummary(diff *SnapshotDiff) DiffSummary {
 	summary := DiffSummary{
@@ -543,6 +795,80 @@ func dependencySet(deps []*model.Dependency) map[string]bool {
 	return set
 }
 
+// diffDependencies matches dependencies by DependsOnID. For each target, types
+// present on only one side are paired off in type order as retypes; any left
+// over are additions or removals. Results are ordered by target, then type.
+func diffDependencies(from, to []*model.Dependency) (added, removed []model.Dependency, retyped []DependencyRetype) {
+	fromByTarget := dependenciesByTarget(from)
+	toByTarget := dependenciesByTarget(to)
+
+	targets := make([]string, 0, len(fromByTarget)+len(toByTarget))
+	for target := range fromByTarget {
+		targets = append(targets, target)
+	}
+	for target := range toByTarget {
+		if _, ok := fromByTarget[target]; !ok {
+			targets = append(targets, target)
+		}
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		oldDeps, newDeps := fromByTarget[target], toByTarget[target]
+		var gone, fresh []*model.Dependency
+		for _, dep := range oldDeps {
+			if !hasDependencyType(newDeps, dep.Type) {
+				gone = append(gone, dep)
+			}
+		}
+		for _, dep := range newDeps {
+			if !hasDependencyType(oldDeps, dep.Type) {
+				fresh = append(fresh, dep)
+			}
+		}
+
+		paired := min(len(gone), len(fresh))
+		for i := 0; i < paired; i++ {
+			retyped = append(retyped, DependencyRetype{DependsOnID: target, From: gone[i].Type, To: fresh[i].Type})
+		}
+		for _, dep := range gone[paired:] {
+			removed = append(removed, *dep)
+		}
+		for _, dep := range fresh[paired:] {
+			added = append(added, *dep)
+		}
+	}
+	return added, removed, retyped
+}
+
+// dependenciesByTarget groups deps by DependsOnID, one entry per type, sorted
+// by type. Nil and target-less entries are skipped as in dependencySet.
+func dependenciesByTarget(deps []*model.Dependency) map[string][]*model.Dependency {
+	byTarget := make(map[string][]*model.Dependency)
+	for _, dep := range deps {
+		if dep == nil || dep.DependsOnID == "" {
+			continue
+		}
+		if hasDependencyType(byTarget[dep.DependsOnID], dep.Type) {
+			continue
+		}
+		byTarget[dep.DependsOnID] = append(byTarget[dep.DependsOnID], dep)
+	}
+	for _, group := range byTarget {
+		sort.Slice(group, func(i, j int) bool { return group[i].Type < group[j].Type })
+	}
+	return byTarget
+}
+
+func hasDependencyType(deps []*model.Dependency, t model.DependencyType) bool {
+	for _, dep := range deps {
+		if dep.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 func stringSet(strs []string) map[string]bool {
 	set := make(map[string]bool)
 	for _, s := range strs {
@@ -1,6 +1,11 @@
 package analysis
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,6 +50,78 @@ func TestNewSnapshotAt(t *testing.T) {
 	}
 }
 
+func TestCompareSnapshots_DiffWindow(t *testing.T) {
+	issues := []model.Issue{{ID: "ISSUE-1", Title: "First", Status: model.StatusOpen}}
+	nine := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	ten := nine.Add(time.Hour)
+
+	diff := CompareSnapshots(NewSnapshotAt(issues, nine, "r1"), NewSnapshotAt(issues, ten, "r2"))
+
+	if !diff.FromTimestamp.Equal(nine) || !diff.ToTimestamp.Equal(ten) {
+		t.Errorf("diff window = %v..%v, want %v..%v", diff.FromTimestamp, diff.ToTimestamp, nine, ten)
+	}
+	if diff.FromRevision != "r1" || diff.ToRevision != "r2" {
+		t.Errorf("revisions = %q..%q, want r1..r2", diff.FromRevision, diff.ToRevision)
+	}
+
+	before := time.Now()
+	if snap := NewSnapshot(issues); snap.Timestamp.Before(before) {
+		t.Errorf("NewSnapshot timestamp %v predates construction at %v", snap.Timestamp, before)
+	}
+}
+
+func TestSnapshotSaveLoadRoundTrip(t *testing.T) {
+	fromIssues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "A", DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Title: "Second", Status: model.StatusClosed},
+	}
+	toIssues := []model.Issue{
+		{ID: "A", Title: "First", Status: model.StatusClosed},
+		{ID: "C", Title: "Third", Status: model.StatusOpen},
+	}
+	ts := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	from := NewSnapshotAt(fromIssues, ts, "abc123")
+	to := NewSnapshotAt(toIssues, ts.Add(time.Hour), "def456")
+
+	var buf bytes.Buffer
+	if err := from.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if !loaded.Timestamp.Equal(ts) || loaded.Revision != "abc123" || loaded.TotalCount != 2 || loaded.Stats == nil {
+		t.Fatalf("loaded snapshot = %+v", loaded)
+	}
+
+	want, err := json.Marshal(CompareSnapshots(from, to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(CompareSnapshots(loaded, to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("diff from loaded snapshot differs:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestLoadSnapshotRejectsUnknownVersion(t *testing.T) {
+	for _, input := range []string{
+		`{"version": 99, "issues": []}`,
+		`{"issues": []}`,
+	} {
+		_, err := LoadSnapshot(strings.NewReader(input))
+		if err == nil || !strings.Contains(err.Error(), "unsupported snapshot version") {
+			t.Errorf("LoadSnapshot(%s) error = %v, want unsupported version", input, err)
+		}
+	}
+}
+
 func TestCompareSnapshots_NewIssues(t *testing.T) {
 	fromIssues := []model.Issue{
 		{ID: "ISSUE-1", Title: "First", Status: model.StatusOpen},
@@ -127,6 +204,195 @@ func TestCompareSnapshots_ReopenedIssues(t *testing.T) {
 	}
 }
 
+func TestCompareSnapshots_StatusTransitions(t *testing.T) {
+	fromIssues := []model.Issue{
+		{ID: "done", Title: "Done", Status: model.StatusOpen},
+		{ID: "back", Title: "Back", Status: model.StatusClosed},
+		{ID: "back-edited", Title: "Old title", Status: model.StatusClosed},
+		{ID: "started", Title: "Started", Status: model.StatusOpen},
+		{ID: "same", Title: "Same", Status: model.StatusOpen},
+	}
+	toIssues := []model.Issue{
+		{ID: "done", Title: "Done", Status: model.StatusClosed},
+		{ID: "back", Title: "Back", Status: model.StatusOpen},
+		{ID: "back-edited", Title: "New title", Status: model.StatusInProgress},
+		{ID: "started", Title: "Started", Status: model.StatusInProgress},
+		{ID: "same", Title: "Same", Status: model.StatusOpen},
+	}
+
+	diff := CompareSnapshots(NewSnapshot(fromIssues), NewSnapshot(toIssues))
+
+	if len(diff.StatusTransitions) != 4 {
+		t.Fatalf("expected 4 status transitions, got %v", diff.StatusTransitions)
+	}
+	if tr := diff.StatusTransitions["done"]; !tr.Completed() || tr.Reopened() {
+		t.Errorf("done: %+v should be a completion", tr)
+	}
+	if tr := diff.StatusTransitions["started"]; tr.Completed() || tr.Reopened() {
+		t.Errorf("started: %+v should be neither completion nor reopen", tr)
+	}
+	if got := diff.Reopened(); len(got) != 2 || got[0] != "back" || got[1] != "back-edited" {
+		t.Errorf("Reopened() = %v, want [back back-edited]", got)
+	}
+
+	for _, m := range diff.ModifiedIssues {
+		switch m.IssueID {
+		case "started":
+			// Generic entry kept for backward compatibility
+			if len(m.Changes) != 1 || m.Changes[0].Field != "status" {
+				t.Errorf("started changes = %+v, want the generic status entry", m.Changes)
+			}
+			if m.StatusTransition == nil || m.StatusTransition.To != model.StatusInProgress {
+				t.Errorf("started transition = %+v", m.StatusTransition)
+			}
+		case "back-edited":
+			if m.StatusTransition == nil || !m.StatusTransition.Reopened() {
+				t.Errorf("back-edited transition = %+v, want a reopen", m.StatusTransition)
+			}
+		}
+	}
+}
+
+func TestCompareSnapshotsFiltered(t *testing.T) {
+	fromIssues := []model.Issue{
+		{ID: "retitled", Title: "Old", Status: model.StatusOpen},
+		{ID: "started", Title: "Started", Status: model.StatusOpen, Priority: 1},
+		{ID: "relinked", Title: "Relinked", Status: model.StatusOpen},
+	}
+	toIssues := []model.Issue{
+		{ID: "retitled", Title: "New", Status: model.StatusOpen},
+		{ID: "started", Title: "Started", Status: model.StatusInProgress, Priority: 0},
+		{ID: "relinked", Title: "Relinked", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "relinked", DependsOnID: "started", Type: model.DepBlocks},
+		}},
+	}
+	from, to := NewSnapshot(fromIssues), NewSnapshot(toIssues)
+
+	diff, err := CompareSnapshotsFiltered(from, to, []string{"status", "dependencies"})
+	if err != nil {
+		t.Fatalf("CompareSnapshotsFiltered: %v", err)
+	}
+	got := make(map[string][]string)
+	for _, m := range diff.ModifiedIssues {
+		for _, c := range m.Changes {
+			got[m.IssueID] = append(got[m.IssueID], c.Field)
+		}
+	}
+	if len(got) != 2 || strings.Join(got["started"], ",") != "status" || strings.Join(got["relinked"], ",") != "dependencies" {
+		t.Errorf("filtered changes = %v, want started:[status] relinked:[dependencies]", got)
+	}
+
+	// Issues whose only changes are filtered out are omitted entirely
+	diff, err = CompareSnapshotsFiltered(from, to, []string{"title"})
+	if err != nil {
+		t.Fatalf("CompareSnapshotsFiltered: %v", err)
+	}
+	if len(diff.ModifiedIssues) != 1 || diff.ModifiedIssues[0].IssueID != "retitled" {
+		t.Errorf("title-only diff = %+v, want just retitled", diff.ModifiedIssues)
+	}
+
+	// No filter behaves like CompareSnapshots
+	all, err := CompareSnapshotsFiltered(from, to, nil)
+	if err != nil || len(all.ModifiedIssues) != len(CompareSnapshots(from, to).ModifiedIssues) {
+		t.Errorf("unfiltered diff = %+v, %v", all, err)
+	}
+
+	if _, err := CompareSnapshotsFiltered(from, to, []string{"stauts"}); err == nil || !strings.Contains(err.Error(), `"stauts"`) {
+		t.Errorf("expected error naming the unknown field, got %v", err)
+	}
+}
+
+func TestSnapshotDiffStats(t *testing.T) {
+	fromIssues := []model.Issue{
+		{ID: "done", Title: "Done", Status: model.StatusOpen},
+		{ID: "started", Title: "Started", Status: model.StatusOpen},
+		{ID: "retitled", Title: "Old", Status: model.StatusOpen, Labels: []string{"a"}},
+		{ID: "gone", Title: "Gone", Status: model.StatusOpen},
+	}
+	toIssues := []model.Issue{
+		{ID: "done", Title: "Done", Status: model.StatusClosed},
+		{ID: "started", Title: "Started", Status: model.StatusInProgress},
+		{ID: "retitled", Title: "New", Status: model.StatusOpen, Labels: []string{"b"}},
+		{ID: "new-open", Title: "New open", Status: model.StatusOpen},
+		{ID: "new-open-2", Title: "New open 2", Status: model.StatusOpen},
+		{ID: "new-closed", Title: "New closed", Status: model.StatusClosed},
+	}
+
+	stats := CompareSnapshots(NewSnapshot(fromIssues), NewSnapshot(toIssues)).Stats()
+
+	if stats.Added != 3 || stats.Removed != 1 || stats.Modified != 2 || stats.Closed != 1 || stats.Reopened != 0 {
+		t.Errorf("counts = %+v", stats)
+	}
+	want := map[string]int{"status": 2, "title": 1, "labels": 1}
+	if len(stats.ModifiedByField) != len(want) {
+		t.Errorf("ModifiedByField = %v, want %v", stats.ModifiedByField, want)
+	}
+	for field, n := range want {
+		if stats.ModifiedByField[field] != n {
+			t.Errorf("ModifiedByField[%s] = %d, want %d", field, stats.ModifiedByField[field], n)
+		}
+	}
+	// Two new open issues, one newly closed
+	if stats.NetOpenDelta != 1 {
+		t.Errorf("NetOpenDelta = %d, want 1", stats.NetOpenDelta)
+	}
+}
+
+// errAfterContext reports cancellation once Err has been polled n times.
+type errAfterContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *errAfterContext) Err() error {
+	if c.remaining <= 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+func TestCompareSnapshotsContext_Cancelled(t *testing.T) {
+	from := NewSnapshot([]model.Issue{
+		{ID: "ISSUE-1", Title: "First", Status: model.StatusOpen},
+	})
+	to := NewSnapshot([]model.Issue{
+		{ID: "ISSUE-1", Title: "First renamed", Status: model.StatusOpen},
+		{ID: "ISSUE-2", Title: "Second", Status: model.StatusOpen},
+	})
+
+	full, err := CompareSnapshotsContext(context.Background(), from, to)
+	if err != nil || full.Partial {
+		t.Fatalf("uncancelled diff: err=%v partial=%v", err, full.Partial)
+	}
+	if len(full.NewIssues) != 1 || len(full.ModifiedIssues) != 1 {
+		t.Fatalf("expected 1 new and 1 modified issue, got %d and %d", len(full.NewIssues), len(full.ModifiedIssues))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	diff, err := CompareSnapshotsContext(ctx, from, to)
+	if !errors.Is(err, context.Canceled) || !diff.Partial {
+		t.Fatalf("pre-cancelled diff: err=%v partial=%v", err, diff.Partial)
+	}
+	if len(diff.NewIssues) != 0 {
+		t.Errorf("pre-cancelled diff should be empty, got %d new issues", len(diff.NewIssues))
+	}
+
+	// Cancel after the issue-set phase: new issues are reported, field
+	// changes are not.
+	diff, err = CompareSnapshotsContext(&errAfterContext{Context: context.Background(), remaining: 1}, from, to)
+	if !errors.Is(err, context.Canceled) || !diff.Partial {
+		t.Fatalf("mid-diff cancel: err=%v partial=%v", err, diff.Partial)
+	}
+	if len(diff.NewIssues) != 1 || len(diff.ModifiedIssues) != 0 {
+		t.Errorf("expected partial diff with 1 new and 0 modified, got %d and %d", len(diff.NewIssues), len(diff.ModifiedIssues))
+	}
+	if diff.Summary.IssuesAdded != 1 {
+		t.Errorf("partial summary should count added issues, got %d", diff.Summary.IssuesAdded)
+	}
+}
+
 func TestDependencySetIgnoresNilAndEmpty(t *testing.T) {
 	deps := []*model.Dependency{
 		nil,
@@ -415,3 +681,54 @@ func TestCompareSnapshots_DependencyTypeChange(t *testing.T) {
 		t.Error("expected dependency change to be detected when Type changes from related to blocks")
 	}
 }
+
+func TestCompareSnapshots_StructuredDependencyChanges(t *testing.T) {
+	dep := func(target string, typ model.DependencyType) *model.Dependency {
+		return &model.Dependency{IssueID: "A", DependsOnID: target, Type: typ}
+	}
+	summarize := func(m ModifiedIssue) string {
+		var parts []string
+		for _, d := range m.AddedDeps {
+			parts = append(parts, "+"+d.DependsOnID+":"+string(d.Type))
+		}
+		for _, d := range m.RemovedDeps {
+			parts = append(parts, "-"+d.DependsOnID+":"+string(d.Type))
+		}
+		for _, r := range m.RetypedDeps {
+			parts = append(parts, "~"+r.DependsOnID+":"+string(r.From)+">"+string(r.To))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := []struct {
+		name     string
+		from, to []*model.Dependency
+		want     string
+	}{
+		{"add", []*model.Dependency{dep("B", model.DepBlocks)},
+			[]*model.Dependency{dep("B", model.DepBlocks), dep("C", model.DepBlocks)}, "+C:blocks"},
+		{"remove", []*model.Dependency{dep("B", model.DepBlocks), dep("C", model.DepRelated)},
+			[]*model.Dependency{dep("B", model.DepBlocks)}, "-C:related"},
+		{"retype", []*model.Dependency{dep("B", model.DepRelated)},
+			[]*model.Dependency{dep("B", model.DepBlocks)}, "~B:related>blocks"},
+		{"combined", []*model.Dependency{dep("B", model.DepRelated), dep("C", model.DepBlocks)},
+			[]*model.Dependency{dep("B", model.DepBlocks), dep("D", model.DepParentChild)},
+			"+D:parent-child -C:blocks ~B:related>blocks"},
+		{"extra type on same target", []*model.Dependency{dep("B", model.DepBlocks)},
+			[]*model.Dependency{dep("B", model.DepBlocks), dep("B", model.DepRelated)}, "+B:related"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := NewSnapshot([]model.Issue{{ID: "A", Status: model.StatusOpen, Dependencies: tt.from}})
+			to := NewSnapshot([]model.Issue{{ID: "A", Status: model.StatusOpen, Dependencies: tt.to}})
+			diff := CompareSnapshots(from, to)
+			if len(diff.ModifiedIssues) != 1 {
+				t.Fatalf("expected 1 modified issue, got %d", len(diff.ModifiedIssues))
+			}
+			if got := summarize(diff.ModifiedIssues[0]); got != tt.want {
+				t.Errorf("dependency changes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
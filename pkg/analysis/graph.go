@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
 	"math"
@@ -42,6 +43,8 @@ type StartupProfile struct {
 	Cycles        time.Duration `json:"cycles"`
 	CyclesTO      bool          `json:"cycles_timeout"`
 	CycleCount    int           `json:"cycle_count"`
+	Criticality   time.Duration `json:"criticality"`
+	CriticalityTO bool          `json:"criticality_timeout"`
 	KCore         time.Duration `json:"kcore"`        // bv-85
 	Articulation  time.Duration `json:"articulation"` // bv-85
 	Slack         time.Duration `json:"slack"`        // bv-85
@@ -55,7 +58,7 @@ type StartupProfile struct {
 }
 
 // GraphStats holds the results of graph analysis.
-// Phase 1 fields (OutDegree, InDegree, TopologicalOrder, Density) are populated
+// Phase 1 fields (OutDegree, InDegree, TopologicalOrder, SCC, Density) are populated
 // immediately and can be read without synchronization after AnalyzeAsync returns.
 // Phase 2 fields (centrality metrics, cycles) are computed in background and
 // must be accessed via thread-safe accessor methods.
@@ -64,6 +67,7 @@ type GraphStats struct {
 	OutDegree        map[string]int // Number of dependencies this issue has (edges out)
 	InDegree         map[string]int // Number of issues that depend on this issue (edges in)
 	TopologicalOrder []string
+	SCC              [][]string // Dependency-cycle clusters: strongly connected components of 2+ issues
 	Density          float64
 	NodeCount        int // Number of nodes in graph
 	EdgeCount        int // Number of edges in graph
@@ -84,6 +88,7 @@ type GraphStats struct {
 	coreNumber        map[string]int
 	articulation      map[string]bool
 	slack             map[string]float64
+	criticality       map[string]float64
 	cycles            [][]string
 
 	// Ranks (1-based, computed for UI optimization)
@@ -111,6 +116,7 @@ type MetricStatus struct {
 	KCore        statusEntry // bv-85: k-core decomposition
 	Articulation statusEntry // bv-85: articulation points (cut vertices)
 	Slack        statusEntry // bv-85: longest-path slack per node
+	Criticality  statusEntry
 }
 
 // statusEntry records computation state for a single metric.
@@ -152,6 +158,9 @@ func betweennessReason(cfg AnalysisConfig, isApprox bool) string {
 		return cfg.BetweennessSkipReason
 	}
 	if cfg.BetweennessMode == BetweennessApproximate || isApprox {
+		if cfg.BetweennessStrategy == BetweennessStrategyPairs {
+			return "approximate (pair sampling)"
+		}
 		return "approximate"
 	}
 	return ""
@@ -360,6 +369,32 @@ func (s *GraphStats) Slack() map[string]float64 {
 	return cp
 }
 
+// GetCriticalityScore returns the criticality score for a single issue.
+func (s *GraphStats) GetCriticalityScore(id string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.criticality == nil {
+		return 0
+	}
+	return s.criticality[id]
+}
+
+// CriticalityScore returns per-node criticality in [0,1]: how much depends on
+// the issue, directly and transitively. See InterpretationLegend. It is empty
+// when AnalysisConfig.ComputeCriticality is off or the metric timed out.
+func (s *GraphStats) CriticalityScore() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.criticality == nil {
+		return nil
+	}
+	cp := make(map[string]float64, len(s.criticality))
+	for k, v := range s.criticality {
+		cp[k] = v
+	}
+	return cp
+}
+
 // Ranks accessors
 
 func (s *GraphStats) PageRankRank() map[string]int {
@@ -466,6 +501,27 @@ func (s *GraphStats) OutDegreeRank() map[string]int {
 	return cp
 }
 
+// TopByInDegree returns the IDs of the n issues with the most dependents,
+// highest InDegree first with ties broken by ID.
+func (s *GraphStats) TopByInDegree(n int) []string {
+	return topIDsByDegree(s.InDegree, n)
+}
+
+// TopByOutDegree returns the IDs of the n issues with the most dependencies,
+// highest OutDegree first with ties broken by ID.
+func (s *GraphStats) TopByOutDegree(n int) []string {
+	return topIDsByDegree(s.OutDegree, n)
+}
+
+func topIDsByDegree(degrees map[string]int, n int) []string {
+	items := getTopItemsInt(degrees, n)
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
 // Cycles returns a copy of detected cycles. Safe for concurrent iteration.
 // Returns nil if Phase 2 is not yet complete.
 func (s *GraphStats) Cycles() [][]string {
@@ -510,13 +566,51 @@ func NewGraphStatsForTest(
 	return stats
 }
 
+// DependencyDirection says how a blocking dependency record is read.
+type DependencyDirection int
+
+const (
+	// DependsOn reads a dependency on issue A with DependsOnID B as "A depends
+	// on B" (B blocks A). This is the beads convention and the default.
+	DependsOn DependencyDirection = iota
+	// Blocks reads the same record as "A blocks B" (B depends on A), for
+	// trackers that store the relationship on the blocking side.
+	Blocks
+)
+
+// String returns the direction name.
+func (d DependencyDirection) String() string {
+	switch d {
+	case DependsOn:
+		return "depends-on"
+	case Blocks:
+		return "blocks"
+	default:
+		return fmt.Sprintf("DependencyDirection(%d)", int(d))
+	}
+}
+
+// AnalyzerOption configures an Analyzer.
+type AnalyzerOption func(*Analyzer)
+
+// WithDependencyDirection sets how dependency records are oriented. Whatever
+// the direction, the analysis graph always has an edge u -> v when u depends
+// on v, so OutDegree counts prerequisites and InDegree counts dependents.
+func WithDependencyDirection(d DependencyDirection) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.direction = d
+	}
+}
+
 // Analyzer encapsulates the graph logic
 type Analyzer struct {
-	g        *simple.DirectedGraph
-	idToNode map[string]int64
-	nodeToID map[int64]string
-	issueMap map[string]model.Issue
-	config   *AnalysisConfig // Optional custom config, nil means use size-based defaults
+	g         *simple.DirectedGraph
+	idToNode  map[string]int64
+	nodeToID  map[int64]string
+	issueMap  map[string]model.Issue
+	config    *AnalysisConfig           // Optional custom config, nil means use size-based defaults
+	direction DependencyDirection       // How dependency records map to edges
+	weight    func(model.Issue) float64 // Critical-path cost per issue; nil counts hops
 }
 
 // SetConfig sets a custom analysis configuration.
@@ -525,7 +619,14 @@ func (a *Analyzer) SetConfig(config *AnalysisConfig) {
 	a.config = config
 }
 
-func NewAnalyzer(issues []model.Issue) *Analyzer {
+// NewAnalyzer builds the dependency graph for issues. By default dependency
+// records use DependsOn semantics; see WithDependencyDirection.
+func NewAnalyzer(issues []model.Issue, opts ...AnalyzerOption) *Analyzer {
+	a := &Analyzer{}
+	for _, opt := range opts {
+		opt(a)
+	}
+
 	g := simple.NewDirectedGraph()
 	// Pre-allocate maps for efficiency
 	idToNode := make(map[string]int64, len(issues))
@@ -563,19 +664,104 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 
 			v, exists := idToNode[dep.DependsOnID]
 			if exists {
-				// Issue (u) depends on v → edge u -> v
+				from, to := a.orient(u, v)
 				// Optimization: Use simple.Node directly to avoid internal map lookups in g.Node()
-				g.SetEdge(g.NewEdge(simple.Node(u), simple.Node(v)))
+				g.SetEdge(g.NewEdge(simple.Node(from), simple.Node(to)))
+			}
+		}
+	}
+
+	a.g = g
+	a.idToNode = idToNode
+	a.nodeToID = nodeToID
+	a.issueMap = issueMap
+	return a
+}
+
+// NewWeightedAnalyzer is NewAnalyzer with an effort-weighted critical path:
+// each issue adds weight(issue) to the chains through it instead of 1, so a
+// short chain of heavy work can outrank a long chain of trivial tasks.
+// PageRank, betweenness and the other metrics stay unweighted.
+func NewWeightedAnalyzer(issues []model.Issue, weight func(model.Issue) float64, opts ...AnalyzerOption) *Analyzer {
+	a := NewAnalyzer(issues, opts...)
+	a.weight = weight
+	return a
+}
+
+// NewWeightedDependencyGraph builds the blocking-dependency graph NewAnalyzer
+// would, but as a weighted graph: the edge from an issue to the issue it
+// depends on has weight weight(issue, dependency). Node IDs follow issue
+// order, and the returned map gives each issue's node. Passing the graph to
+// ApproxBetweenness makes shortest paths follow total weight rather than hop
+// count, so weights should be positive.
+func NewWeightedDependencyGraph(issues []model.Issue, weight func(from, to model.Issue) float64) (*simple.WeightedDirectedGraph, map[string]int64) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	idToNode := make(map[string]int64, len(issues))
+	byID := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		g.AddNode(simple.Node(i))
+		idToNode[issue.ID] = int64(i)
+		byID[issue.ID] = i
+	}
+
+	for i, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			j, ok := byID[dep.DependsOnID]
+			if !ok || j == i {
+				continue
 			}
+			g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(i), simple.Node(j), weight(issue, issues[j])))
 		}
 	}
+	return g, idToNode
+}
+
+// orient returns the graph edge for a blocking record on issue u naming v.
+// Under DependsOn u depends on v → edge u -> v; under Blocks u blocks v, so v
+// depends on u → edge v -> u.
+func (a *Analyzer) orient(u, v int64) (from, to int64) {
+	if a.direction == Blocks {
+		return v, u
+	}
+	return u, v
+}
+
+// Direction returns how the analyzer reads dependency records.
+func (a *Analyzer) Direction() DependencyDirection {
+	return a.direction
+}
 
-	return &Analyzer{
-		g:        g,
-		idToNode: idToNode,
-		nodeToID: nodeToID,
-		issueMap: issueMap,
+// blockerIDs returns the IDs of known issues that block issue. Under DependsOn
+// semantics they come from the issue's own records in declaration order;
+// under Blocks semantics they are read from the graph, sorted.
+func (a *Analyzer) blockerIDs(issue model.Issue) []string {
+	if a.direction == Blocks {
+		node, ok := a.idToNode[issue.ID]
+		if !ok {
+			return nil
+		}
+		var ids []string
+		from := a.g.From(node)
+		for from.Next() {
+			ids = append(ids, a.nodeToID[from.Node().ID()])
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	var ids []string
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if _, exists := a.issueMap[dep.DependsOnID]; exists {
+			ids = append(ids, dep.DependsOnID)
+		}
 	}
+	return ids
 }
 
 // AnalyzeAsync performs graph analysis in two phases for fast startup.
@@ -604,13 +790,17 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	edgeCount := a.g.Edges().Len()
 
 	var cacheKey, dataHash, configHash string
-	if robotDiskCacheEnabled() {
+	// A weight function cannot be hashed, so weighted results are not cached
+	if robotDiskCacheEnabled() && a.weight == nil {
 		issues := make([]model.Issue, 0, len(a.issueMap))
 		for _, issue := range a.issueMap {
 			issues = append(issues, issue)
 		}
 		dataHash = ComputeDataHash(issues)
 		configHash = ComputeConfigHash(&config)
+		if a.direction != DependsOn {
+			configHash += "|" + a.direction.String()
+		}
 		cacheKey = dataHash + "|" + configHash
 
 		if cached, ok := getRobotDiskCachedStats(cacheKey); ok {
@@ -641,21 +831,24 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 			KCore:        statusEntry{State: "pending"},
 			Articulation: statusEntry{State: "pending"},
 			Slack:        statusEntry{State: "pending"},
+			Criticality:  statusEntry{State: "pending"},
 		},
 	}
 
 	// Handle empty graph - mark phase 2 ready immediately
 	if nodeCount == 0 {
+		newProgressReporter(config, false).complete()
 		stats.status = MetricStatus{
-			PageRank:     statusEntry{State: stateFromTiming(config.ComputePageRank, false)},
-			Betweenness:  statusEntry{State: stateFromTiming(config.ComputeBetweenness, false)},
+			PageRank:     statusEntry{State: stateFromTiming(config.ComputePageRank, false), Reason: config.PageRankSkipReason},
+			Betweenness:  statusEntry{State: stateFromTiming(config.betweennessEnabled(), false), Reason: config.BetweennessSkipReason},
 			Eigenvector:  statusEntry{State: stateFromTiming(config.ComputeEigenvector, false)},
-			HITS:         statusEntry{State: stateFromTiming(config.ComputeHITS, false)},
+			HITS:         statusEntry{State: stateFromTiming(config.ComputeHITS, false), Reason: config.HITSSkipReason},
 			Critical:     statusEntry{State: stateFromTiming(config.ComputeCriticalPath, false)},
-			Cycles:       statusEntry{State: stateFromTiming(config.ComputeCycles, false)},
+			Cycles:       statusEntry{State: stateFromTiming(config.ComputeCycles, false), Reason: config.CyclesSkipReason},
 			KCore:        statusEntry{State: "computed"},
 			Articulation: statusEntry{State: "computed"},
 			Slack:        statusEntry{State: "computed"},
+			Criticality:  statusEntry{State: stateFromTiming(config.ComputeCriticality, false), Reason: config.CriticalitySkipReason},
 		}
 		stats.phase2Ready = true
 		close(stats.phase2Done)
@@ -681,6 +874,7 @@ func (a *Analyzer) Analyze() GraphStats {
 		OutDegree:         stats.OutDegree,
 		InDegree:          stats.InDegree,
 		TopologicalOrder:  stats.TopologicalOrder,
+		SCC:               stats.SCC,
 		Density:           stats.Density,
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
@@ -702,6 +896,7 @@ func (a *Analyzer) Analyze() GraphStats {
 		coreNumber:        stats.coreNumber,
 		articulation:      stats.articulation,
 		slack:             stats.slack,
+		criticality:       stats.criticality,
 		cycles:            stats.cycles,
 		phase2Ready:       true,
 		status:            stats.status,
@@ -716,6 +911,7 @@ func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 		OutDegree:         stats.OutDegree,
 		InDegree:          stats.InDegree,
 		TopologicalOrder:  stats.TopologicalOrder,
+		SCC:               stats.SCC,
 		Density:           stats.Density,
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
@@ -737,6 +933,7 @@ func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 		coreNumber:        stats.coreNumber,
 		articulation:      stats.articulation,
 		slack:             stats.slack,
+		criticality:       stats.criticality,
 		cycles:            stats.cycles,
 		phase2Ready:       true,
 		status:            stats.status,
@@ -775,6 +972,7 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 
 	// Handle empty graph
 	if nodeCount == 0 {
+		newProgressReporter(config, false).complete()
 		stats.phase2Ready = true
 		close(stats.phase2Done)
 		profile.Total = time.Since(totalStart)
@@ -800,8 +998,32 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 	return stats, profile
 }
 
+// stronglyConnectedComponents returns the issue IDs of every strongly
+// connected component with more than one issue (Tarjan, O(V+E)), sorted
+// within each component and by first ID. Nil when the graph is acyclic.
+func (a *Analyzer) stronglyConnectedComponents() [][]string {
+	var components [][]string
+	for _, scc := range topo.TarjanSCC(a.g) {
+		if len(scc) < 2 {
+			continue
+		}
+		ids := make([]string, len(scc))
+		for i, n := range scc {
+			ids[i] = a.nodeToID[n.ID()]
+		}
+		sort.Strings(ids)
+		components = append(components, ids)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
 // computePhase1WithProfile calculates fast metrics with timing instrumentation.
 func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupProfile) {
+	newProgressReporter(stats.Config, a.g.Edges().Len() > 0).step(ProgressStageDegrees)
+
 	// Degree centrality
 	degreeStart := time.Now()
 	nodes := a.g.Nodes()
@@ -823,6 +1045,7 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
 		}
 	}
+	stats.SCC = a.stronglyConnectedComponents()
 	profile.TopoSort = time.Since(topoStart)
 
 	// Density
@@ -841,6 +1064,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	localHubs := make(map[string]float64)
 	localAuthorities := make(map[string]float64)
 	localCriticalPath := make(map[string]float64)
+	localCriticality := make(map[string]float64)
 	var localCore map[string]int
 	var localArticulation map[string]bool
 	var localSlack map[string]float64
@@ -850,192 +1074,249 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	actualBetweennessSample := 0
 	cyclesTruncated := false
 
+	progress := newProgressReporter(config, a.g.Edges().Len() > 0)
+	progress.done = 1 // Degrees were reported in phase 1
+
+	// Metrics run concurrently, at most config.MaxConcurrency at a time. Each
+	// writes only its own locals and profile fields, which are read after wait.
+	runner := newMetricRunner(config.maxConcurrency())
+
 	// PageRank
-	if ctx.Err() == nil && config.ComputePageRank {
-		prStart := time.Now()
-		prDone := make(chan map[int64]float64, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
-				}
+	if config.ComputePageRank && runner.acquire(ctx) {
+		progress.step(ProgressStagePageRank)
+		runner.spawn(func() {
+			prStart := time.Now()
+			prDone := make(chan map[int64]float64, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						// Panic -> implicitly causes timeout in parent
+					}
+				}()
+				prDone <- computePageRank(a.g, 0.85, 1e-6)
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
-		}()
-
-		timer := time.NewTimer(config.PageRankTimeout)
-		select {
-		case pr := <-prDone:
-			timer.Stop()
-			for id, score := range pr {
-				localPageRank[a.nodeToID[id]] = score
-			}
-		case <-timer.C:
-			profile.PageRankTO = true
-			if len(a.issueMap) > 0 {
-				uniform := 1.0 / float64(len(a.issueMap))
-				for id := range a.issueMap {
-					localPageRank[id] = uniform
+
+			timer := time.NewTimer(config.PageRankTimeout)
+			select {
+			case pr := <-prDone:
+				timer.Stop()
+				for id, score := range pr {
+					localPageRank[a.nodeToID[id]] = score
 				}
+			case <-timer.C:
+				profile.PageRankTO = true
+				if len(a.issueMap) > 0 {
+					uniform := 1.0 / float64(len(a.issueMap))
+					for id := range a.issueMap {
+						localPageRank[id] = uniform
+					}
+				}
+			case <-ctx.Done():
+				timer.Stop()
+				// Abort immediately
+				return
 			}
-		case <-ctx.Done():
-			timer.Stop()
-			// Abort immediately
-			return
-		}
-		profile.PageRank = time.Since(prStart)
+			profile.PageRank = time.Since(prStart)
+		})
 	}
 
 	// Betweenness
-	if ctx.Err() == nil && config.ComputeBetweenness {
-		bwStart := time.Now()
-		bwDone := make(chan BetweennessResult, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
+	if config.betweennessEnabled() && runner.acquire(ctx) {
+		progress.step(ProgressStageBetweenness)
+		runner.spawn(func() {
+			bwStart := time.Now()
+			bwDone := make(chan BetweennessResult, 1)
+			// Lets pivot sampling stop working once its result is abandoned
+			bwCtx, cancel := context.WithTimeout(ctx, config.BetweennessTimeout)
+			defer cancel()
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						// Panic -> implicitly causes timeout in parent
+					}
+				}()
+				// Choose algorithm based on mode
+				if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
+					if config.BetweennessStrategy == BetweennessStrategyPairs {
+						bwDone <- ApproxBetweennessPairs(a.g, config.BetweennessSampleSize, 1)
+					} else {
+						bwDone <- ApproxBetweennessCtx(bwCtx, a.g, config.BetweennessSampleSize, 1)
+					}
+				} else {
+					// Exact mode or mode not set (default to exact)
+					exact := network.Betweenness(a.g)
+					bwDone <- BetweennessResult{
+						Scores:     exact,
+						Mode:       BetweennessExact,
+						TotalNodes: a.g.Nodes().Len(),
+					}
 				}
 			}()
-			// Choose algorithm based on mode
-			if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
-				bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize, 1)
-			} else {
-				// Exact mode or mode not set (default to exact)
-				exact := network.Betweenness(a.g)
-				bwDone <- BetweennessResult{
-					Scores:     exact,
-					Mode:       BetweennessExact,
-					TotalNodes: a.g.Nodes().Len(),
+
+			timer := time.NewTimer(config.BetweennessTimeout)
+			select {
+			case result := <-bwDone:
+				timer.Stop()
+				scores := result.Scores
+				if config.BetweennessNormalized {
+					scores = result.NormalizedScores()
 				}
+				for id, score := range scores {
+					localBetweenness[a.nodeToID[id]] = score
+				}
+				// Track if approximation was used
+				if result.Mode == BetweennessApproximate {
+					betweennessIsApprox = true
+					actualBetweennessSample = result.SampleSize
+				}
+			case <-timer.C:
+				profile.BetweennessTO = true
+			case <-ctx.Done():
+				timer.Stop()
+				return
 			}
-		}()
-
-		timer := time.NewTimer(config.BetweennessTimeout)
-		select {
-		case result := <-bwDone:
-			timer.Stop()
-			for id, score := range result.Scores {
-				localBetweenness[a.nodeToID[id]] = score
-			}
-			// Track if approximation was used
-			if result.Mode == BetweennessApproximate {
-				betweennessIsApprox = true
-				actualBetweennessSample = result.SampleSize
-			}
-		case <-timer.C:
-			profile.BetweennessTO = true
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-		profile.Betweenness = time.Since(bwStart)
+			profile.Betweenness = time.Since(bwStart)
+		})
 	}
 
 	// Eigenvector
-	if ctx.Err() == nil && config.ComputeEigenvector {
-		evStart := time.Now()
-		for id, score := range computeEigenvector(a.g) {
-			localEigenvector[a.nodeToID[id]] = score
-		}
-		profile.Eigenvector = time.Since(evStart)
-	}
-
-	// HITS
-	if ctx.Err() == nil && config.ComputeHITS && a.g.Edges().Len() > 0 {
-		hitsStart := time.Now()
-		hitsDone := make(chan map[int64]network.HubAuthority, 1)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					// Panic -> implicitly causes timeout in parent
-				}
-			}()
-			hitsDone <- network.HITS(a.g, 1e-3)
-		}()
-
-		timer := time.NewTimer(config.HITSTimeout)
-		select {
-		case hubAuth := <-hitsDone:
-			timer.Stop()
-			for id, ha := range hubAuth {
-				localHubs[a.nodeToID[id]] = ha.Hub
-				localAuthorities[a.nodeToID[id]] = ha.Authority
+	if config.ComputeEigenvector && runner.acquire(ctx) {
+		progress.step(ProgressStageEigenvector)
+		runner.spawn(func() {
+			evStart := time.Now()
+			for id, score := range computeEigenvector(a.g) {
+				localEigenvector[a.nodeToID[id]] = score
 			}
-		case <-timer.C:
-			profile.HITSTO = true
-		case <-ctx.Done():
-			timer.Stop()
-			return
-		}
-		profile.HITS = time.Since(hitsStart)
-	}
-
-	// Critical Path
-	if ctx.Err() == nil && config.ComputeCriticalPath {
-		cpStart := time.Now()
-		sorted, err := topo.Sort(a.g)
-		if err == nil {
-			localCriticalPath = a.computeHeights(sorted)
-		}
-		profile.CriticalPath = time.Since(cpStart)
+			profile.Eigenvector = time.Since(evStart)
+		})
 	}
 
-	// Cycles
-	if ctx.Err() == nil && config.ComputeCycles {
-		cyclesStart := time.Now()
-		maxCycles := config.MaxCyclesToStore
-		if maxCycles == 0 {
-			maxCycles = 100
-		}
-
-		sccs := topo.TarjanSCC(a.g)
-		hasCycles := false
-		for _, scc := range sccs {
-			if len(scc) > 1 {
-				hasCycles = true
-				break
-			}
-		}
-
-		if hasCycles {
-			cyclesDone := make(chan [][]graph.Node, 1)
+	// HITS
+	if config.ComputeHITS && a.g.Edges().Len() > 0 && runner.acquire(ctx) {
+		progress.step(ProgressStageHITS)
+		runner.spawn(func() {
+			hitsStart := time.Now()
+			hitsDone := make(chan map[int64]network.HubAuthority, 1)
 			go func() {
 				defer func() {
 					if r := recover(); r != nil {
 						// Panic -> implicitly causes timeout in parent
 					}
 				}()
-				cyclesDone <- findCyclesSafe(a.g, maxCycles)
+				hitsDone <- network.HITS(a.g, 1e-3)
 			}()
 
-			timer := time.NewTimer(config.CyclesTimeout)
+			timer := time.NewTimer(config.HITSTimeout)
 			select {
-			case cycles := <-cyclesDone:
+			case hubAuth := <-hitsDone:
 				timer.Stop()
-				profile.CycleCount = len(cycles)
-				cyclesToProcess := cycles
-				if len(cyclesToProcess) > maxCycles {
-					cyclesToProcess = cyclesToProcess[:maxCycles]
-					cyclesTruncated = true
-				}
-
-				for _, cycle := range cyclesToProcess {
-					var cycleIDs []string
-					for _, n := range cycle {
-						cycleIDs = append(cycleIDs, a.nodeToID[n.ID()])
-					}
-					localCycles = append(localCycles, cycleIDs)
+				for id, ha := range hubAuth {
+					localHubs[a.nodeToID[id]] = ha.Hub
+					localAuthorities[a.nodeToID[id]] = ha.Authority
 				}
 			case <-timer.C:
-				profile.CyclesTO = true
+				profile.HITSTO = true
 			case <-ctx.Done():
 				timer.Stop()
 				return
 			}
-		}
-		profile.Cycles = time.Since(cyclesStart)
+			profile.HITS = time.Since(hitsStart)
+		})
 	}
 
+	// Critical Path
+	if config.ComputeCriticalPath && runner.acquire(ctx) {
+		progress.step(ProgressStageCriticalPath)
+		runner.spawn(func() {
+			cpStart := time.Now()
+			sorted, err := topo.Sort(a.g)
+			if err == nil {
+				localCriticalPath = a.computeHeights(sorted)
+			}
+			profile.CriticalPath = time.Since(cpStart)
+		})
+	}
+
+	// Cycles
+	if config.ComputeCycles && runner.acquire(ctx) {
+		progress.step(ProgressStageCycles)
+		runner.spawn(func() {
+			cyclesStart := time.Now()
+			maxCycles := config.MaxCyclesToStore
+			if maxCycles == 0 {
+				maxCycles = 100
+			}
+
+			sccs := topo.TarjanSCC(a.g)
+			hasCycles := false
+			for _, scc := range sccs {
+				if len(scc) > 1 {
+					hasCycles = true
+					break
+				}
+			}
+
+			if hasCycles {
+				cyclesDone := make(chan [][]graph.Node, 1)
+				go func() {
+					defer func() {
+						if r := recover(); r != nil {
+							// Panic -> implicitly causes timeout in parent
+						}
+					}()
+					// One extra so a truncated result can be told apart
+					cyclesDone <- findCyclesSafe(a.g, maxCycles+1)
+				}()
+
+				timer := time.NewTimer(config.CyclesTimeout)
+				select {
+				case cycles := <-cyclesDone:
+					timer.Stop()
+					profile.CycleCount = len(cycles)
+					cyclesToProcess := cycles
+					if len(cyclesToProcess) > maxCycles {
+						cyclesToProcess = cyclesToProcess[:maxCycles]
+						cyclesTruncated = true
+					}
+
+					for _, cycle := range cyclesToProcess {
+						var cycleIDs []string
+						for _, n := range cycle {
+							cycleIDs = append(cycleIDs, a.nodeToID[n.ID()])
+						}
+						localCycles = append(localCycles, cycleIDs)
+					}
+					canonicalizeCycles(localCycles)
+				case <-timer.C:
+					profile.CyclesTO = true
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				}
+			}
+			profile.Cycles = time.Since(cyclesStart)
+		})
+	}
+
+	// Criticality: one reverse BFS per node, so it stops at the deadline
+	// rather than running on in the background like HITS.
+	if config.ComputeCriticality && runner.acquire(ctx) {
+		progress.step(ProgressStageCriticality)
+		runner.spawn(func() {
+			critStart := time.Now()
+			critCtx, cancel := context.WithTimeout(ctx, config.CriticalityTimeout)
+			defer cancel()
+			if scores, ok := a.computeCriticality(critCtx); ok {
+				localCriticality = scores
+			} else if ctx.Err() == nil {
+				profile.CriticalityTO = true
+			}
+			profile.Criticality = time.Since(critStart)
+		})
+	}
+
+	runner.wait()
+
 	// Check cancellation before advanced signals
 	if ctx.Err() != nil {
 		return
@@ -1070,6 +1351,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	stats.coreNumber = localCore
 	stats.articulation = localArticulation
 	stats.slack = localSlack
+	stats.criticality = localCriticality
 	stats.cycles = localCycles
 
 	// Assign ranks
@@ -1092,9 +1374,9 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 
 	// record status snapshot
 	stats.status = MetricStatus{
-		PageRank: statusEntry{State: stateFromTiming(config.ComputePageRank, profile.PageRankTO), Elapsed: profile.PageRank},
+		PageRank: statusEntry{State: stateFromTiming(config.ComputePageRank, profile.PageRankTO), Reason: config.PageRankSkipReason, Elapsed: profile.PageRank},
 		Betweenness: statusEntry{
-			State:   stateFromTiming(config.ComputeBetweenness, profile.BetweennessTO),
+			State:   stateFromTiming(config.betweennessEnabled(), profile.BetweennessTO),
 			Reason:  betweennessReason(config, betweennessIsApprox),
 			Sample:  actualBetweennessSample,
 			Elapsed: profile.Betweenness,
@@ -1106,12 +1388,17 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		KCore:        statusEntry{State: "computed", Elapsed: profile.KCore},        // bv-85: always computed (fast)
 		Articulation: statusEntry{State: "computed", Elapsed: profile.Articulation}, // bv-85: computed with k-core
 		Slack:        statusEntry{State: "computed", Elapsed: profile.Slack},        // bv-85: always computed (fast)
+		Criticality:  statusEntry{State: stateFromTiming(config.ComputeCriticality, profile.CriticalityTO), Reason: config.CriticalitySkipReason, Elapsed: profile.Criticality},
 	}
 	stats.mu.Unlock()
+
+	progress.complete()
 }
 
 // computePhase1 calculates fast metrics synchronously.
 func (a *Analyzer) computePhase1(stats *GraphStats) {
+	newProgressReporter(stats.Config, a.g.Edges().Len() > 0).step(ProgressStageDegrees)
+
 	nodes := a.g.Nodes()
 
 	// Basic Degree Centrality
@@ -1139,6 +1426,9 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 		}
 	}
 
+	// Dependency cycle clusters
+	stats.SCC = a.stronglyConnectedComponents()
+
 	// Density
 	n := float64(len(a.issueMap))
 	e := float64(a.g.Edges().Len())
@@ -1177,6 +1467,7 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 				KCore:        failEntry,
 				Articulation: failEntry,
 				Slack:        failEntry,
+				Criticality:  failEntry,
 			}
 			stats.phase2Ready = true
 		}
@@ -1209,7 +1500,11 @@ func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 				}
 			}
 		}
-		heights[nid] = 1.0 + maxParentHeight
+		cost := 1.0
+		if a.weight != nil {
+			cost = a.weight(a.issueMap[a.nodeToID[nid]])
+		}
+		heights[nid] = cost + maxParentHeight
 		impactScores[a.nodeToID[nid]] = heights[nid]
 	}
 
@@ -1519,17 +1814,8 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 		}
 
 		isBlocked := false
-		for _, dep := range issue.Dependencies {
-			if dep == nil || !dep.Type.IsBlocking() {
-				continue
-			}
-
-			blocker, exists := a.issueMap[dep.DependsOnID]
-			if !exists {
-				continue
-			}
-
-			if blocker.Status != model.StatusClosed {
+		for _, blockerID := range a.blockerIDs(issue) {
+			if a.issueMap[blockerID].Status != model.StatusClosed {
 				isBlocked = true
 				break
 			}
@@ -1558,15 +1844,7 @@ func (a *Analyzer) GetBlockers(issueID string) []string {
 		return nil
 	}
 
-	var blockers []string
-	for _, dep := range issue.Dependencies {
-		if dep != nil && dep.Type.IsBlocking() {
-			if _, exists := a.issueMap[dep.DependsOnID]; exists {
-				blockers = append(blockers, dep.DependsOnID)
-			}
-		}
-	}
-	return blockers
+	return a.blockerIDs(issue)
 }
 
 // GetOpenBlockers returns the IDs of non-closed issues that block the given issue
@@ -1577,18 +1855,491 @@ func (a *Analyzer) GetOpenBlockers(issueID string) []string {
 	}
 
 	var openBlockers []string
-	for _, dep := range issue.Dependencies {
-		if dep != nil && dep.Type.IsBlocking() {
-			if blocker, exists := a.issueMap[dep.DependsOnID]; exists {
-				if blocker.Status != model.StatusClosed {
-					openBlockers = append(openBlockers, dep.DependsOnID)
-				}
-			}
+	for _, blockerID := range a.blockerIDs(issue) {
+		if a.issueMap[blockerID].Status != model.StatusClosed {
+			openBlockers = append(openBlockers, blockerID)
 		}
 	}
 	return openBlockers
 }
 
+// FalselyBlocked returns the sorted IDs of open issues that list at least one
+// blocking prerequisite, all of which are closed (or tombstoned). Such issues
+// look blocked but are actually ready to start. statusFn supplies the current
+// status for an ID; pass nil to use the statuses the analyzer was built with.
+// Dependencies on unknown issues are ignored.
+func (a *Analyzer) FalselyBlocked(statusFn func(id string) model.Status) []string {
+	if statusFn == nil {
+		statusFn = func(id string) model.Status {
+			return a.issueMap[id].Status
+		}
+	}
+	isDone := func(status model.Status) bool {
+		return status.IsClosed() || status.IsTombstone()
+	}
+
+	var result []string
+	for id, issue := range a.issueMap {
+		if isDone(statusFn(id)) {
+			continue
+		}
+		blockers := 0
+		allClosed := true
+		for _, blockerID := range a.blockerIDs(issue) {
+			blockers++
+			if !isDone(statusFn(blockerID)) {
+				allClosed = false
+				break
+			}
+		}
+		if blockers > 0 && allClosed {
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// CoverageByRoot returns, for each root epic (an epic with no parent), the
+// fraction of all issues that sit beneath it in the parent-child hierarchy:
+// its transitive descendant count over the total issue count. Blocking edges
+// are ignored.
+func (a *Analyzer) CoverageByRoot() map[string]float64 {
+	coverage := make(map[string]float64)
+	total := len(a.issueMap)
+	if total == 0 {
+		return coverage
+	}
+
+	children := make(map[string][]string)
+	hasParent := make(map[string]bool)
+	for id, issue := range a.issueMap {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if _, exists := a.issueMap[dep.DependsOnID]; exists {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], id)
+				hasParent[id] = true
+			}
+		}
+	}
+
+	for id, issue := range a.issueMap {
+		if issue.IssueType != model.TypeEpic || hasParent[id] {
+			continue
+		}
+		seen := map[string]bool{id: true}
+		stack := append([]string(nil), children[id]...)
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[cur] {
+				continue
+			}
+			seen[cur] = true
+			stack = append(stack, children[cur]...)
+		}
+		coverage[id] = float64(len(seen)-1) / float64(total)
+	}
+	return coverage
+}
+
+// Roots returns the IDs of issues that depend on nothing, sorted: with edges
+// u -> v meaning "u depends on v", a root has no outgoing edges (OutDegree 0).
+// Roots can be started right away; other issues may still depend on them.
+func (a *Analyzer) Roots() []string {
+	var roots []string
+	for id, nid := range a.idToNode {
+		if a.g.From(nid).Len() == 0 {
+			roots = append(roots, id)
+		}
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// Leaves returns the IDs of issues that nothing depends on, sorted: with edges
+// u -> v meaning "u depends on v", a leaf has no incoming edges (InDegree 0).
+// Finishing a leaf unblocks nothing else. An isolated issue is both.
+func (a *Analyzer) Leaves() []string {
+	var leaves []string
+	for id, nid := range a.idToNode {
+		if a.g.To(nid).Len() == 0 {
+			leaves = append(leaves, id)
+		}
+	}
+	sort.Strings(leaves)
+	return leaves
+}
+
+// Descendants returns the IDs of every issue beneath id in the parent-child
+// hierarchy (children, grandchildren, ...), not including id itself. Blocking
+// edges are ignored. Returns an empty set for unknown IDs or leaf issues.
+func (a *Analyzer) Descendants(id string) map[string]bool {
+	children := make(map[string][]string)
+	for childID, issue := range a.issueMap {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], childID)
+			}
+		}
+	}
+
+	descendants := make(map[string]bool)
+	stack := append([]string(nil), children[id]...)
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if cur == id || descendants[cur] {
+			continue
+		}
+		descendants[cur] = true
+		stack = append(stack, children[cur]...)
+	}
+	return descendants
+}
+
+// Islands returns the IDs of issues with no blocking dependencies in either
+// direction (InDegree and OutDegree both zero), sorted. The graph metrics say
+// nothing useful about them.
+func (a *Analyzer) Islands() []string {
+	var islands []string
+	for id, nid := range a.idToNode {
+		if a.g.From(nid).Len() == 0 && a.g.To(nid).Len() == 0 {
+			islands = append(islands, id)
+		}
+	}
+	sort.Strings(islands)
+	return islands
+}
+
+// SuggestedOrder returns the open issues in a suggested work order: every
+// issue comes after its open blockers, and whenever several issues are ready
+// the highest-priority one (lowest Priority value, then ID) goes first.
+// Closed issues count as done and are omitted, as are issues caught in or
+// behind a dependency cycle, which have no valid place in the order.
+func (a *Analyzer) SuggestedOrder() []string {
+	pending := make(map[string]int) // Open issue -> open blockers not yet ordered
+	var ready readyQueue
+	for id, issue := range a.issueMap {
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		blockers := 0
+		it := a.g.From(a.idToNode[id])
+		for it.Next() {
+			if blocker := a.issueMap[a.nodeToID[it.Node().ID()]]; blocker.Status != model.StatusClosed {
+				blockers++
+			}
+		}
+		if blockers == 0 {
+			ready = append(ready, readyItem{id: id, priority: issue.Priority})
+		} else {
+			pending[id] = blockers
+		}
+	}
+	heap.Init(&ready)
+
+	order := make([]string, 0, len(ready)+len(pending))
+	for ready.Len() > 0 {
+		next := heap.Pop(&ready).(readyItem).id
+		order = append(order, next)
+
+		dependents := a.g.To(a.idToNode[next])
+		for dependents.Next() {
+			depID := a.nodeToID[dependents.Node().ID()]
+			if _, waiting := pending[depID]; !waiting {
+				continue
+			}
+			pending[depID]--
+			if pending[depID] == 0 {
+				delete(pending, depID)
+				heap.Push(&ready, readyItem{id: depID, priority: a.issueMap[depID].Priority})
+			}
+		}
+	}
+	return order
+}
+
+// readyItem is an issue whose blockers are all done, keyed for SuggestedOrder.
+type readyItem struct {
+	id       string
+	priority int
+}
+
+// readyQueue is a min-heap of readyItems ordered by priority, then ID.
+type readyQueue []readyItem
+
+func (q readyQueue) Len() int { return len(q) }
+func (q readyQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].id < q[j].id
+}
+func (q readyQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *readyQueue) Push(x any)   { *q = append(*q, x.(readyItem)) }
+func (q *readyQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// CriticalPath returns the issue IDs of the longest dependency chain, from
+// the issue that must be done first to the last dependent, measured the way
+// the critical path score is (in hops, or in weight for NewWeightedAnalyzer).
+// It starts at the issue with the highest score and repeatedly steps to the
+// dependent with the highest score; ties go to the lowest ID. Returns nil if
+// the graph is empty or has a dependency cycle, which has no longest path.
+func (a *Analyzer) CriticalPath() []string {
+	sorted, err := topo.Sort(a.g)
+	if err != nil || len(sorted) == 0 {
+		return nil
+	}
+	heights := a.computeHeights(sorted)
+
+	better := func(id, than string) bool {
+		if than == "" {
+			return true
+		}
+		if heights[id] != heights[than] {
+			return heights[id] > heights[than]
+		}
+		return id < than
+	}
+
+	current := ""
+	for id := range heights {
+		if better(id, current) {
+			current = id
+		}
+	}
+
+	var path []string
+	for current != "" {
+		path = append(path, current)
+		next := ""
+		dependents := a.g.To(a.idToNode[current])
+		for dependents.Next() {
+			if id := a.nodeToID[dependents.Node().ID()]; better(id, next) {
+				next = id
+			}
+		}
+		current = next
+	}
+	return path
+}
+
+// CommonPrerequisites returns the issues that both a and b transitively
+// depend on, nearest first: ordered by the farther of the two hop distances,
+// then by the combined distance, then by ID. Returns nil if either ID is
+// unknown or they share no prerequisites.
+func (a *Analyzer) CommonPrerequisites(idA, idB string) []string {
+	nodeA, okA := a.idToNode[idA]
+	nodeB, okB := a.idToNode[idB]
+	if !okA || !okB {
+		return nil
+	}
+
+	distA := a.prerequisiteDistances(nodeA)
+	distB := a.prerequisiteDistances(nodeB)
+
+	type candidate struct {
+		id       string
+		far, sum int
+	}
+	var shared []candidate
+	for nid, da := range distA {
+		db, ok := distB[nid]
+		if !ok {
+			continue
+		}
+		far := da
+		if db > far {
+			far = db
+		}
+		shared = append(shared, candidate{id: a.nodeToID[nid], far: far, sum: da + db})
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+
+	sort.Slice(shared, func(i, j int) bool {
+		if shared[i].far != shared[j].far {
+			return shared[i].far < shared[j].far
+		}
+		if shared[i].sum != shared[j].sum {
+			return shared[i].sum < shared[j].sum
+		}
+		return shared[i].id < shared[j].id
+	})
+
+	result := make([]string, len(shared))
+	for i, c := range shared {
+		result[i] = c.id
+	}
+	return result
+}
+
+// TransitiveImpact returns the sorted IDs of every issue that transitively
+// depends on issueID: what slips if it is delayed. The issue itself is left
+// out even when it sits on a cycle. Returns nil for an unknown ID.
+func (a *Analyzer) TransitiveImpact(issueID string) []string {
+	return a.reachableIDs(issueID, a.g.To)
+}
+
+// TransitiveDependencies returns the sorted IDs of every issue that issueID
+// transitively depends on. The issue itself is left out even when it sits on
+// a cycle. Returns nil for an unknown ID.
+func (a *Analyzer) TransitiveDependencies(issueID string) []string {
+	return a.reachableIDs(issueID, a.g.From)
+}
+
+// reachableIDs walks next from issueID with a visited set, so cycles
+// terminate, and returns the IDs reached, sorted.
+func (a *Analyzer) reachableIDs(issueID string, next func(id int64) graph.Nodes) []string {
+	start, ok := a.idToNode[issueID]
+	if !ok {
+		return nil
+	}
+	visited := map[int64]bool{start: true}
+	stack := []int64{start}
+	var ids []string
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		it := next(cur)
+		for it.Next() {
+			nid := it.Node().ID()
+			if visited[nid] {
+				continue
+			}
+			visited[nid] = true
+			ids = append(ids, a.nodeToID[nid])
+			stack = append(stack, nid)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// prerequisiteDistances returns the BFS hop count from start to every issue it
+// transitively depends on (start itself excluded).
+func (a *Analyzer) prerequisiteDistances(start int64) map[int64]int {
+	dist := map[int64]int{start: 0}
+	queue := []int64{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		from := a.g.From(cur)
+		for from.Next() {
+			nid := from.Node().ID()
+			if _, seen := dist[nid]; seen {
+				continue
+			}
+			dist[nid] = dist[cur] + 1
+			queue = append(queue, nid)
+		}
+	}
+	delete(dist, start)
+	return dist
+}
+
+// WouldCreateCycle reports whether adding the dependency "from depends on to"
+// would close a loop, i.e. to already (transitively) depends on from. A
+// self-dependency always counts; edges touching unknown issues never do.
+func (a *Analyzer) WouldCreateCycle(from, to string) bool {
+	if from == to {
+		return true
+	}
+	fromNode, okFrom := a.idToNode[from]
+	toNode, okTo := a.idToNode[to]
+	if !okFrom || !okTo {
+		return false
+	}
+
+	seen := map[int64]bool{toNode: true}
+	stack := []int64{toNode}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		prereqs := a.g.From(cur)
+		for prereqs.Next() {
+			nid := prereqs.Node().ID()
+			if nid == fromNode {
+				return true
+			}
+			if !seen[nid] {
+				seen[nid] = true
+				stack = append(stack, nid)
+			}
+		}
+	}
+	return false
+}
+
+// allPathsLimit caps how many paths AllPaths returns, so dense graphs cannot
+// blow up the enumeration even within the depth bound.
+const allPathsLimit = 1000
+
+// AllPaths enumerates the simple dependency paths from "from" to "to", i.e.
+// all the ways from (transitively) depends on to. Each path starts with from
+// and ends with to; maxDepth bounds the number of edges per path. Paths are
+// found in lexical order of their IDs and at most allPathsLimit are returned.
+// Returns nil for unknown issues, from == to, or a non-positive maxDepth.
+func (a *Analyzer) AllPaths(from, to string, maxDepth int) [][]string {
+	if from == to || maxDepth <= 0 {
+		return nil
+	}
+	fromNode, okFrom := a.idToNode[from]
+	toNode, okTo := a.idToNode[to]
+	if !okFrom || !okTo {
+		return nil
+	}
+
+	var paths [][]string
+	onPath := map[int64]bool{fromNode: true}
+	path := []string{from}
+
+	var walk func(cur int64)
+	walk = func(cur int64) {
+		if len(paths) >= allPathsLimit || len(path) > maxDepth {
+			return
+		}
+		var next []int64
+		prereqs := a.g.From(cur)
+		for prereqs.Next() {
+			next = append(next, prereqs.Node().ID())
+		}
+		sort.Slice(next, func(i, j int) bool { return a.nodeToID[next[i]] < a.nodeToID[next[j]] })
+
+		for _, nid := range next {
+			if len(paths) >= allPathsLimit {
+				return
+			}
+			if nid == toNode {
+				found := make([]string, len(path), len(path)+1)
+				copy(found, path)
+				paths = append(paths, append(found, to))
+				continue
+			}
+			if onPath[nid] {
+				continue
+			}
+			onPath[nid] = true
+			path = append(path, a.nodeToID[nid])
+			walk(nid)
+			path = path[:len(path)-1]
+			delete(onPath, nid)
+		}
+	}
+	walk(fromNode)
+
+	return paths
+}
+
 // BlockerChainEntry represents a single entry in a blocker chain.
 type BlockerChainEntry struct {
 	ID          string `json:"id"`
@@ -1726,16 +2477,15 @@ func (a *Analyzer) GetBlockerChain(issueID string) *BlockerChainResult {
 
 // countBlockedBy returns the number of open issues that are blocked by the given issue.
 func (a *Analyzer) countBlockedBy(issueID string) int {
+	node, ok := a.idToNode[issueID]
+	if !ok {
+		return 0
+	}
 	count := 0
-	for _, issue := range a.issueMap {
-		if issue.Status == model.StatusClosed {
-			continue
-		}
-		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type.IsBlocking() && dep.DependsOnID == issueID {
-				count++
-				break
-			}
+	dependents := a.g.To(node)
+	for dependents.Next() {
+		if a.issueMap[a.nodeToID[dependents.Node().ID()]].Status != model.StatusClosed {
+			count++
 		}
 	}
 	return count
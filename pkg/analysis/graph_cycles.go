@@ -144,4 +144,54 @@ func findOneCycleInSCC(g graph.Directed, scc []graph.Node) []graph.Node {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// canonicalCycle rotates a cycle of issue IDs to start at its lexicographically
+// smallest ID, preserving direction. A closed cycle (first ID repeated at the
+// end) stays closed.
+func canonicalCycle(cycle []string) []string {
+	if len(cycle) == 0 {
+		return cycle
+	}
+	closed := len(cycle) > 1 && cycle[0] == cycle[len(cycle)-1]
+	ring := cycle
+	if closed {
+		ring = cycle[:len(cycle)-1]
+	}
+
+	minIdx := 0
+	for i, id := range ring {
+		if id < ring[minIdx] {
+			minIdx = i
+		}
+	}
+
+	out := make([]string, 0, len(cycle))
+	for i := range ring {
+		out = append(out, ring[(minIdx+i)%len(ring)])
+	}
+	if closed {
+		out = append(out, out[0])
+	}
+	return out
+}
+
+// canonicalizeCycles normalizes every cycle in place and sorts the list by
+// length (shortest first), then lexicographically by ID, so equal graphs
+// produce equal cycle output regardless of node numbering.
+func canonicalizeCycles(cycles [][]string) {
+	for i, cycle := range cycles {
+		cycles[i] = canonicalCycle(cycle)
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		if len(cycles[i]) != len(cycles[j]) {
+			return len(cycles[i]) < len(cycles[j])
+		}
+		for k := range cycles[i] {
+			if cycles[i][k] != cycles[j][k] {
+				return cycles[i][k] < cycles[j][k]
+			}
+		}
+		return false
+	})
+}
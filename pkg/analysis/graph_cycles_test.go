@@ -1,8 +1,10 @@
 package analysis
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
 	graph "gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
@@ -353,3 +355,47 @@ func BenchmarkFindOneCycleInSCC(b *testing.B) {
 		findOneCycleInSCC(g, toGraphNodes(scc))
 	}
 }
+
+func TestCanonicalCycle(t *testing.T) {
+	tests := []struct {
+		in, want []string
+	}{
+		{[]string{"c", "a", "b", "c"}, []string{"a", "b", "c", "a"}},
+		{[]string{"b", "c", "a"}, []string{"a", "b", "c"}},
+		{[]string{"x", "x"}, []string{"x", "x"}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := canonicalCycle(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("canonicalCycle(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGraphStatsCyclesStableAcrossInputOrder(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "z-1", Dependencies: blockedBy("z-2")},
+		{ID: "z-2", Dependencies: blockedBy("z-1")},
+		{ID: "m-3", Dependencies: blockedBy("m-1")},
+		{ID: "m-1", Dependencies: blockedBy("m-2")},
+		{ID: "m-2", Dependencies: blockedBy("m-3")},
+	}
+	reversed := make([]model.Issue, len(issues))
+	for i, issue := range issues {
+		reversed[len(issues)-1-i] = issue
+	}
+
+	first := NewAnalyzer(issues).Analyze()
+	second := NewAnalyzer(reversed).Analyze()
+
+	want := [][]string{
+		{"z-1", "z-2", "z-1"},
+		{"m-1", "m-3", "m-2", "m-1"},
+	}
+	if got := first.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("cycles = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(first.Cycles(), second.Cycles()) {
+		t.Errorf("cycles depend on input order: %v vs %v", first.Cycles(), second.Cycles())
+	}
+}
@@ -7,6 +7,20 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
+// blockedBy returns blocking dependencies on each of ids.
+func blockedBy(ids ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, id := range ids {
+		deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+	}
+	return deps
+}
+
+// childOf returns a parent-child dependency on parent.
+func childOf(parent string) []*model.Dependency {
+	return []*model.Dependency{{DependsOnID: parent, Type: model.DepParentChild}}
+}
+
 // Cover getter and configured analysis pathways that were previously untested.
 func TestAnalyzerProfileAndGetters(t *testing.T) {
 	issues := []model.Issue{
@@ -2,6 +2,7 @@ package analysis_test
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"testing"
 	"time"
@@ -10,6 +11,20 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
+// blockedBy returns blocking dependencies on each of ids.
+func blockedBy(ids ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, id := range ids {
+		deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+	}
+	return deps
+}
+
+// childOf returns a parent-child dependency on parent.
+func childOf(parent string) []*model.Dependency {
+	return []*model.Dependency{{DependsOnID: parent, Type: model.DepParentChild}}
+}
+
 // Helper to extract IDs from issues and sort them for comparison
 func getIDs(issues []model.Issue) []string {
 	ids := make([]string, len(issues))
@@ -59,6 +74,98 @@ func TestAnalyzeEmptyIssues(t *testing.T) {
 	}
 }
 
+// TestAnalyzeTrivialGraphs covers the 0, 1 and 2 node edge cases, where
+// density, normalization and critical path have n<=1 special cases.
+func TestAnalyzeTrivialGraphs(t *testing.T) {
+	tests := []struct {
+		name         string
+		issues       []model.Issue
+		density      float64
+		pageRank     map[string]float64 // Expected within 1e-6; nil skips
+		criticalPath string
+	}{
+		{
+			name:         "empty",
+			issues:       nil,
+			density:      0,
+			pageRank:     map[string]float64{},
+			criticalPath: "map[]",
+		},
+		{
+			name:         "single",
+			issues:       []model.Issue{{ID: "A", Status: model.StatusOpen}},
+			density:      0,
+			pageRank:     map[string]float64{"A": 1},
+			criticalPath: "map[A:1]",
+		},
+		{
+			name: "two with one edge",
+			issues: []model.Issue{
+				{ID: "A", Status: model.StatusOpen},
+				{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+			},
+			density:      0.5,
+			criticalPath: "map[A:2 B:1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			an := analysis.NewAnalyzer(tt.issues)
+			stats := an.AnalyzeWithConfig(analysis.FullAnalysisConfig())
+
+			if stats.Density != tt.density {
+				t.Errorf("Density = %v, want %v", stats.Density, tt.density)
+			}
+			if len(stats.Cycles()) != 0 {
+				t.Errorf("Cycles = %v, want none", stats.Cycles())
+			}
+			if got := fmt.Sprint(stats.CriticalPathScore()); got != tt.criticalPath {
+				t.Errorf("CriticalPathScore = %s, want %s", got, tt.criticalPath)
+			}
+
+			pr := stats.PageRank()
+			if tt.pageRank != nil {
+				if len(pr) != len(tt.pageRank) {
+					t.Errorf("PageRank = %v, want %v", pr, tt.pageRank)
+				}
+				for id, want := range tt.pageRank {
+					if math.Abs(pr[id]-want) > 1e-6 {
+						t.Errorf("PageRank[%s] = %v, want %v", id, pr[id], want)
+					}
+				}
+			}
+
+			metrics := map[string]map[string]float64{
+				"PageRank":     pr,
+				"Betweenness":  stats.Betweenness(),
+				"Eigenvector":  stats.Eigenvector(),
+				"Hubs":         stats.Hubs(),
+				"Authorities":  stats.Authorities(),
+				"CriticalPath": stats.CriticalPathScore(),
+				"Slack":        stats.Slack(),
+				"Criticality":  stats.CriticalityScore(),
+			}
+			for metric, scores := range metrics {
+				if len(tt.issues) == 0 && len(scores) != 0 {
+					t.Errorf("%s should be empty for no issues, got %v", metric, scores)
+				}
+				for id, v := range scores {
+					if math.IsNaN(v) || math.IsInf(v, 0) {
+						t.Errorf("%s[%s] = %v", metric, id, v)
+					}
+				}
+			}
+
+			// Downstream consumers must also cope without panicking.
+			_ = stats.GenerateInsights(5)
+			_ = an.GetExecutionPlan()
+			_ = an.ComputeImpactScores()
+			_ = analysis.ComputeTriage(tt.issues)
+		})
+	}
+}
+
 func TestGetActionableIssuesAllClosed(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "A", Status: model.StatusClosed},
@@ -425,6 +532,613 @@ func TestGetOpenBlockers(t *testing.T) {
 	}
 }
 
+func TestRootsAndLeaves(t *testing.T) {
+	// Chain: C depends on B, B depends on A; D is isolated
+	issues := []model.Issue{
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+
+	roots := an.Roots()
+	if fmt.Sprint(roots) != "[A D]" {
+		t.Errorf("Expected roots [A D], got %v", roots)
+	}
+
+	leaves := an.Leaves()
+	if fmt.Sprint(leaves) != "[C D]" {
+		t.Errorf("Expected leaves [C D], got %v", leaves)
+	}
+
+	empty := analysis.NewAnalyzer(nil)
+	if len(empty.Roots()) != 0 || len(empty.Leaves()) != 0 {
+		t.Error("Expected no roots or leaves for empty graph")
+	}
+}
+
+func TestCommonPrerequisites(t *testing.T) {
+	// Diamond: Left and Right both depend on Base, which depends on Core.
+	// Top depends on Left and Right; Other is unrelated.
+	issues := []model.Issue{
+		{ID: "Top", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "Left", Type: model.DepBlocks},
+			{DependsOnID: "Right", Type: model.DepBlocks},
+		}},
+		{ID: "Left", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "Base", Type: model.DepBlocks},
+		}},
+		{ID: "Right", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "Base", Type: model.DepBlocks},
+		}},
+		{ID: "Base", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "Core", Type: model.DepBlocks},
+		}},
+		{ID: "Core", Status: model.StatusOpen},
+		{ID: "Other", Status: model.StatusOpen},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+
+	if got := an.CommonPrerequisites("Left", "Right"); fmt.Sprint(got) != "[Base Core]" {
+		t.Errorf("Expected [Base Core], got %v", got)
+	}
+	if got := an.CommonPrerequisites("Top", "Left"); fmt.Sprint(got) != "[Base Core]" {
+		t.Errorf("Expected [Base Core] for Top/Left, got %v", got)
+	}
+	if got := an.CommonPrerequisites("Left", "Other"); got != nil {
+		t.Errorf("Expected nil for unrelated issues, got %v", got)
+	}
+	if got := an.CommonPrerequisites("Left", "missing"); got != nil {
+		t.Errorf("Expected nil for unknown ID, got %v", got)
+	}
+}
+
+func TestFalselyBlocked(t *testing.T) {
+	issues := []model.Issue{
+		// Sole blocker is closed: falsely blocked
+		{ID: "ready", Status: model.StatusBlocked, Dependencies: []*model.Dependency{
+			{DependsOnID: "done", Type: model.DepBlocks},
+		}},
+		{ID: "done", Status: model.StatusClosed},
+		// One closed and one open blocker: genuinely blocked
+		{ID: "waiting", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "done", Type: model.DepBlocks},
+			{DependsOnID: "active", Type: model.DepBlocks},
+		}},
+		{ID: "active", Status: model.StatusInProgress},
+		// Closed itself: not reported
+		{ID: "finished", Status: model.StatusClosed, Dependencies: []*model.Dependency{
+			{DependsOnID: "done", Type: model.DepBlocks},
+		}},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+
+	if got := an.FalselyBlocked(nil); fmt.Sprint(got) != "[ready]" {
+		t.Errorf("Expected [ready], got %v", got)
+	}
+
+	// A status override closing "active" frees "waiting" too
+	override := func(id string) model.Status {
+		if id == "active" {
+			return model.StatusClosed
+		}
+		return an.GetIssue(id).Status
+	}
+	if got := an.FalselyBlocked(override); fmt.Sprint(got) != "[ready waiting]" {
+		t.Errorf("Expected [ready waiting] with override, got %v", got)
+	}
+}
+
+func TestCoverageByRoot(t *testing.T) {
+	issues := []model.Issue{
+		// Big epic: two direct children and one grandchild
+		{ID: "epic-big", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "big-1", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("epic-big")},
+		{ID: "big-2", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("epic-big")},
+		{ID: "big-1a", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("big-1")},
+		// Small epic: one child, plus a blocking edge that must not count
+		{ID: "epic-small", Status: model.StatusOpen, IssueType: model.TypeEpic, Dependencies: []*model.Dependency{
+			{DependsOnID: "loose", Type: model.DepBlocks},
+		}},
+		{ID: "small-1", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("epic-small")},
+		// Unparented task
+		{ID: "loose", Status: model.StatusOpen, IssueType: model.TypeTask},
+		// Nested epic is not a root
+		{ID: "epic-nested", Status: model.StatusOpen, IssueType: model.TypeEpic, Dependencies: []*model.Dependency{
+			{DependsOnID: "epic-small", Type: model.DepParentChild},
+		}},
+	}
+
+	coverage := analysis.NewAnalyzer(issues).CoverageByRoot()
+
+	if len(coverage) != 2 {
+		t.Fatalf("Expected 2 root epics, got %v", coverage)
+	}
+	if got, want := coverage["epic-big"], 3.0/8.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("epic-big coverage = %f, want %f", got, want)
+	}
+	if got, want := coverage["epic-small"], 2.0/8.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("epic-small coverage = %f, want %f", got, want)
+	}
+}
+
+func TestWouldCreateCycle(t *testing.T) {
+	// Chain: A depends on B, B depends on C. D is unrelated.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+
+	// Safe: A already depends on C transitively; D has no links.
+	for _, edge := range [][2]string{{"A", "C"}, {"D", "A"}, {"C", "D"}, {"A", "Missing"}} {
+		if an.WouldCreateCycle(edge[0], edge[1]) {
+			t.Errorf("Expected %s->%s to be safe", edge[0], edge[1])
+		}
+	}
+
+	// Cycle-closing: C depending on A (or B) loops back through the chain.
+	for _, edge := range [][2]string{{"C", "A"}, {"B", "A"}, {"C", "B"}, {"D", "D"}} {
+		if !an.WouldCreateCycle(edge[0], edge[1]) {
+			t.Errorf("Expected %s->%s to close a cycle", edge[0], edge[1])
+		}
+	}
+}
+
+func TestDependencyDirectionDegrees(t *testing.T) {
+	// Same records, read both ways: A lists B, B lists C.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen},
+	}
+
+	tests := []struct {
+		name        string
+		opts        []analysis.AnalyzerOption
+		out, in     string
+		blockersOfA string
+		blockersOfC string
+		actionable  string
+	}{
+		{
+			// Default: A depends on B depends on C; C is the root prerequisite.
+			name:        "depends-on",
+			out:         "map[A:1 B:1 C:0]",
+			in:          "map[A:0 B:1 C:1]",
+			blockersOfA: "[B]",
+			blockersOfC: "[]",
+			actionable:  "[C]",
+		},
+		{
+			// Blocks: A blocks B blocks C; A is the root prerequisite.
+			name:        "blocks",
+			opts:        []analysis.AnalyzerOption{analysis.WithDependencyDirection(analysis.Blocks)},
+			out:         "map[A:0 B:1 C:1]",
+			in:          "map[A:1 B:1 C:0]",
+			blockersOfA: "[]",
+			blockersOfC: "[B]",
+			actionable:  "[A]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			an := analysis.NewAnalyzer(issues, tt.opts...)
+			stats := an.Analyze()
+
+			if got := fmt.Sprint(stats.OutDegree); got != tt.out {
+				t.Errorf("OutDegree = %s, want %s", got, tt.out)
+			}
+			if got := fmt.Sprint(stats.InDegree); got != tt.in {
+				t.Errorf("InDegree = %s, want %s", got, tt.in)
+			}
+			if got := fmt.Sprint(an.GetBlockers("A")); got != tt.blockersOfA {
+				t.Errorf("GetBlockers(A) = %s, want %s", got, tt.blockersOfA)
+			}
+			if got := fmt.Sprint(an.GetOpenBlockers("C")); got != tt.blockersOfC {
+				t.Errorf("GetOpenBlockers(C) = %s, want %s", got, tt.blockersOfC)
+			}
+			var ids []string
+			for _, issue := range an.GetActionableIssues() {
+				ids = append(ids, issue.ID)
+			}
+			if got := fmt.Sprint(ids); got != tt.actionable {
+				t.Errorf("actionable = %s, want %s", got, tt.actionable)
+			}
+		})
+	}
+}
+
+func TestAllPathsDiamond(t *testing.T) {
+	// A depends on B and C, both of which depend on D.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "D", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "D", Type: model.DepBlocks},
+		}},
+		{ID: "D", Status: model.StatusOpen},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+
+	if got := fmt.Sprint(an.AllPaths("A", "D", 5)); got != "[[A B D] [A C D]]" {
+		t.Errorf("AllPaths(A, D) = %s, want [[A B D] [A C D]]", got)
+	}
+	// Both paths need two edges.
+	if got := an.AllPaths("A", "D", 1); len(got) != 0 {
+		t.Errorf("AllPaths with depth 1 = %v, want none", got)
+	}
+	// Paths only run along dependencies, never against them.
+	if got := an.AllPaths("D", "A", 5); len(got) != 0 {
+		t.Errorf("AllPaths(D, A) = %v, want none", got)
+	}
+}
+
+func TestIslands(t *testing.T) {
+	// A depends on B; C is unconnected. The related link does not count.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "A", Type: model.DepRelated},
+		}},
+	}
+
+	if got := fmt.Sprint(analysis.NewAnalyzer(issues).Islands()); got != "[C]" {
+		t.Errorf("Islands() = %s, want [C]", got)
+	}
+	if got := analysis.NewAnalyzer(nil).Islands(); len(got) != 0 {
+		t.Errorf("Islands() on empty graph = %v, want none", got)
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic", Status: model.StatusOpen},
+		{ID: "feat", Status: model.StatusOpen, Dependencies: childOf("epic")},
+		{ID: "task", Status: model.StatusOpen, Dependencies: childOf("feat")},
+		// Blocking links are not part of the hierarchy.
+		{ID: "other", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "epic", Type: model.DepBlocks},
+		}},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+	if got := fmt.Sprint(an.Descendants("epic")); got != "map[feat:true task:true]" {
+		t.Errorf("Descendants(epic) = %s", got)
+	}
+	if got := an.Descendants("task"); len(got) != 0 {
+		t.Errorf("Descendants(task) = %v, want none", got)
+	}
+}
+
+func TestTopByDegreeStableTies(t *testing.T) {
+	// In-degrees: A=3, B=2, C=2, D=0, E=0, F=0. Out-degrees: D=3, E=2, F=2.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "F", Status: model.StatusOpen, Dependencies: blockedBy("A", "C")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: blockedBy("A", "B")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blockedBy("A", "B", "C")},
+	}
+
+	for i := 0; i < 5; i++ {
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		if got := fmt.Sprint(stats.TopByInDegree(3)); got != "[A B C]" {
+			t.Fatalf("TopByInDegree(3) = %s, want [A B C]", got)
+		}
+		if got := fmt.Sprint(stats.TopByOutDegree(3)); got != "[D E F]" {
+			t.Fatalf("TopByOutDegree(3) = %s, want [D E F]", got)
+		}
+		if got := fmt.Sprint(stats.TopByInDegree(5)); got != "[A B C D E]" {
+			t.Fatalf("TopByInDegree(5) = %s, want zero-degree ties ordered by ID", got)
+		}
+	}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	if got := len(stats.TopByInDegree(100)); got != len(issues) {
+		t.Errorf("TopByInDegree(100) returned %d IDs, want all %d", got, len(issues))
+	}
+	if got := stats.TopByOutDegree(0); len(got) != 0 {
+		t.Errorf("TopByOutDegree(0) = %v, want empty", got)
+	}
+}
+
+func TestSuggestedOrder(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Priority: 3, Status: model.StatusOpen},
+		{ID: "B", Priority: 0, Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "C", Priority: 1, Status: model.StatusOpen},
+		{ID: "D", Priority: 2, Status: model.StatusInProgress},
+		{ID: "E", Priority: 1, Status: model.StatusOpen, Dependencies: blockedBy("F")},
+		{ID: "F", Priority: 0, Status: model.StatusClosed},
+		{ID: "G", Priority: 0, Status: model.StatusOpen, Dependencies: blockedBy("H")},
+		{ID: "H", Priority: 0, Status: model.StatusOpen, Dependencies: blockedBy("G")},
+	}
+
+	// "A B C D E" also respects every dependency, but C, E and D outrank A
+	// while B waits on it. F is closed; G and H block each other.
+	got := fmt.Sprint(analysis.NewAnalyzer(issues).SuggestedOrder())
+	if want := "[C E D A B]"; got != want {
+		t.Errorf("SuggestedOrder = %s, want %s", got, want)
+	}
+}
+
+func TestWeightedCriticalPath(t *testing.T) {
+	minutes := func(m int) *int { return &m }
+	chain := func(prefix string, n, effort int) []model.Issue {
+		var issues []model.Issue
+		for i := 1; i <= n; i++ {
+			issue := model.Issue{ID: fmt.Sprintf("%s%d", prefix, i), Status: model.StatusOpen, EstimatedMinutes: minutes(effort)}
+			if i > 1 {
+				issue.Dependencies = []*model.Dependency{{DependsOnID: fmt.Sprintf("%s%d", prefix, i-1), Type: model.DepBlocks}}
+			}
+			issues = append(issues, issue)
+		}
+		return issues
+	}
+	// Two 4-hour tasks against five 5-minute tasks
+	issues := append(chain("heavy-", 2, 240), chain("trivial-", 5, 5)...)
+
+	hops := analysis.NewAnalyzer(issues).Analyze()
+	if hops.GetCriticalPathScore("heavy-1") >= hops.GetCriticalPathScore("trivial-1") {
+		t.Fatalf("unweighted: the longer chain should rank first, got heavy=%v trivial=%v",
+			hops.GetCriticalPathScore("heavy-1"), hops.GetCriticalPathScore("trivial-1"))
+	}
+
+	effort := func(issue model.Issue) float64 {
+		if issue.EstimatedMinutes == nil {
+			return 1
+		}
+		return float64(*issue.EstimatedMinutes)
+	}
+	weighted := analysis.NewWeightedAnalyzer(issues, effort).Analyze()
+	if got := weighted.GetCriticalPathScore("heavy-1"); got != 480 {
+		t.Errorf("heavy-1 weighted height = %v, want 480", got)
+	}
+	if got := weighted.GetCriticalPathScore("trivial-1"); got != 25 {
+		t.Errorf("trivial-1 weighted height = %v, want 25", got)
+	}
+	if rank := weighted.CriticalPathRank(); rank["heavy-1"] >= rank["trivial-1"] {
+		t.Errorf("weighted: heavy chain should outrank trivial chain, ranks %d vs %d", rank["heavy-1"], rank["trivial-1"])
+	}
+
+	// Other metrics ignore the weights
+	hopsRank := hops.PageRank()
+	for id, pr := range weighted.PageRank() {
+		if math.Abs(pr-hopsRank[id]) > 1e-9 {
+			t.Errorf("PageRank[%s] = %v weighted, %v unweighted; should not depend on weights", id, pr, hopsRank[id])
+		}
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen},
+		{ID: "b", Status: model.StatusOpen, Dependencies: blockedBy("a")},
+		{ID: "c", Status: model.StatusOpen, Dependencies: blockedBy("a")}, // c and b tie; b wins on ID
+		{ID: "d", Status: model.StatusOpen, Dependencies: blockedBy("b", "c")},
+		{ID: "e", Status: model.StatusOpen, Dependencies: blockedBy("d")},
+		{ID: "x", Status: model.StatusOpen},
+		{ID: "y", Status: model.StatusOpen, Dependencies: blockedBy("x")},
+	}
+	if got, want := fmt.Sprint(analysis.NewAnalyzer(issues).CriticalPath()), "[a b d e]"; got != want {
+		t.Errorf("CriticalPath = %s, want %s", got, want)
+	}
+
+	// Weighted: the heavy two-issue chain beats the four-hop one
+	effort := func(issue model.Issue) float64 {
+		if issue.ID == "x" || issue.ID == "y" {
+			return 10
+		}
+		return 1
+	}
+	if got, want := fmt.Sprint(analysis.NewWeightedAnalyzer(issues, effort).CriticalPath()), "[x y]"; got != want {
+		t.Errorf("weighted CriticalPath = %s, want %s", got, want)
+	}
+
+	cyclic := append(issues,
+		model.Issue{ID: "p", Status: model.StatusOpen, Dependencies: blockedBy("q")},
+		model.Issue{ID: "q", Status: model.StatusOpen, Dependencies: blockedBy("p")},
+	)
+	if got := analysis.NewAnalyzer(cyclic).CriticalPath(); got != nil {
+		t.Errorf("CriticalPath with a cycle = %v, want nil", got)
+	}
+	if got := analysis.NewAnalyzer(nil).CriticalPath(); got != nil {
+		t.Errorf("CriticalPath of an empty graph = %v, want nil", got)
+	}
+}
+
+func TestAnalyzeSCC(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "e", Status: model.StatusOpen, Dependencies: blockedBy("c")},
+		{ID: "d", Status: model.StatusOpen, Dependencies: blockedBy("e")},
+		{ID: "c", Status: model.StatusOpen, Dependencies: blockedBy("d")},
+		{ID: "b", Status: model.StatusOpen, Dependencies: blockedBy("a")},
+		{ID: "a", Status: model.StatusOpen, Dependencies: blockedBy("b")},
+		{ID: "x", Status: model.StatusOpen, Dependencies: blockedBy("a")}, // depends on a cycle but is not part of one
+		{ID: "y", Status: model.StatusOpen},
+	}
+
+	// Cycle enumeration off, as on large graphs; SCC is still filled in
+	cfg := analysis.DefaultConfig()
+	cfg.ComputeCycles = false
+	stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+	if got, want := fmt.Sprint(stats.SCC), "[[a b] [c d e]]"; got != want {
+		t.Errorf("SCC = %s, want %s", got, want)
+	}
+	if stats.Cycles() != nil {
+		t.Errorf("Cycles should be skipped, got %v", stats.Cycles())
+	}
+
+	if scc := analysis.NewAnalyzer(issues[5:]).Analyze().SCC; scc != nil {
+		t.Errorf("acyclic graph SCC = %v, want nil", scc)
+	}
+}
+
+func TestAnalyzeWithConfigHonorsFlags(t *testing.T) {
+	// A deep chain and a dense graph are the worst cases for the always-on
+	// structural passes, so each shape must stay cheap with everything off.
+	for _, shape := range []struct {
+		name     string
+		generate func(int) []model.Issue
+	}{
+		{"sparse", generateSparseGraph},
+		{"chain", generateChainGraph},
+		{"dense", generateDenseGraph},
+	} {
+		t.Run("all skipped/"+shape.name, func(t *testing.T) {
+			cfg := analysis.AnalysisConfig{PageRankSkipReason: "off for this test"}
+			issues := shape.generate(10000)
+
+			start := time.Now()
+			stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("skipping every metric on a 10k-node %s graph took %v", shape.name, elapsed)
+			}
+
+			if len(stats.PageRank()) != 0 || len(stats.Betweenness()) != 0 ||
+				len(stats.CriticalPathScore()) != 0 || len(stats.CriticalityScore()) != 0 {
+				t.Error("skipped metrics should be empty")
+			}
+			status := stats.Status()
+			for name, entry := range map[string]string{
+				"pagerank":    status.PageRank.State,
+				"betweenness": status.Betweenness.State,
+				"hits":        status.HITS.State,
+				"cycles":      status.Cycles.State,
+				"criticality": status.Criticality.State,
+			} {
+				if entry != "skipped" {
+					t.Errorf("%s state = %q, want skipped", name, entry)
+				}
+			}
+			if status.PageRank.Reason != "off for this test" {
+				t.Errorf("PageRank skip reason = %q", status.PageRank.Reason)
+			}
+		})
+	}
+
+	t.Run("betweenness mode skip", func(t *testing.T) {
+		cfg := analysis.DefaultConfig()
+		cfg.BetweennessMode = analysis.BetweennessSkip
+		stats := analysis.NewAnalyzer(generateSparseGraph(50)).AnalyzeWithConfig(cfg)
+		if len(stats.Betweenness()) != 0 || stats.Status().Betweenness.State != "skipped" {
+			t.Errorf("BetweennessSkip should skip betweenness, state %q", stats.Status().Betweenness.State)
+		}
+	})
+
+	t.Run("max cycles", func(t *testing.T) {
+		var issues []model.Issue
+		for _, pair := range [][2]string{{"a", "b"}, {"c", "d"}, {"e", "f"}} {
+			issues = append(issues,
+				model.Issue{ID: pair[0], Dependencies: []*model.Dependency{{DependsOnID: pair[1], Type: model.DepBlocks}}},
+				model.Issue{ID: pair[1], Dependencies: []*model.Dependency{{DependsOnID: pair[0], Type: model.DepBlocks}}})
+		}
+		cfg := analysis.DefaultConfig()
+		cfg.MaxCyclesToStore = 2
+		stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+		if got := len(stats.Cycles()); got != 2 {
+			t.Errorf("stored %d cycles, want MaxCyclesToStore=2", got)
+		}
+		if reason := stats.Status().Cycles.Reason; reason != "truncated" {
+			t.Errorf("Cycles reason = %q, want truncated", reason)
+		}
+	})
+}
+
+func TestHITSStarGraph(t *testing.T) {
+	// Every leaf depends on the center: edges leaf -> center
+	issues := []model.Issue{{ID: "center", Status: model.StatusOpen}}
+	for i := 1; i <= 5; i++ {
+		id := fmt.Sprintf("leaf-%d", i)
+		issues = append(issues, model.Issue{ID: id, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: "center", Type: model.DepBlocks}}})
+	}
+
+	stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(analysis.DefaultConfig())
+	authorities, hubs := stats.Authorities(), stats.Hubs()
+	for i := 1; i <= 5; i++ {
+		leaf := fmt.Sprintf("leaf-%d", i)
+		if authorities["center"] <= authorities[leaf] {
+			t.Errorf("authority: center %v should dominate %s %v", authorities["center"], leaf, authorities[leaf])
+		}
+		if hubs[leaf] <= hubs["center"] {
+			t.Errorf("hub: %s %v should outscore center %v", leaf, hubs[leaf], hubs["center"])
+		}
+	}
+	if rank := stats.AuthoritiesRank()["center"]; rank != 1 {
+		t.Errorf("center authority rank = %d, want 1", rank)
+	}
+
+	cfg := analysis.DefaultConfig()
+	cfg.ComputeHITS = false
+	cfg.ComputeEigenvector = false
+	off := analysis.NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+	if len(off.Hubs()) != 0 || len(off.Authorities()) != 0 || len(off.Eigenvector()) != 0 {
+		t.Error("HITS and eigenvector should be empty when their flags are off")
+	}
+}
+
+func TestTransitiveImpactAndDependencies(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "base", Status: model.StatusOpen},
+		{ID: "mid", Status: model.StatusOpen, Dependencies: blockedBy("base")},
+		{ID: "top-b", Status: model.StatusOpen, Dependencies: blockedBy("mid")},
+		{ID: "top-a", Status: model.StatusOpen, Dependencies: blockedBy("mid", "base")},
+		// loop-1 and loop-2 block each other and both need top-a
+		{ID: "loop-1", Status: model.StatusOpen, Dependencies: blockedBy("top-a", "loop-2")},
+		{ID: "loop-2", Status: model.StatusOpen, Dependencies: blockedBy("loop-1")},
+		{ID: "other", Status: model.StatusOpen},
+	}
+	an := analysis.NewAnalyzer(issues)
+
+	if got, want := fmt.Sprint(an.TransitiveImpact("base")), "[loop-1 loop-2 mid top-a top-b]"; got != want {
+		t.Errorf("TransitiveImpact(base) = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(an.TransitiveDependencies("loop-2")), "[base loop-1 mid top-a]"; got != want {
+		t.Errorf("TransitiveDependencies(loop-2) = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(an.TransitiveImpact("loop-1")), "[loop-2]"; got != want {
+		t.Errorf("TransitiveImpact(loop-1) = %s, want %s", got, want)
+	}
+	if got := an.TransitiveImpact("other"); got != nil {
+		t.Errorf("TransitiveImpact(other) = %v, want nil", got)
+	}
+	if got := an.TransitiveDependencies("missing"); got != nil {
+		t.Errorf("TransitiveDependencies(missing) = %v, want nil", got)
+	}
+}
+
 // TestAnalyzeCompletesWithinTimeout ensures that Analyze() does not hang
 // even on graphs that might cause HITS or cycle detection to take a long time.
 // This test creates a sparse graph structure that could cause convergence issues
@@ -0,0 +1,89 @@
+package analysis
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+// Progress values for issues without children.
+const (
+	progressNotStarted = 0.0
+	progressInProgress = 0.5
+	progressDone       = 1.0
+)
+
+// ImportanceVsProgress returns, for each issue, an [importance, progress] pair
+// in [0,1] for plotting work on a quadrant chart ("important but not started").
+//
+// Importance is the mean of criticality and PageRank scaled by the largest
+// PageRank, so it needs Phase 2; before that it is 0. Progress is 1 for closed
+// issues, the closed fraction of parent-child descendants for open parents,
+// and otherwise 0.5 for in-progress work and 0 for everything else.
+func (s *GraphStats) ImportanceVsProgress(issues []model.Issue) map[string][2]float64 {
+	pageRank := s.PageRank()
+	criticality := s.CriticalityScore()
+	maxPageRank := 0.0
+	for _, v := range pageRank {
+		if v > maxPageRank {
+			maxPageRank = v
+		}
+	}
+
+	done := make(map[string]bool, len(issues))
+	children := make(map[string][]string)
+	for _, issue := range issues {
+		done[issue.ID] = issue.Status.IsClosed() || issue.Status.IsTombstone()
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	result := make(map[string][2]float64, len(issues))
+	for _, issue := range issues {
+		importance := criticality[issue.ID]
+		if maxPageRank > 0 {
+			importance = (importance + pageRank[issue.ID]/maxPageRank) / 2
+		} else {
+			importance /= 2
+		}
+
+		var progress float64
+		switch {
+		case done[issue.ID]:
+			progress = progressDone
+		case len(children[issue.ID]) > 0:
+			progress = closedDescendantFraction(issue.ID, children, done)
+		case issue.Status == model.StatusInProgress:
+			progress = progressInProgress
+		default:
+			progress = progressNotStarted
+		}
+
+		result[issue.ID] = [2]float64{importance, progress}
+	}
+	return result
+}
+
+// closedDescendantFraction returns the share of id's transitive parent-child
+// descendants that are done. Cycles in the hierarchy are visited once.
+func closedDescendantFraction(id string, children map[string][]string, done map[string]bool) float64 {
+	seen := map[string]bool{id: true}
+	stack := append([]string(nil), children[id]...)
+	total, closed := 0, 0
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		total++
+		if done[cur] {
+			closed++
+		}
+		stack = append(stack, children[cur]...)
+	}
+	if total == 0 {
+		return progressNotStarted
+	}
+	return float64(closed) / float64(total)
+}
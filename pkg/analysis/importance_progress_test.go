@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestImportanceVsProgress(t *testing.T) {
+	// "core" is an open prerequisite for three issues; "leaf" is closed and
+	// nothing depends on it. "epic" has two children, one closed.
+	issues := []model.Issue{
+		{ID: "core", Status: model.StatusOpen},
+		{ID: "a", Status: model.StatusOpen, Dependencies: blockedBy("core")},
+		{ID: "b", Status: model.StatusInProgress, Dependencies: blockedBy("core")},
+		{ID: "c", Status: model.StatusOpen, Dependencies: blockedBy("core")},
+		{ID: "leaf", Status: model.StatusClosed},
+		{ID: "epic", Status: model.StatusOpen},
+		{ID: "e1", Status: model.StatusClosed, Dependencies: childOf("epic")},
+		{ID: "e2", Status: model.StatusOpen, Dependencies: childOf("epic")},
+	}
+
+	stats := NewAnalyzer(issues).Analyze()
+	pairs := stats.ImportanceVsProgress(issues)
+
+	core, leaf := pairs["core"], pairs["leaf"]
+	if core[0] <= leaf[0] {
+		t.Errorf("core importance %f should exceed leaf importance %f", core[0], leaf[0])
+	}
+	if core[0] < 0.9 || core[0] > 1 {
+		t.Errorf("core should be the most important issue, got %f", core[0])
+	}
+	if core[1] != 0 {
+		t.Errorf("open core progress = %f, want 0", core[1])
+	}
+	if leaf[1] != 1 {
+		t.Errorf("closed leaf progress = %f, want 1", leaf[1])
+	}
+	if got := pairs["b"][1]; got != 0.5 {
+		t.Errorf("in-progress progress = %f, want 0.5", got)
+	}
+	if got := pairs["epic"][1]; got != 0.5 {
+		t.Errorf("epic with one of two children closed: progress = %f, want 0.5", got)
+	}
+	for id, pair := range pairs {
+		for _, v := range pair {
+			if v < 0 || v > 1 {
+				t.Errorf("%s: value %f outside [0,1]", id, v)
+			}
+		}
+	}
+}
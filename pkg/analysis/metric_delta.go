@@ -0,0 +1,183 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// MetricChange records how one issue's structural metrics moved after an update.
+// Level is the length of the longest prerequisite chain below the issue
+// (0 = no blocking prerequisites); it is -1 when the graph contains a cycle.
+type MetricChange struct {
+	ID              string `json:"id"`
+	InDegreeBefore  int    `json:"in_degree_before"`
+	InDegreeAfter   int    `json:"in_degree_after"`
+	OutDegreeBefore int    `json:"out_degree_before"`
+	OutDegreeAfter  int    `json:"out_degree_after"`
+	LevelBefore     int    `json:"level_before"`
+	LevelAfter      int    `json:"level_after"`
+}
+
+// MetricDelta lists the issues whose in/out-degree or level changed after
+// Analyzer.Update, sorted by ID, so the UI can highlight the affected rows.
+type MetricDelta struct {
+	Changes []MetricChange `json:"changes"`
+}
+
+// IDs returns the IDs of all changed issues in sorted order.
+func (d MetricDelta) IDs() []string {
+	ids := make([]string, len(d.Changes))
+	for i, c := range d.Changes {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// Update applies changed (new or edited) issues to the analyzer's graph in
+// place and reports which issues' degrees or levels shifted as a result.
+// Only the changed issues' outgoing edges are rebuilt, plus edges from existing
+// issues that referenced a newly added ID. Previously computed GraphStats are
+// not updated; re-run analysis for centrality metrics. Not safe to call
+// concurrently with analysis on the same Analyzer.
+func (a *Analyzer) Update(changed []model.Issue) MetricDelta {
+	beforeIn, beforeOut := a.degreeMaps()
+	beforeLevel := a.levels()
+
+	var added []string
+	for _, issue := range changed {
+		if _, exists := a.idToNode[issue.ID]; !exists {
+			n := a.g.NewNode()
+			a.g.AddNode(n)
+			a.idToNode[issue.ID] = n.ID()
+			a.nodeToID[n.ID()] = issue.ID
+			added = append(added, issue.ID)
+		}
+		a.issueMap[issue.ID] = issue
+	}
+
+	for _, issue := range changed {
+		a.removeRecordEdges(a.idToNode[issue.ID])
+		a.addBlockingEdges(issue)
+	}
+
+	// Existing issues may have been waiting on an ID that only now exists.
+	if len(added) > 0 {
+		for _, issue := range a.issueMap {
+			a.addBlockingEdges(issue)
+		}
+	}
+
+	afterIn, afterOut := a.degreeMaps()
+	afterLevel := a.levels()
+
+	var delta MetricDelta
+	for id := range a.issueMap {
+		change := MetricChange{
+			ID:              id,
+			InDegreeBefore:  beforeIn[id],
+			InDegreeAfter:   afterIn[id],
+			OutDegreeBefore: beforeOut[id],
+			OutDegreeAfter:  afterOut[id],
+			LevelBefore:     levelOf(beforeLevel, id),
+			LevelAfter:      levelOf(afterLevel, id),
+		}
+		if change.InDegreeBefore != change.InDegreeAfter ||
+			change.OutDegreeBefore != change.OutDegreeAfter ||
+			change.LevelBefore != change.LevelAfter {
+			delta.Changes = append(delta.Changes, change)
+		}
+	}
+	sort.Slice(delta.Changes, func(i, j int) bool {
+		return delta.Changes[i].ID < delta.Changes[j].ID
+	})
+	return delta
+}
+
+// removeRecordEdges removes the edges built from node u's own dependency
+// records: its outgoing edges under DependsOn, its incoming ones under Blocks.
+func (a *Analyzer) removeRecordEdges(u int64) {
+	nodes := a.g.From(u)
+	if a.direction == Blocks {
+		nodes = a.g.To(u)
+	}
+	var stale []int64
+	for nodes.Next() {
+		stale = append(stale, nodes.Node().ID())
+	}
+	for _, v := range stale {
+		from, to := a.orient(u, v)
+		a.g.RemoveEdge(from, to)
+	}
+}
+
+// addBlockingEdges adds an edge for each blocking dependency of issue whose
+// target exists, oriented as in NewAnalyzer. Setting an existing edge is a
+// no-op.
+func (a *Analyzer) addBlockingEdges(issue model.Issue) {
+	u, ok := a.idToNode[issue.ID]
+	if !ok {
+		return
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if v, exists := a.idToNode[dep.DependsOnID]; exists && v != u {
+			from, to := a.orient(u, v)
+			a.g.SetEdge(a.g.NewEdge(simple.Node(from), simple.Node(to)))
+		}
+	}
+}
+
+// degreeMaps returns in/out-degree per issue using the GraphStats convention.
+func (a *Analyzer) degreeMaps() (in, out map[string]int) {
+	in = make(map[string]int, len(a.idToNode))
+	out = make(map[string]int, len(a.idToNode))
+	for id, nid := range a.idToNode {
+		in[id] = a.g.To(nid).Len()
+		out[id] = a.g.From(nid).Len()
+	}
+	return in, out
+}
+
+// levels returns the longest prerequisite chain length per issue, or nil if
+// the graph has a cycle.
+func (a *Analyzer) levels() map[string]int {
+	sorted, err := topo.Sort(a.g)
+	if err != nil {
+		return nil
+	}
+	level := make(map[int64]int, len(sorted))
+	// topo.Sort puts dependents before their prerequisites; walk in reverse so
+	// every prerequisite's level is known first.
+	for i := len(sorted) - 1; i >= 0; i-- {
+		nid := sorted[i].ID()
+		max := 0
+		from := a.g.From(nid)
+		for from.Next() {
+			if l := level[from.Node().ID()] + 1; l > max {
+				max = l
+			}
+		}
+		level[nid] = max
+	}
+	out := make(map[string]int, len(level))
+	for nid, l := range level {
+		out[a.nodeToID[nid]] = l
+	}
+	return out
+}
+
+func levelOf(levels map[string]int, id string) int {
+	if levels == nil {
+		return -1
+	}
+	if l, ok := levels[id]; ok {
+		return l
+	}
+	return -1
+}
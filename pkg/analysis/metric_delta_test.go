@@ -0,0 +1,96 @@
+package analysis
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestAnalyzerUpdateReportsAffectedNodes(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen},
+	}
+	a := NewAnalyzer(issues)
+
+	updated := issues[2]
+	updated.Dependencies = []*model.Dependency{
+		{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks},
+	}
+	delta := a.Update([]model.Issue{updated})
+
+	if got, want := delta.IDs(), []string{"B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changed IDs = %v, want %v", got, want)
+	}
+
+	b, c := delta.Changes[0], delta.Changes[1]
+	if b.InDegreeBefore != 0 || b.InDegreeAfter != 1 {
+		t.Errorf("B in-degree %d -> %d, want 0 -> 1", b.InDegreeBefore, b.InDegreeAfter)
+	}
+	if c.OutDegreeBefore != 0 || c.OutDegreeAfter != 1 {
+		t.Errorf("C out-degree %d -> %d, want 0 -> 1", c.OutDegreeBefore, c.OutDegreeAfter)
+	}
+	if c.LevelBefore != 0 || c.LevelAfter != 2 {
+		t.Errorf("C level %d -> %d, want 0 -> 2", c.LevelBefore, c.LevelAfter)
+	}
+
+	// A repeated update with no structural change reports nothing.
+	if again := a.Update([]model.Issue{updated}); len(again.Changes) != 0 {
+		t.Errorf("expected no changes on idempotent update, got %v", again.IDs())
+	}
+}
+
+func TestAnalyzerUpdateNewIssueResolvesDanglingDeps(t *testing.T) {
+	a := NewAnalyzer([]model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "A", DependsOnID: "X", Type: model.DepBlocks},
+		}},
+	})
+
+	delta := a.Update([]model.Issue{{ID: "X", Status: model.StatusOpen}})
+
+	if got, want := delta.IDs(), []string{"A", "X"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changed IDs = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyzerUpdateBlocksDirectionMatchesFreshAnalyzer(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: blockedBy("B")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blockedBy("C")},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+	}
+	a := NewAnalyzer(issues, WithDependencyDirection(Blocks))
+
+	// A now blocks C instead of B; E is new and blocks D.
+	final := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: blockedBy("C")},
+		issues[1], issues[2], issues[3],
+		{ID: "E", Status: model.StatusOpen, Dependencies: blockedBy("D")},
+	}
+	a.Update([]model.Issue{final[0], final[4]})
+
+	fresh := NewAnalyzer(final, WithDependencyDirection(Blocks))
+	if got, want := edgeSet(a), edgeSet(fresh); !reflect.DeepEqual(got, want) {
+		t.Errorf("edges after Update = %v, fresh analyzer has %v", got, want)
+	}
+}
+
+// edgeSet returns the analyzer's edges as sorted "from->to" issue ID pairs.
+func edgeSet(a *Analyzer) []string {
+	var edges []string
+	it := a.g.Edges()
+	for it.Next() {
+		e := it.Edge()
+		edges = append(edges, a.nodeToID[e.From().ID()]+"->"+a.nodeToID[e.To().ID()])
+	}
+	sort.Strings(edges)
+	return edges
+}
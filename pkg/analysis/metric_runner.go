@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"context"
+	"sync"
+)
+
+// metricRunner runs phase 2 metrics on their own goroutines, at most limit at
+// a time. Metrics are started in the order they are submitted.
+type metricRunner struct {
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu       sync.Mutex
+	panicked bool
+	panicVal interface{}
+}
+
+func newMetricRunner(limit int) *metricRunner {
+	if limit < 1 {
+		limit = 1
+	}
+	return &metricRunner{sem: make(chan struct{}, limit)}
+}
+
+// acquire blocks until a slot is free. It returns false, holding no slot, if
+// ctx is cancelled first.
+func (r *metricRunner) acquire(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case r.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// spawn runs fn in the slot taken by the preceding acquire and frees it when
+// fn returns.
+func (r *metricRunner) spawn(fn func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.sem }()
+		defer func() {
+			if v := recover(); v != nil {
+				r.mu.Lock()
+				if !r.panicked {
+					r.panicked, r.panicVal = true, v
+				}
+				r.mu.Unlock()
+			}
+		}()
+		fn()
+	}()
+}
+
+// wait blocks until every spawned metric has returned, then re-raises the
+// first panic on the calling goroutine so computePhase2's recovery sees it.
+func (r *metricRunner) wait() {
+	r.wg.Wait()
+	if r.panicked {
+		panic(r.panicVal)
+	}
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestMetricRunnerBoundsConcurrency(t *testing.T) {
+	const limit = 2
+	runner := newMetricRunner(limit)
+
+	var active, peak, ran int32
+	fakeMetric := func() {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		atomic.AddInt32(&ran, 1)
+	}
+
+	for i := 0; i < 8; i++ {
+		if !runner.acquire(context.Background()) {
+			t.Fatal("acquire failed without cancellation")
+		}
+		runner.spawn(fakeMetric)
+	}
+	runner.wait()
+
+	if ran != 8 {
+		t.Fatalf("ran %d metrics, want 8", ran)
+	}
+	if peak > limit {
+		t.Fatalf("peak concurrency %d exceeds limit %d", peak, limit)
+	}
+	if peak < limit {
+		t.Errorf("peak concurrency %d, expected metrics to overlap up to %d", peak, limit)
+	}
+}
+
+func TestMetricRunnerAcquireCancelled(t *testing.T) {
+	runner := newMetricRunner(1)
+	if !runner.acquire(context.Background()) {
+		t.Fatal("first acquire should succeed")
+	}
+	release := make(chan struct{})
+	runner.spawn(func() { <-release })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if runner.acquire(ctx) {
+		t.Fatal("acquire should fail once the context is cancelled")
+	}
+	close(release)
+	runner.wait()
+}
+
+func TestMetricRunnerWaitReraisesPanic(t *testing.T) {
+	runner := newMetricRunner(2)
+	runner.acquire(context.Background())
+	runner.spawn(func() { panic("boom") })
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+	}()
+	runner.wait()
+	t.Fatal("wait should re-raise the metric panic")
+}
+
+func TestMaxConcurrencyDefaultAndResults(t *testing.T) {
+	if got := (AnalysisConfig{}).maxConcurrency(); got != runtime.GOMAXPROCS(0) {
+		t.Errorf("default maxConcurrency = %d, want GOMAXPROCS %d", got, runtime.GOMAXPROCS(0))
+	}
+
+	cfg := FullAnalysisConfig()
+	serial := cfg
+	serial.MaxConcurrency = 1
+	if ComputeConfigHash(&cfg) != ComputeConfigHash(&serial) {
+		t.Error("MaxConcurrency should not change the config hash")
+	}
+
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}, {DependsOnID: "C", Type: model.DepBlocks}}},
+	}
+	parallel := NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+	sequential := NewAnalyzer(issues).AnalyzeWithConfig(serial)
+	for _, id := range []string{"A", "B", "C", "D"} {
+		if parallel.GetPageRankScore(id) != sequential.GetPageRankScore(id) ||
+			parallel.GetBetweennessScore(id) != sequential.GetBetweennessScore(id) ||
+			parallel.GetCriticalPathScore(id) != sequential.GetCriticalPathScore(id) {
+			t.Errorf("%s: metrics differ between MaxConcurrency 1 and default", id)
+		}
+	}
+}
@@ -144,6 +144,81 @@ func (a *Analyzer) ComputeUnblocks(issueID string) []string {
 	return a.computeUnblocks(issueID)
 }
 
+// UnblockOptions configures ComputeUnblockLevels.
+type UnblockOptions struct {
+	// IncludeTransitive follows the cascade: once a level is unblocked and
+	// treated as done, the dependents it frees form the next level.
+	IncludeTransitive bool
+}
+
+// ComputeUnblockLevels reports what closing issueID frees up, grouped into
+// levels. Level 0 matches ComputeUnblocks. With IncludeTransitive, each
+// further level holds the issues that become actionable once every earlier
+// level is done; a dependent that still has another open blocker is never
+// reported. Levels are sorted; nil if nothing is unblocked.
+func (a *Analyzer) ComputeUnblockLevels(issueID string, opts UnblockOptions) [][]string {
+	if _, ok := a.idToNode[issueID]; !ok {
+		return nil
+	}
+
+	done := map[string]bool{issueID: true}
+	frontier := []string{issueID}
+	var levels [][]string
+
+	for len(frontier) > 0 {
+		candidates := make(map[string]bool)
+		for _, id := range frontier {
+			dependents := a.g.To(a.idToNode[id])
+			for dependents.Next() {
+				depID := a.nodeToID[dependents.Node().ID()]
+				if done[depID] {
+					continue
+				}
+				if issue, ok := a.issueMap[depID]; ok && issue.Status == model.StatusClosed {
+					continue
+				}
+				candidates[depID] = true
+			}
+		}
+
+		var level []string
+		for depID := range candidates {
+			if !a.blockedOutside(depID, done) {
+				level = append(level, depID)
+			}
+		}
+		if len(level) == 0 {
+			break
+		}
+		sort.Strings(level)
+		levels = append(levels, level)
+		if !opts.IncludeTransitive {
+			break
+		}
+
+		for _, id := range level {
+			done[id] = true
+		}
+		frontier = level
+	}
+	return levels
+}
+
+// blockedOutside reports whether issueID has an open blocker that is not in done.
+func (a *Analyzer) blockedOutside(issueID string, done map[string]bool) bool {
+	blockers := a.g.From(a.idToNode[issueID])
+	for blockers.Next() {
+		blockerID := a.nodeToID[blockers.Node().ID()]
+		if done[blockerID] {
+			continue
+		}
+		if blocker, ok := a.issueMap[blockerID]; ok && blocker.Status != model.StatusClosed {
+			return true
+		}
+	}
+	return false
+}
+
 // findConnectedComponents uses union-find to group related issues
 func (a *Analyzer) findConnectedComponents() map[string][]string {
 	// Simple union-find
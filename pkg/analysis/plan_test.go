@@ -1,6 +1,7 @@
 package analysis_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -248,6 +249,38 @@ func TestGetExecutionPlanPartialUnblock(t *testing.T) {
 	}
 }
 
+func TestComputeUnblockLevelsCascade(t *testing.T) {
+	// R <- A <- C <- F, R <- B, D needs A and B, E needs C and the open X
+	issues := []model.Issue{
+		{ID: "R", Status: model.StatusOpen},
+		{ID: "A", Status: model.StatusOpen, Dependencies: blockedBy("R")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blockedBy("R")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blockedBy("A", "B")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: blockedBy("C", "X")},
+		{ID: "F", Status: model.StatusOpen, Dependencies: blockedBy("C")},
+		{ID: "X", Status: model.StatusOpen},
+	}
+	an := analysis.NewAnalyzer(issues)
+
+	direct := an.ComputeUnblockLevels("R", analysis.UnblockOptions{})
+	if got, want := fmt.Sprint(direct), "[[A B]]"; got != want {
+		t.Errorf("direct levels = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(direct[0]), fmt.Sprint(an.ComputeUnblocks("R")); got != want {
+		t.Errorf("level 0 = %s, want ComputeUnblocks %s", got, want)
+	}
+
+	cascade := an.ComputeUnblockLevels("R", analysis.UnblockOptions{IncludeTransitive: true})
+	if got, want := fmt.Sprint(cascade), "[[A B] [C D] [F]]"; got != want {
+		t.Errorf("cascade levels = %s, want %s (E stays blocked by X)", got, want)
+	}
+
+	if got := an.ComputeUnblockLevels("missing", analysis.UnblockOptions{IncludeTransitive: true}); got != nil {
+		t.Errorf("unknown issue should unblock nothing, got %v", got)
+	}
+}
+
 func TestGetExecutionPlanConnectedGraph(t *testing.T) {
 	// A depends on B, C depends on B
 	// B is the only actionable, and completing it unblocks both A and C
@@ -112,16 +112,15 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 		criticalPath = stats.CriticalPathScore()
 	}
 
-	// Precompute blocker counts directly from dependencies to avoid relying on graph direction nuances.
+	// Precompute how many issues each issue blocks, read in the analyzer's
+	// dependency direction.
 	blockerCounts := make(map[string]int)
 	for id := range a.issueMap {
 		blockerCounts[id] = 0
 	}
 	for _, issue := range a.issueMap {
-		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type.IsBlocking() {
-				blockerCounts[dep.DependsOnID]++
-			}
+		for _, blockerID := range a.blockerIDs(issue) {
+			blockerCounts[blockerID]++
 		}
 	}
 
@@ -877,3 +877,30 @@ func TestParallelizationGainZero(t *testing.T) {
 		t.Errorf("Expected ParallelizationGain=%d, got %d", expectedGain, *recA.WhatIf.ParallelizationGain)
 	}
 }
+
+func TestComputeImpactScoresBlockerRatioBlocksDirection(t *testing.T) {
+	// Same graph as above, recorded from the blocker's side: root blocks dep1-3.
+	issues := []model.Issue{
+		{ID: "root", Title: "Root", Status: model.StatusOpen, Priority: 1, Dependencies: []*model.Dependency{
+			{DependsOnID: "dep1", Type: model.DepBlocks},
+			{DependsOnID: "dep2", Type: model.DepBlocks},
+			{DependsOnID: "dep3", Type: model.DepBlocks},
+		}},
+		{ID: "dep1", Title: "Dep 1", Status: model.StatusOpen, Priority: 1},
+		{ID: "dep2", Title: "Dep 2", Status: model.StatusOpen, Priority: 1},
+		{ID: "dep3", Title: "Dep 3", Status: model.StatusOpen, Priority: 1},
+	}
+
+	an := analysis.NewAnalyzer(issues, analysis.WithDependencyDirection(analysis.Blocks))
+	scoreMap := make(map[string]analysis.ImpactScore)
+	for _, s := range an.ComputeImpactScores() {
+		scoreMap[s.IssueID] = s
+	}
+
+	if scoreMap["root"].Breakdown.BlockerRatioNorm != 1.0 {
+		t.Errorf("Root should have max blocker ratio (1.0), got %f", scoreMap["root"].Breakdown.BlockerRatioNorm)
+	}
+	if scoreMap["dep1"].Breakdown.BlockerRatioNorm != 0.0 {
+		t.Errorf("dep1 should have 0 blocker ratio, got %f", scoreMap["dep1"].Breakdown.BlockerRatioNorm)
+	}
+}
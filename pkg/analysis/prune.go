@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Prune removes closed issues that were closed before keepClosedNewerThan
+// from the analyzer, keeping the active graph small for long-lived repos.
+// Blocking paths through a pruned issue are contracted: if A depends on a
+// pruned X and X depends on B, A now depends on B directly, so ordering and
+// reachability among the remaining issues are unchanged. Remaining issues'
+// dependency lists are rewritten to match. Issues with no ClosedAt fall back
+// to UpdatedAt. Returns the pruned IDs, sorted.
+func (a *Analyzer) Prune(keepClosedNewerThan time.Time) []string {
+	var pruned []string
+	for id, issue := range a.issueMap {
+		if !issue.Status.IsClosed() {
+			continue
+		}
+		closedAt := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			closedAt = *issue.ClosedAt
+		}
+		if closedAt.Before(keepClosedNewerThan) {
+			pruned = append(pruned, id)
+		}
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+	sort.Strings(pruned)
+
+	prunedSet := make(map[string]bool, len(pruned))
+	for _, id := range pruned {
+		prunedSet[id] = true
+	}
+
+	// Contract one node at a time; edges added while contracting earlier
+	// nodes carry paths through chains of pruned issues.
+	for _, id := range pruned {
+		p := a.idToNode[id]
+
+		var dependents, prereqs []int64
+		to := a.g.To(p)
+		for to.Next() {
+			dependents = append(dependents, to.Node().ID())
+		}
+		from := a.g.From(p)
+		for from.Next() {
+			prereqs = append(prereqs, from.Node().ID())
+		}
+		for _, u := range dependents {
+			for _, v := range prereqs {
+				if u != v && !a.g.HasEdgeFromTo(u, v) {
+					a.g.SetEdge(a.g.NewEdge(simple.Node(u), simple.Node(v)))
+				}
+			}
+		}
+
+		a.g.RemoveNode(p)
+		delete(a.idToNode, id)
+		delete(a.nodeToID, p)
+		delete(a.issueMap, id)
+	}
+
+	a.rewriteContractedDependencies(prunedSet)
+	return pruned
+}
+
+// rewriteContractedDependencies updates the stored dependencies of issues that
+// referenced a pruned issue so they match the contracted graph edges. Under
+// Blocks semantics an issue's records name its dependents rather than its
+// prerequisites.
+func (a *Analyzer) rewriteContractedDependencies(pruned map[string]bool) {
+	for id, issue := range a.issueMap {
+		affected := false
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && pruned[dep.DependsOnID] {
+				affected = true
+				break
+			}
+		}
+		if !affected {
+			continue
+		}
+
+		deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+		have := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				if pruned[dep.DependsOnID] {
+					continue
+				}
+				have[dep.DependsOnID] = true
+			}
+			deps = append(deps, dep)
+		}
+
+		var added []string
+		targets := a.g.From(a.idToNode[id])
+		if a.direction == Blocks {
+			targets = a.g.To(a.idToNode[id])
+		}
+		for targets.Next() {
+			if depID := a.nodeToID[targets.Node().ID()]; !have[depID] {
+				added = append(added, depID)
+			}
+		}
+		sort.Strings(added)
+		for _, depID := range added {
+			deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: depID, Type: model.DepBlocks})
+		}
+
+		issue.Dependencies = deps
+		a.issueMap[id] = issue
+	}
+}
@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestPrunePreservesTransitiveBlocking(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, -6, 0)
+	recent := now.AddDate(0, 0, -3)
+
+	// A -> X -> Y -> B, where X and Y are long-closed; R is recently closed.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: append(blockedBy("X"), &model.Dependency{DependsOnID: "R", Type: model.DepRelated})},
+		{ID: "X", Status: model.StatusClosed, ClosedAt: &old, Dependencies: blockedBy("Y")},
+		{ID: "Y", Status: model.StatusClosed, UpdatedAt: old, Dependencies: blockedBy("B")},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "R", Status: model.StatusClosed, ClosedAt: &recent, Dependencies: blockedBy("B")},
+	}
+
+	an := NewAnalyzer(issues)
+	pruned := an.Prune(now.AddDate(0, -1, 0))
+	if !reflect.DeepEqual(pruned, []string{"X", "Y"}) {
+		t.Fatalf("pruned = %v, want [X Y]", pruned)
+	}
+
+	if _, ok := an.issueMap["X"]; ok {
+		t.Error("pruned issue X should be gone")
+	}
+	if _, ok := an.issueMap["R"]; !ok {
+		t.Error("recently closed issue R should be kept")
+	}
+
+	// A still (transitively) depends on B, now via a direct edge.
+	if !an.g.HasEdgeFromTo(an.idToNode["A"], an.idToNode["B"]) {
+		t.Fatal("expected contracted edge A -> B")
+	}
+	if !an.WouldCreateCycle("B", "A") {
+		t.Error("B depending on A should still close a loop after pruning")
+	}
+
+	var got []string
+	for _, dep := range an.issueMap["A"].Dependencies {
+		got = append(got, string(dep.Type)+":"+dep.DependsOnID)
+	}
+	if want := []string{"related:R", "blocks:B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("A dependencies = %v, want %v", got, want)
+	}
+
+	stats := an.Analyze()
+	if stats.NodeCount != 3 || stats.OutDegree["A"] != 1 || stats.InDegree["B"] != 2 {
+		t.Errorf("unexpected stats after prune: nodes=%d out(A)=%d in(B)=%d",
+			stats.NodeCount, stats.OutDegree["A"], stats.InDegree["B"])
+	}
+}
+
+func TestPruneNothingToPrune(t *testing.T) {
+	an := NewAnalyzer([]model.Issue{{ID: "A", Status: model.StatusOpen}})
+	if pruned := an.Prune(time.Now()); pruned != nil {
+		t.Errorf("expected nothing pruned, got %v", pruned)
+	}
+}
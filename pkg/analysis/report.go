@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportLevel selects how much detail GraphStats.Report includes.
+type ReportLevel int
+
+const (
+	// ReportBrief is headline counts plus the top bottleneck.
+	ReportBrief ReportLevel = iota
+	// ReportStandard adds the top 10 issues by each metric.
+	ReportStandard
+	// ReportFull lists every issue by each metric, plus cycles, articulation
+	// points, k-core numbers and slack.
+	ReportFull
+)
+
+// reportStandardTopN is how many issues per metric a Standard report lists.
+const reportStandardTopN = 10
+
+// String returns the level name.
+func (l ReportLevel) String() string {
+	switch l {
+	case ReportBrief:
+		return "brief"
+	case ReportStandard:
+		return "standard"
+	case ReportFull:
+		return "full"
+	default:
+		return fmt.Sprintf("ReportLevel(%d)", int(l))
+	}
+}
+
+// Report formats the stats as plain text at the given level of detail. Each
+// level contains everything the lower levels do. Phase 2 metrics that are not
+// ready yet are reported as empty.
+func (s *GraphStats) Report(level ReportLevel) string {
+	var sb strings.Builder
+
+	cycles := s.Cycles()
+	betweenness := s.Betweenness()
+
+	fmt.Fprintf(&sb, "Graph: %d issues, %d dependencies, density %.4f\n", s.NodeCount, s.EdgeCount, s.Density)
+	fmt.Fprintf(&sb, "Cycles: %d\n", len(cycles))
+	if top := getTopItems(betweenness, 1); len(top) > 0 {
+		fmt.Fprintf(&sb, "Top bottleneck: %s (betweenness %.4f)\n", top[0].ID, top[0].Value)
+	} else {
+		sb.WriteString("Top bottleneck: none\n")
+	}
+
+	if level < ReportStandard {
+		return sb.String()
+	}
+
+	limit := reportStandardTopN
+	if level >= ReportFull {
+		limit = s.NodeCount
+	}
+
+	sections := []reportSection{
+		{"PageRank", getTopItems(s.PageRank(), limit)},
+		{"Betweenness", getTopItems(betweenness, limit)},
+		{"Eigenvector", getTopItems(s.Eigenvector(), limit)},
+		{"Hubs", getTopItems(s.Hubs(), limit)},
+		{"Authorities", getTopItems(s.Authorities(), limit)},
+		{"Critical path", getTopItems(s.CriticalPathScore(), limit)},
+		{"Blocks most (in-degree)", getTopItemsInt(s.InDegree, limit)},
+	}
+	if level >= ReportFull {
+		sections = append(sections,
+			reportSection{"K-core", getTopItemsInt(s.CoreNumber(), limit)},
+			reportSection{"Slack", getTopItems(s.Slack(), limit)},
+		)
+	}
+	for _, section := range sections {
+		writeReportSection(&sb, section.title, section.items)
+	}
+
+	if level >= ReportFull {
+		sb.WriteString("\nCycle members:\n")
+		if len(cycles) == 0 {
+			sb.WriteString("  (none)\n")
+		}
+		for _, cycle := range cycles {
+			fmt.Fprintf(&sb, "  %s\n", strings.Join(cycle, " -> "))
+		}
+
+		sb.WriteString("\nArticulation points:\n")
+		points := s.ArticulationPoints()
+		if len(points) == 0 {
+			sb.WriteString("  (none)\n")
+		}
+		for _, id := range points {
+			fmt.Fprintf(&sb, "  %s\n", id)
+		}
+	}
+
+	return sb.String()
+}
+
+type reportSection struct {
+	title string
+	items []InsightItem
+}
+
+func writeReportSection(sb *strings.Builder, title string, items []InsightItem) {
+	fmt.Fprintf(sb, "\n%s:\n", title)
+	if len(items) == 0 {
+		sb.WriteString("  (none)\n")
+		return
+	}
+	for i, item := range items {
+		fmt.Fprintf(sb, "  %2d. %s  %.4f\n", i+1, item.ID, item.Value)
+	}
+}
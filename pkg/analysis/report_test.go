@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func reportTestStats() *GraphStats {
+	// A chain of 15 issues with a fan-in on the middle, plus one 2-cycle.
+	var issues []model.Issue
+	for i := 0; i < 15; i++ {
+		issue := model.Issue{ID: fmt.Sprintf("r-%02d", i), Status: model.StatusOpen}
+		if i > 0 {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprintf("r-%02d", i-1), Type: model.DepBlocks})
+		}
+		if i > 8 {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: "r-07", Type: model.DepBlocks})
+		}
+		issues = append(issues, issue)
+	}
+	issues = append(issues,
+		model.Issue{ID: "c-1", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "c-2", Type: model.DepBlocks}}},
+		model.Issue{ID: "c-2", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "c-1", Type: model.DepBlocks}}},
+	)
+	stats := NewAnalyzer(issues).Analyze()
+	return &stats
+}
+
+func TestReportLevelsAreNested(t *testing.T) {
+	stats := reportTestStats()
+	brief := stats.Report(ReportBrief)
+	standard := stats.Report(ReportStandard)
+	full := stats.Report(ReportFull)
+
+	if !strings.Contains(brief, "Graph: 17 issues") || !strings.Contains(brief, "Cycles: 1") {
+		t.Errorf("brief report missing headline counts:\n%s", brief)
+	}
+	if !strings.Contains(brief, "Top bottleneck: ") || strings.Contains(brief, "Top bottleneck: none") {
+		t.Errorf("brief report should name the top bottleneck:\n%s", brief)
+	}
+	if strings.Contains(brief, "PageRank:") {
+		t.Errorf("brief report should not list per-metric rankings:\n%s", brief)
+	}
+
+	for name, lower := range map[string]string{"brief": brief, "standard": standard} {
+		for _, line := range strings.Split(strings.TrimSpace(lower), "\n") {
+			if !strings.Contains(full, line) {
+				t.Errorf("%s line %q missing from full report", name, line)
+			}
+		}
+	}
+
+	if strings.Contains(standard, "  11. ") {
+		t.Errorf("standard report should list at most %d issues per metric", reportStandardTopN)
+	}
+	if !strings.Contains(full, "  17. ") || !strings.Contains(full, "Cycle members:") {
+		t.Errorf("full report should list every issue and cycle members:\n%s", full)
+	}
+}
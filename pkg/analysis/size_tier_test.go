@@ -0,0 +1,114 @@
+package analysis_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ============================================================================
+// Size-tier benchmarks and budget guard for ConfigForSize
+// ============================================================================
+
+// tierShapes are the graph shapes each size tier is measured on: sparse is a
+// typical tracker, chain the deepest possible graph and dense the most edges
+// per node.
+var tierShapes = []struct {
+	name     string
+	generate func(n int) []model.Issue
+}{
+	{"sparse", generateSparseGraph},
+	{"chain", generateChainGraph},
+	{"dense", generateDenseGraph},
+}
+
+// BenchmarkAnalyzeSizeTiers runs Analyze with the ConfigForSize tier for each
+// graph shape and size and reports the average time spent in every metric, so
+// a slow metric shows up by name rather than only in the total.
+func BenchmarkAnalyzeSizeTiers(b *testing.B) {
+	for _, shape := range tierShapes {
+		for _, size := range []int{100, 1000, 5000} {
+			b.Run(fmt.Sprintf("%s=%d", shape.name, size), func(b *testing.B) {
+				issues := shape.generate(size)
+				an := analysis.NewAnalyzer(issues)
+				_, probe := an.AnalyzeWithProfile(analysis.AnalysisConfig{})
+				cfg := analysis.ConfigForSize(probe.NodeCount, probe.EdgeCount)
+
+				var sum analysis.StartupProfile
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, profile := analysis.NewAnalyzer(issues).AnalyzeWithProfile(cfg)
+					sum.PageRank += profile.PageRank
+					sum.Betweenness += profile.Betweenness
+					sum.Eigenvector += profile.Eigenvector
+					sum.HITS += profile.HITS
+					sum.CriticalPath += profile.CriticalPath
+					sum.Cycles += profile.Cycles
+					sum.Criticality += profile.Criticality
+					sum.KCore += profile.KCore
+					sum.Slack += profile.Slack
+				}
+				b.StopTimer()
+
+				perOp := func(d time.Duration) float64 {
+					return float64(d.Microseconds()) / 1000 / float64(b.N)
+				}
+				b.ReportMetric(perOp(sum.PageRank), "pagerank-ms/op")
+				b.ReportMetric(perOp(sum.Betweenness), "betweenness-ms/op")
+				b.ReportMetric(perOp(sum.Eigenvector), "eigenvector-ms/op")
+				b.ReportMetric(perOp(sum.HITS), "hits-ms/op")
+				b.ReportMetric(perOp(sum.CriticalPath), "critical-ms/op")
+				b.ReportMetric(perOp(sum.Cycles), "cycles-ms/op")
+				b.ReportMetric(perOp(sum.Criticality), "criticality-ms/op")
+				b.ReportMetric(perOp(sum.KCore), "kcore-ms/op")
+				b.ReportMetric(perOp(sum.Slack), "slack-ms/op")
+			})
+		}
+	}
+}
+
+// TestConfigForSizeKeepsAnalyzeWithinBudget checks that a sparse, a deep-chain
+// and a dense graph in each size tier (small, medium, large, XL) finish a full
+// Analyze within the tier's wall-clock target. The targets leave at least 20x
+// headroom over a single-core run, so a metric that escapes its tier's limits
+// fails here rather than only showing up in the benchmarks.
+func TestConfigForSizeKeepsAnalyzeWithinBudget(t *testing.T) {
+	tiers := []struct {
+		name   string
+		size   int
+		target time.Duration
+	}{
+		{"small", 80, 250 * time.Millisecond},
+		{"medium", 400, 500 * time.Millisecond},
+		{"large", 1500, 1 * time.Second},
+		{"xl", 3000, 2 * time.Second},
+	}
+
+	for _, shape := range tierShapes {
+		for _, tier := range tiers {
+			t.Run(shape.name+"/"+tier.name, func(t *testing.T) {
+				if testing.Short() && tier.size > 1000 {
+					t.Skip("large tiers skipped in -short mode")
+				}
+				issues := shape.generate(tier.size)
+
+				start := time.Now()
+				an := analysis.NewAnalyzer(issues)
+				stats := an.Analyze()
+				elapsed := time.Since(start)
+
+				cfg := stats.Config
+				if want := analysis.ConfigForSize(stats.NodeCount, stats.EdgeCount); cfg.BetweennessMode != want.BetweennessMode {
+					t.Fatalf("Analyze used betweenness mode %q, ConfigForSize picks %q", cfg.BetweennessMode, want.BetweennessMode)
+				}
+				if elapsed > tier.target {
+					t.Errorf("%d-node %s Analyze took %v, over the %s tier target of %v", tier.size, shape.name, elapsed, tier.name, tier.target)
+				}
+			})
+		}
+	}
+}
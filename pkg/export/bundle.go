@@ -0,0 +1,138 @@
+// Package export provides data export functionality for bv.
+//
+// This file builds the static site bundle: the SQLite database and JSON data,
+// the viewer assets, optional per-epic pages and the manifest. The pages
+// wizard and the build-only path both go through BuildBundle.
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BundleBuild is the analysis BuildBundle ran, for callers that add
+// target-specific files (e.g. a README) to the bundle.
+type BundleBuild struct {
+	Stats  *analysis.GraphStats
+	Triage *analysis.TriageResult
+}
+
+// SetAssetCopier overrides how BuildBundle copies the viewer assets into the
+// bundle. The default copies the embedded assets.
+func (w *Wizard) SetAssetCopier(copyAssets func(outputDir, title string) error) {
+	w.assetCopier = copyAssets
+}
+
+// BuildBundle writes the static site for the already-filtered issues into the
+// wizard's bundle directory (see PerformExport) and validates it.
+func (w *Wizard) BuildBundle(issues []model.Issue) (*BundleBuild, error) {
+	bundlePath := w.bundlePath
+
+	fmt.Println("Exporting static site...")
+	fmt.Printf("  -> Loading %d issues\n", len(issues))
+
+	// Build graph and compute stats
+	fmt.Println("  -> Running graph analysis...")
+	stats := analysis.NewAnalyzer(issues).AnalyzeAsync(context.Background())
+	stats.WaitForPhase2()
+
+	// Compute triage
+	fmt.Println("  -> Generating triage data...")
+	triage := analysis.ComputeTriage(issues)
+
+	// Create exporter
+	exporter := newBundleExporter(issues, stats, &triage)
+	if w.config.Title != "" {
+		exporter.Config.Title = w.config.Title
+	}
+
+	// Export SQLite database
+	fmt.Println("  -> Writing database and JSON files...")
+	if err := exporter.Export(bundlePath); err != nil {
+		return nil, fmt.Errorf("export failed: %w", err)
+	}
+
+	// Copy viewer assets
+	fmt.Println("  -> Copying viewer assets...")
+	copyAssets := w.assetCopier
+	if copyAssets == nil {
+		copyAssets = CopyEmbeddedAssets
+	}
+	if err := copyAssets(bundlePath, w.config.Title); err != nil {
+		return nil, fmt.Errorf("failed to copy assets: %w", err)
+	}
+
+	// Optional per-epic pages for large backlogs
+	if w.config.SplitByEpic {
+		fmt.Println("  -> Writing per-epic pages...")
+		pages, err := w.WriteEpicPages(issues, stats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write epic pages: %w", err)
+		}
+		fmt.Printf("     %d epic pages in %s/\n", len(pages), EpicPagesDir)
+	}
+
+	// Record exactly which issues were published
+	manifest, err := w.WriteManifest(issues)
+	if err != nil {
+		fmt.Printf("  -> Warning: %v\n", err)
+	}
+	if err := ValidateBundle(bundlePath, manifest); err != nil {
+		return nil, err
+	}
+
+	return &BundleBuild{Stats: stats, Triage: &triage}, nil
+}
+
+// newBundleExporter creates the SQLite exporter for issues, with their
+// blocking dependencies as the graph edges.
+func newBundleExporter(issues []model.Issue, stats *analysis.GraphStats, triage *analysis.TriageResult) *SQLiteExporter {
+	var deps []*model.Dependency
+	issuePointers := make([]*model.Issue, len(issues))
+	for i := range issues {
+		issue := &issues[i]
+		issuePointers[i] = issue
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			deps = append(deps, &model.Dependency{
+				IssueID:     issue.ID,
+				DependsOnID: dep.DependsOnID,
+				Type:        dep.Type,
+			})
+		}
+	}
+	return NewSQLiteExporter(issuePointers, deps, stats, triage)
+}
+
+// BuildOnly generates the bundle for issues at cfg.OutputPath without any
+// prompts or deploy step, for CI. Issues are filtered by cfg as in the wizard.
+// The result reports DeployTarget "none".
+func (w *Wizard) BuildOnly(cfg WizardConfig, issues []model.Issue) (*WizardResult, error) {
+	if cfg.OutputPath == "" {
+		return nil, fmt.Errorf("build-only export requires an output path")
+	}
+	cfg.DeployTarget = "none"
+	cfg.syncIncludeClosed()
+	w.config = &cfg
+
+	if err := os.MkdirAll(cfg.OutputPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	w.bundlePath = cfg.OutputPath
+
+	if _, err := w.BuildBundle(FilterExportIssues(issues, &cfg)); err != nil {
+		return nil, err
+	}
+
+	return &WizardResult{
+		BundlePath:     cfg.OutputPath,
+		DeployTarget:   "none",
+		PublishedCount: w.publishedCount,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildOnlyWritesBundleWithoutDeploying(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "site")
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Open", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-2", Title: "Closed", Status: model.StatusClosed, IssueType: model.TypeTask},
+		{ID: "bv-3", Title: "Blocked", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "bv-3", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+
+	wizard := NewWizard(t.TempDir())
+	var copied string
+	wizard.SetAssetCopier(func(outputDir, title string) error {
+		copied = title
+		return os.WriteFile(filepath.Join(outputDir, "index.html"), []byte("<title>"+title+"</title>"), 0644)
+	})
+	deployLog := filepath.Join(t.TempDir(), "deploys.jsonl")
+	wizard.SetDeployLogPath(deployLog)
+
+	result, err := wizard.BuildOnly(WizardConfig{Title: "CI Board", DeployTarget: "github", OutputPath: out}, issues)
+	if err != nil {
+		t.Fatalf("BuildOnly: %v", err)
+	}
+	if result.DeployTarget != "none" || result.BundlePath != out || result.PublishedCount != 2 {
+		t.Errorf("result = %+v, want target none, bundle %s, 2 published", result, out)
+	}
+	if copied != "CI Board" {
+		t.Errorf("asset copier got title %q", copied)
+	}
+
+	for _, rel := range []string{"index.html", ManifestFileName, "beads.sqlite3"} {
+		if _, err := os.Stat(filepath.Join(out, rel)); err != nil {
+			t.Errorf("bundle missing %s: %v", rel, err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(out, ManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if manifest.PublishedCount != 2 {
+		t.Errorf("manifest published_count = %d, want 2", manifest.PublishedCount)
+	}
+
+	if _, err := os.Stat(deployLog); !os.IsNotExist(err) {
+		t.Errorf("BuildOnly should not record a deploy, stat err = %v", err)
+	}
+
+	if _, err := NewWizard("").BuildOnly(WizardConfig{}, issues); err == nil {
+		t.Error("expected an error without an output path")
+	}
+}
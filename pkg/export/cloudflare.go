@@ -38,6 +38,10 @@ type CloudflareDeployConfig struct {
 
 	// SkipConfirmation skips interactive confirmation prompts (for CI)
 	SkipConfirmation bool
+
+	// OnStep, if set, is called as each deploy stage starts and finishes
+	// (see StepRunning). All calls happen before DeployToCloudflarePages returns.
+	OnStep func(stage, status string)
 }
 
 // CloudflareDeployResult contains the result of a deployment.
@@ -272,13 +276,17 @@ func SuggestProjectName(bundlePath string) string {
 }
 
 // DeployToCloudflarePages performs a complete deployment to Cloudflare Pages.
-func DeployToCloudflarePages(config CloudflareDeployConfig) (*CloudflareDeployResult, error) {
+func DeployToCloudflarePages(config CloudflareDeployConfig) (_ *CloudflareDeployResult, err error) {
+	step := stepReporter{onStep: config.OnStep}
+	defer func() { step.finish(err) }()
+
 	// Set default branch
 	if config.Branch == "" {
 		config.Branch = "main"
 	}
 
 	// 1. Check wrangler CLI status
+	step.start(StageCheckAuth)
 	status, err := CheckWranglerStatus()
 	if err != nil {
 		return nil, fmt.Errorf("failed to check wrangler status: %w", err)
@@ -349,6 +357,7 @@ func DeployToCloudflarePages(config CloudflareDeployConfig) (*CloudflareDeployRe
 	}
 
 	// 6. Generate _headers file for Cloudflare
+	step.start(StageWriteHeaders)
 	fmt.Println("\n  -> Generating _headers file...")
 	if err := GenerateHeadersFile(config.BundlePath); err != nil {
 		// Non-fatal, just warn
@@ -356,13 +365,10 @@ func DeployToCloudflarePages(config CloudflareDeployConfig) (*CloudflareDeployRe
 	}
 
 	// 7. Deploy to Cloudflare Pages
+	step.start(StageUpload)
 	fmt.Printf("\n  -> Deploying to Cloudflare Pages (project: %s)...\n", config.ProjectName)
 
-	cmd := exec.Command("wrangler", "pages", "deploy",
-		config.BundlePath,
-		"--project-name", config.ProjectName,
-		"--branch", config.Branch,
-	)
+	cmd := exec.Command("wrangler", wranglerDeployArgs(config)...)
 
 	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
@@ -389,6 +395,25 @@ func DeployToCloudflarePages(config CloudflareDeployConfig) (*CloudflareDeployRe
 	}, nil
 }
 
+// wranglerDeployArgs returns the wrangler arguments DeployToCloudflarePages
+// runs; config.Branch must already be defaulted.
+func wranglerDeployArgs(config CloudflareDeployConfig) []string {
+	return []string{"pages", "deploy",
+		config.BundlePath,
+		"--project-name", config.ProjectName,
+		"--branch", config.Branch,
+	}
+}
+
+// cloudflareDeployCommands lists the command lines DeployToCloudflarePages
+// runs for config, for dry runs. It also writes a _headers file first.
+func cloudflareDeployCommands(config CloudflareDeployConfig) []string {
+	if config.Branch == "" {
+		config.Branch = "main"
+	}
+	return []string{commandLine("wrangler", wranglerDeployArgs(config)...)}
+}
+
 // cloudflareConfirmPrompt asks for user confirmation.
 func cloudflareConfirmPrompt(question string) bool {
 	reader := bufio.NewReader(os.Stdin)
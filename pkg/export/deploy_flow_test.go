@@ -192,6 +192,325 @@ exit 0
 	}
 }
 
+func TestDeployToGitHubPages_Org_CreatesRepoUnderOrg(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	stateDir := t.TempDir()
+
+	ghScript := `#!/bin/sh
+set -eu
+state_dir="${BV_TEST_STATE_DIR:-}"
+
+case "${1-}" in
+  auth)
+    echo "Logged in to github.com account testuser (GitHub)"
+    exit 0
+    ;;
+  api)
+    case "${2-}" in
+      user/memberships/orgs/acme)
+        echo "active"
+        exit 0
+        ;;
+    esac
+    exit 1
+    ;;
+  repo)
+    case "${2-}" in
+      view)
+        echo "$*" >> "$state_dir/view_args"
+        exit 1
+        ;;
+      create)
+        echo "$*" > "$state_dir/create_args"
+        echo "create failed"
+        exit 1
+        ;;
+    esac
+    ;;
+esac
+
+exit 0
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+	t.Setenv("BV_TEST_STATE_DIR", stateDir)
+
+	bundleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundleDir, "index.html"), []byte("<!doctype html>"), 0644); err != nil {
+		t.Fatalf("WriteFile index.html: %v", err)
+	}
+
+	_, err := DeployToGitHubPages(GitHubDeployConfig{
+		RepoName:         "repo",
+		Org:              "acme",
+		BundlePath:       bundleDir,
+		SkipConfirmation: true,
+	})
+	if err == nil || !strings.Contains(strings.ToLower(err.Error()), "failed to create repository") {
+		t.Fatalf("Expected repo create failure, got: %v", err)
+	}
+
+	createArgs, readErr := os.ReadFile(filepath.Join(stateDir, "create_args"))
+	if readErr != nil {
+		t.Fatalf("gh repo create was not called: %v", readErr)
+	}
+	if !strings.Contains(string(createArgs), "repo create acme/repo ") {
+		t.Fatalf("Expected repo to be created under org, got args: %q", createArgs)
+	}
+	viewArgs, _ := os.ReadFile(filepath.Join(stateDir, "view_args"))
+	if !strings.Contains(string(viewArgs), "acme/repo") {
+		t.Fatalf("Expected existence check against org repo, got args: %q", viewArgs)
+	}
+}
+
+func TestDeployToGitHubPages_Org_NoAccess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	stateDir := t.TempDir()
+
+	ghScript := `#!/bin/sh
+set -eu
+state_dir="${BV_TEST_STATE_DIR:-}"
+
+case "${1-}" in
+  auth)
+    echo "Logged in to github.com account testuser (GitHub)"
+    exit 0
+    ;;
+  api)
+    echo "Not Found" >&2
+    exit 1
+    ;;
+  repo)
+    touch "$state_dir/repo_called"
+    exit 1
+    ;;
+esac
+
+exit 0
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+	t.Setenv("BV_TEST_STATE_DIR", stateDir)
+
+	bundleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundleDir, "index.html"), []byte("<!doctype html>"), 0644); err != nil {
+		t.Fatalf("WriteFile index.html: %v", err)
+	}
+
+	_, err := DeployToGitHubPages(GitHubDeployConfig{
+		RepoName:         "repo",
+		Org:              "acme",
+		BundlePath:       bundleDir,
+		SkipConfirmation: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), `no access to GitHub organization "acme"`) {
+		t.Fatalf("Expected org access error, got: %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(stateDir, "repo_called")); statErr == nil {
+		t.Fatal("Expected no repo commands after failed org access check")
+	}
+}
+
+func TestDeployToGitHubPages_RecordsPushedCommitSHA(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	stateDir := t.TempDir()
+
+	ghScript := `#!/bin/sh
+set -eu
+case "$*" in
+  "auth status"*)
+    echo "Logged in to github.com account testuser (GitHub)"
+    exit 0
+    ;;
+  "repo view testuser/site"*)
+    echo "site"
+    exit 0
+    ;;
+  "api repos/testuser/site/pages")
+    echo '{"html_url":"https://testuser.github.io/site/","source":{"branch":"main","path":"/"},"build_type":"legacy"}'
+    exit 0
+    ;;
+esac
+exit 1
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	gitScript := `#!/bin/sh
+set -eu
+echo "$*" >> "$BV_TEST_STATE_DIR/git_args"
+case "$*" in
+  "rev-parse HEAD")
+    echo "0123456789abcdef0123456789abcdef01234567"
+    ;;
+  "config user.name")
+    echo "Test User"
+    ;;
+  "config user.email")
+    echo "test@example.com"
+    ;;
+esac
+exit 0
+`
+	writeExecutable(t, binDir, "git", gitScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+	t.Setenv("BV_TEST_STATE_DIR", stateDir)
+
+	bundleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundleDir, "index.html"), []byte("<!doctype html>"), 0644); err != nil {
+		t.Fatalf("WriteFile index.html: %v", err)
+	}
+
+	result, err := DeployToGitHubPages(GitHubDeployConfig{
+		RepoName:         "testuser/site",
+		BundlePath:       bundleDir,
+		SkipConfirmation: true,
+	})
+	if err != nil {
+		t.Fatalf("DeployToGitHubPages: %v", err)
+	}
+	if result.CommitSHA != "0123456789abcdef0123456789abcdef01234567" {
+		t.Fatalf("Expected pushed commit SHA, got %q", result.CommitSHA)
+	}
+
+	gitArgs, err := os.ReadFile(filepath.Join(stateDir, "git_args"))
+	if err != nil {
+		t.Fatalf("ReadFile git_args: %v", err)
+	}
+	args := string(gitArgs)
+	pushAt := strings.Index(args, "push -u origin main")
+	if pushAt < 0 || strings.Index(args, "rev-parse HEAD") < pushAt {
+		t.Fatalf("Expected rev-parse after push, got git calls:\n%s", args)
+	}
+
+	// The wizard carries the deploy details through to its result.
+	wizard := NewWizard(t.TempDir())
+	wizard.config.DeployTarget = "github"
+	wizard.config.RepoName = "testuser/site"
+	wizard.bundlePath = bundleDir
+	wizard.SetDeployLogPath(filepath.Join(t.TempDir(), "deploys.jsonl"))
+	wizardResult, err := wizard.PerformDeploy()
+	if err != nil {
+		t.Fatalf("PerformDeploy: %v", err)
+	}
+	if wizardResult.CommitSHA != result.CommitSHA || !wizardResult.PagesAlreadyEnabled {
+		t.Fatalf("Expected the commit SHA and already-enabled Pages in the wizard result, got %+v", wizardResult)
+	}
+}
+
+func TestQualifyRepoName(t *testing.T) {
+	cases := []struct{ org, name, want string }{
+		{"", "repo", "repo"},
+		{"acme", "repo", "acme/repo"},
+		{"acme", "other/repo", "other/repo"},
+	}
+	for _, c := range cases {
+		if got := QualifyRepoName(c.org, c.name); got != c.want {
+			t.Errorf("QualifyRepoName(%q, %q) = %q, want %q", c.org, c.name, got, c.want)
+		}
+	}
+}
+
+func TestEnsureGitHubPages_IdempotentOnRepeatDeploy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	stateDir := t.TempDir()
+
+	ghScript := `#!/bin/sh
+set -eu
+state_dir="${BV_TEST_STATE_DIR:-}"
+enabled_file="$state_dir/pages_enabled"
+url="https://testuser.github.io/site/"
+
+case "$*" in
+  "api repos/testuser/site/pages -X POST"*)
+    echo "POST" >> "$state_dir/writes"
+    if [ -f "$enabled_file" ]; then
+      echo '{"message":"GitHub Pages is already enabled.","status":"409"}'
+      exit 1
+    fi
+    touch "$enabled_file"
+    exit 0
+    ;;
+  "api repos/testuser/site/pages -X PUT"*)
+    echo "PUT" >> "$state_dir/writes"
+    exit 0
+    ;;
+  "api repos/testuser/site/pages -q .html_url")
+    [ -f "$enabled_file" ] || exit 1
+    echo "$url"
+    exit 0
+    ;;
+  "api repos/testuser/site/pages")
+    [ -f "$enabled_file" ] || { echo '{"message":"Not Found"}'; exit 1; }
+    echo "{\"html_url\":\"$url\",\"source\":{\"branch\":\"main\",\"path\":\"/\"},\"build_type\":\"legacy\"}"
+    exit 0
+    ;;
+esac
+
+exit 1
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+	t.Setenv("BV_TEST_STATE_DIR", stateDir)
+
+	origDelay := pagesSetupDelay
+	pagesSetupDelay = 0
+	t.Cleanup(func() { pagesSetupDelay = origDelay })
+
+	url, already, err := EnsureGitHubPages("testuser/site")
+	if err != nil {
+		t.Fatalf("first EnsureGitHubPages: %v", err)
+	}
+	if already {
+		t.Fatal("Expected first run to enable Pages, got already enabled")
+	}
+	if url != "https://testuser.github.io/site/" {
+		t.Fatalf("Unexpected Pages URL on first run: %q", url)
+	}
+
+	url, already, err = EnsureGitHubPages("testuser/site")
+	if err != nil {
+		t.Fatalf("second EnsureGitHubPages: %v", err)
+	}
+	if !already {
+		t.Fatal("Expected second run to report Pages already enabled")
+	}
+	if url != "https://testuser.github.io/site/" {
+		t.Fatalf("Unexpected Pages URL on second run: %q", url)
+	}
+
+	writes, err := os.ReadFile(filepath.Join(stateDir, "writes"))
+	if err != nil {
+		t.Fatalf("ReadFile writes: %v", err)
+	}
+	if got := strings.TrimSpace(string(writes)); got != "POST" {
+		t.Fatalf("Expected a single POST across both runs, got %q", got)
+	}
+}
+
 func TestDeployToGitHubPages_AuthenticateFlow_StopsAtGitIdentityCheck(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("shell script stubs not supported on windows in this test")
@@ -460,3 +779,88 @@ exit 0
 		t.Fatalf("Unexpected DeployToCloudflarePages error: %v", err)
 	}
 }
+
+func TestWizard_PerformDeploy_DryRunRunsNothing(t *testing.T) {
+	// With an empty PATH any attempt to run gh, git or wrangler would fail
+	t.Setenv("PATH", "")
+
+	// The bundle is built in a scratch directory; the commands must name
+	// where a real run would put it instead.
+	scratch := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "deploys.jsonl")
+
+	tests := []struct {
+		name   string
+		config WizardConfig
+		path   string
+		want   []string
+	}{
+		{
+			name:   "github",
+			config: WizardConfig{DeployTarget: "github", RepoName: "pages", RepoOrg: "acme", RepoDescription: "Issue dashboard"},
+			path:   dryRunBundlePlaceholder,
+			want: []string{
+				"gh repo create acme/pages --public --description 'Issue dashboard' --clone=false",
+				"cd '<bundle-dir>' && git remote add origin https://github.com/acme/pages.git",
+				"git push -u origin main",
+				"gh api repos/acme/pages/pages -X POST",
+			},
+		},
+		{
+			name:   "cloudflare",
+			config: WizardConfig{DeployTarget: "cloudflare", CloudflareProject: "dash", OutputPath: "./site"},
+			path:   "./site",
+			want:   []string{"wrangler pages deploy ./site --project-name dash --branch main"},
+		},
+		{
+			name:   "local",
+			config: WizardConfig{DeployTarget: "local", OutputPath: "./site"},
+			path:   "./site",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wizard := NewWizard("/tmp/test")
+			wizard.SetDeployLogPath(logPath)
+			wizard.config = &tt.config
+			wizard.DryRun = true
+			wizard.PerformExport(scratch)
+
+			result, err := wizard.PerformDeploy()
+			if err != nil {
+				t.Fatalf("PerformDeploy: %v", err)
+			}
+			if !result.DryRun || result.DeployTarget != tt.config.DeployTarget || result.BundlePath != tt.path {
+				t.Errorf("result = %+v", result)
+			}
+			all := strings.Join(result.Commands, "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(all, want) {
+					t.Errorf("commands missing %q:\n%s", want, all)
+				}
+			}
+			if strings.Contains(all, scratch) {
+				t.Errorf("commands name the scratch directory:\n%s", all)
+			}
+			if len(tt.want) == 0 && len(result.Commands) != 0 {
+				t.Errorf("expected no commands, got %v", result.Commands)
+			}
+		})
+	}
+
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Errorf("dry runs should not be recorded in the deploy log (stat err %v)", err)
+	}
+}
+
+func TestWizard_PerformDeploy_ConfigDryRun(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+	wizard.SetDeployLogPath(filepath.Join(t.TempDir(), "deploys.jsonl"))
+	wizard.config = &WizardConfig{DeployTarget: "cloudflare", CloudflareProject: "dash", DryRun: true}
+	wizard.PerformExport(t.TempDir())
+
+	result, err := wizard.PerformDeploy()
+	if err != nil || !result.DryRun {
+		t.Fatalf("PerformDeploy = %+v, %v; want a dry run from the config", result, err)
+	}
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DeployRecord is one entry in the deploy audit log.
+type DeployRecord struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Target         string    `json:"target"`                  // "github", "cloudflare", "local", "single-file"
+	Repo           string    `json:"repo,omitempty"`          // GitHub owner/name or Cloudflare project
+	URL            string    `json:"url,omitempty"`           // Live site URL, or the bundle path for local targets
+	PublishedCount int       `json:"published_count"`         // Issues written to the bundle
+	Success        bool      `json:"success"`                 // False when the deploy returned an error
+	Error          string    `json:"error,omitempty"`         // Deploy error, if any
+	GitCommit      string    `json:"git_commit,omitempty"`    // HEAD of the project repository, if any
+	PushedCommit   string    `json:"pushed_commit,omitempty"` // Bundle commit pushed by git-based targets
+}
+
+// DeployLogPath returns the default path of the deploy audit log,
+// ~/.beads_viewer/deploys.jsonl.
+func DeployLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".beads_viewer", "deploys.jsonl")
+}
+
+// AppendDeployRecord appends record to the JSON-lines log at path, creating
+// the file and its directory if needed.
+func AppendDeployRecord(path string, record DeployRecord) error {
+	if path == "" {
+		return fmt.Errorf("could not determine deploy log path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadDeployRecords reads the log at path, oldest first. A missing log is
+// empty history, not an error.
+func ReadDeployRecords(path string) ([]DeployRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []DeployRecord
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record DeployRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SetDeployLogPath overrides where PerformDeploy records deploys.
+func (w *Wizard) SetDeployLogPath(path string) {
+	w.deployLogPath = path
+}
+
+// DeployHistory returns the recorded deploys, oldest first.
+func (w *Wizard) DeployHistory() ([]DeployRecord, error) {
+	return ReadDeployRecords(w.logPath())
+}
+
+func (w *Wizard) logPath() string {
+	if w.deployLogPath != "" {
+		return w.deployLogPath
+	}
+	return DeployLogPath()
+}
+
+// recordDeploy appends the outcome of a deploy to the audit log. Logging
+// problems are reported but never fail the deploy.
+func (w *Wizard) recordDeploy(result *WizardResult, deployErr error) {
+	record := DeployRecord{
+		Timestamp:      time.Now().UTC(),
+		Target:         w.config.DeployTarget,
+		PublishedCount: w.publishedCount,
+		Success:        deployErr == nil,
+		GitCommit:      gitHeadCommit(w.beadsPath),
+	}
+	if deployErr != nil {
+		record.Error = deployErr.Error()
+	}
+	if result != nil {
+		record.PublishedCount = result.PublishedCount
+		record.URL = result.PagesURL
+		record.PushedCommit = result.CommitSHA
+		switch {
+		case result.RepoFullName != "":
+			record.Repo = result.RepoFullName
+		case result.CloudflareProject != "":
+			record.Repo = result.CloudflareProject
+		}
+		if record.URL == "" {
+			record.URL = result.BundlePath
+		}
+	}
+
+	if err := AppendDeployRecord(w.logPath(), record); err != nil {
+		fmt.Printf("Warning: could not record deploy: %v\n", err)
+	}
+}
+
+// gitHeadCommit returns the HEAD commit of the repository containing path,
+// or "" if there is none.
+func gitHeadCommit(path string) string {
+	dir := path
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// formatDeployAge renders how long ago t was, e.g. "2h ago".
+func formatDeployAge(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,54 @@
+// Package export provides data export functionality for bv.
+//
+// This file reports deploy progress stage by stage so a UI can render a live
+// checklist while gh, git or wrangler run.
+package export
+
+// Deploy step statuses passed to an OnStep callback.
+const (
+	StepRunning = "running"
+	StepDone    = "done"
+	StepFailed  = "failed"
+)
+
+// Deploy stages reported by DeployToGitHubPages and DeployToCloudflarePages.
+const (
+	StageCheckAuth    = "check-auth"
+	StageCreateRepo   = "create-repo"
+	StagePush         = "push"
+	StageEnablePages  = "enable-pages"
+	StageWriteHeaders = "write-headers"
+	StageUpload       = "upload"
+)
+
+// stepReporter tracks the current deploy stage for an OnStep callback. A nil
+// callback makes every method a no-op.
+type stepReporter struct {
+	onStep func(stage, status string)
+	stage  string
+}
+
+// start marks the current stage done and reports stage as running.
+func (s *stepReporter) start(stage string) {
+	s.finish(nil)
+	s.stage = stage
+	if s.onStep != nil {
+		s.onStep(stage, StepRunning)
+	}
+}
+
+// finish reports the current stage as done, or failed when err is non-nil.
+// Deploy functions defer it with their returned error.
+func (s *stepReporter) finish(err error) {
+	if s.stage == "" {
+		return
+	}
+	status := StepDone
+	if err != nil {
+		status = StepFailed
+	}
+	if s.onStep != nil {
+		s.onStep(s.stage, status)
+	}
+	s.stage = ""
+}
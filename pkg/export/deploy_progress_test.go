@@ -0,0 +1,118 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+type recordedStep struct{ stage, status string }
+
+func recordSteps(steps *[]recordedStep) func(stage, status string) {
+	return func(stage, status string) {
+		*steps = append(*steps, recordedStep{stage, status})
+	}
+}
+
+func TestDeployToGitHubPages_ReportsSteps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+
+	ghScript := `#!/bin/sh
+case "$*" in
+  "auth status"*)
+    echo "Logged in to github.com account testuser (GitHub)"
+    exit 0
+    ;;
+  "repo view testuser/site"*)
+    echo "site"
+    exit 0
+    ;;
+  "api repos/testuser/site/pages")
+    echo '{"html_url":"https://testuser.github.io/site/","source":{"branch":"main","path":"/"},"build_type":"legacy"}'
+    exit 0
+    ;;
+esac
+exit 1
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	gitScript := `#!/bin/sh
+case "$*" in
+  "rev-parse HEAD") echo "0123456789abcdef0123456789abcdef01234567" ;;
+  "config user.name") echo "Test User" ;;
+  "config user.email") echo "test@example.com" ;;
+esac
+exit 0
+`
+	writeExecutable(t, binDir, "git", gitScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+
+	bundleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundleDir, "index.html"), []byte("<!doctype html>"), 0644); err != nil {
+		t.Fatalf("WriteFile index.html: %v", err)
+	}
+
+	var steps []recordedStep
+	if _, err := DeployToGitHubPages(GitHubDeployConfig{
+		RepoName:         "testuser/site",
+		BundlePath:       bundleDir,
+		SkipConfirmation: true,
+		OnStep:           recordSteps(&steps),
+	}); err != nil {
+		t.Fatalf("DeployToGitHubPages: %v", err)
+	}
+
+	want := []recordedStep{
+		{StageCheckAuth, StepRunning}, {StageCheckAuth, StepDone},
+		{StageCreateRepo, StepRunning}, {StageCreateRepo, StepDone},
+		{StagePush, StepRunning}, {StagePush, StepDone},
+		{StageEnablePages, StepRunning}, {StageEnablePages, StepDone},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps = %v\nwant %v", steps, want)
+	}
+}
+
+func TestDeployToGitHubPages_ReportsFailedStep(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	writeExecutable(t, binDir, "gh", "#!/bin/sh\necho \"You are not logged in\"\nexit 1\n")
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+
+	var steps []recordedStep
+	_, err := DeployToGitHubPages(GitHubDeployConfig{
+		RepoName:         "repo",
+		BundlePath:       t.TempDir(),
+		SkipConfirmation: true,
+		OnStep:           recordSteps(&steps),
+	})
+	if err == nil {
+		t.Fatal("Expected an authentication error")
+	}
+
+	want := []recordedStep{{StageCheckAuth, StepRunning}, {StageCheckAuth, StepFailed}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps = %v, want %v", steps, want)
+	}
+}
+
+func TestStepReporter_NilCallback(t *testing.T) {
+	step := stepReporter{}
+	step.start(StagePush)
+	step.finish(fmt.Errorf("boom"))
+	step.finish(nil)
+}
@@ -0,0 +1,232 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements per-epic pages: one viewer page for each top-level epic
+// and its descendants, plus an index linking to them, so large backlogs can be
+// browsed a slice at a time instead of in one giant page.
+package export
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EpicPagesDir is the bundle subdirectory holding the per-epic pages.
+const EpicPagesDir = "epics"
+
+// EpicPage describes one generated per-epic page.
+type EpicPage struct {
+	EpicID     string `json:"epic_id"`
+	Title      string `json:"title"`
+	File       string `json:"file"` // Relative to the bundle root, slash-separated
+	IssueCount int    `json:"issue_count"`
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// epicAssetRoot leads from an epic page back to the bundle root.
+const epicAssetRoot = "../"
+
+// epicPageRewrites point the loads in index.html that are not script or
+// stylesheet tags at the bundle root.
+var epicPageRewrites = [][2]string{
+	{`navigator.serviceWorker.register('./coi-serviceworker.js')`, `navigator.serviceWorker.register('` + epicAssetRoot + `coi-serviceworker.js')`},
+}
+
+// epicPageFile returns the bundle-relative file name for an epic's page. IDs
+// that sanitize to a name already in used get a numeric suffix; names are
+// compared case-insensitively so pages stay distinct on case-insensitive file
+// systems. The chosen name is added to used.
+func epicPageFile(epicID string, used map[string]bool) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(epicID, "_"), "._")
+	if name == "" || strings.EqualFold(name, "index") {
+		name = "epic_" + name
+	}
+	file := name
+	for i := 2; used[strings.ToLower(file)]; i++ {
+		file = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[strings.ToLower(file)] = true
+	return EpicPagesDir + "/" + file + ".html"
+}
+
+// GenerateEpicPageHTML renders the viewer for an epic's issues as a page that
+// lives in the bundle's epics/ directory. Only the issues' database and JSON
+// data are embedded; the viewer's scripts, styles and vendor files are loaded
+// from the bundle root, so every page shares the bundle's copies.
+func GenerateEpicPageHTML(opts SingleFileOptions) ([]byte, error) {
+	files, err := exportDataFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+	index, err := readViewerAsset("index.html")
+	if err != nil {
+		return nil, err
+	}
+
+	page := replaceTitle(string(index), opts.Title)
+	page = scriptSrcPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := scriptSrcPattern.FindStringSubmatch(tag)
+		return "<script" + m[1] + ` src="` + epicAssetPath(m[2]) + `"` + m[3] + "></script>"
+	})
+	page = stylesheetPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := stylesheetPattern.FindStringSubmatch(tag)
+		return `<link rel="stylesheet" href="` + epicAssetPath(m[1]) + `">`
+	})
+	for _, r := range epicPageRewrites {
+		page = strings.Replace(page, r[0], r[1], 1)
+	}
+	return injectFileMap(page, files, "<script>window.BV_ASSET_ROOT = '"+epicAssetRoot+"';</script>\n")
+}
+
+// epicAssetPath resolves a viewer asset reference from an epic page.
+func epicAssetPath(ref string) string {
+	if strings.Contains(ref, "://") {
+		return ref
+	}
+	return epicAssetRoot + strings.TrimPrefix(ref, "./")
+}
+
+// TopLevelEpics returns the epics that have no parent among issues, sorted by
+// ID, each with its descendants (via parent-child links) including itself.
+func TopLevelEpics(issues []model.Issue) (epics []model.Issue, members map[string][]model.Issue) {
+	byID := make(map[string]model.Issue, len(issues))
+	children := make(map[string][]string)
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	hasParent := make(map[string]bool)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if _, ok := byID[dep.DependsOnID]; ok {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+				hasParent[issue.ID] = true
+			}
+		}
+	}
+
+	members = make(map[string][]model.Issue)
+	for _, issue := range issues {
+		if issue.IssueType != model.TypeEpic || hasParent[issue.ID] {
+			continue
+		}
+		epics = append(epics, issue)
+
+		seen := map[string]bool{issue.ID: true}
+		queue := []string{issue.ID}
+		for head := 0; head < len(queue); head++ {
+			id := queue[head]
+			members[issue.ID] = append(members[issue.ID], byID[id])
+			for _, child := range children[id] {
+				if !seen[child] {
+					seen[child] = true
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+	sort.Slice(epics, func(i, j int) bool { return epics[i].ID < epics[j].ID })
+	return epics, members
+}
+
+// WriteEpicPages writes a page per top-level epic into the bundle's epics/
+// directory, plus epics/index.html linking to them. The pages load the viewer
+// from the bundle root, so it must hold the viewer assets. Issues outside any
+// epic are not given a page. Stats is optional.
+func WriteEpicPages(bundlePath string, issues []model.Issue, stats *analysis.GraphStats, title string) ([]EpicPage, error) {
+	if title == "" {
+		title = "Beads Viewer"
+	}
+
+	dir := filepath.Join(bundlePath, EpicPagesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create dir: %w", err)
+	}
+
+	epics, members := TopLevelEpics(issues)
+	pages := make([]EpicPage, 0, len(epics))
+	used := make(map[string]bool, len(epics))
+	for _, epic := range epics {
+		page := EpicPage{
+			EpicID:     epic.ID,
+			Title:      epic.Title,
+			File:       epicPageFile(epic.ID, used),
+			IssueCount: len(members[epic.ID]),
+		}
+		content, err := GenerateEpicPageHTML(SingleFileOptions{
+			Issues: members[epic.ID],
+			Stats:  stats,
+			Title:  fmt.Sprintf("%s: %s", epic.ID, epic.Title),
+		})
+		if err == nil {
+			err = os.WriteFile(filepath.Join(bundlePath, filepath.FromSlash(page.File)), content, 0644)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("write page for epic %s: %w", epic.ID, err)
+		}
+		pages = append(pages, page)
+	}
+
+	if err := writeEpicIndex(bundlePath, pages, title); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// writeEpicIndex writes epics/index.html listing the per-epic pages.
+func writeEpicIndex(bundlePath string, pages []EpicPage, title string) error {
+	var items strings.Builder
+	for _, page := range pages {
+		fmt.Fprintf(&items, "<li><a href=\"%s\">%s</a> %s <span>(%d issues)</span></li>\n",
+			html.EscapeString(strings.TrimPrefix(page.File, EpicPagesDir+"/")),
+			html.EscapeString(page.EpicID),
+			html.EscapeString(page.Title),
+			page.IssueCount)
+	}
+	if len(pages) == 0 {
+		items.WriteString("<li>No epics found.</li>\n")
+	}
+
+	content := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%[1]s: epics</title>
+<style>body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;max-width:900px;margin:0 auto;padding:1rem 1.5rem}li{margin:.35rem 0}span{color:#64748b}</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+<p><a href="../index.html">Full dashboard</a></p>
+<ul>
+%[2]s</ul>
+</body>
+</html>
+`, html.EscapeString(title), items.String())
+
+	if err := os.WriteFile(filepath.Join(bundlePath, EpicPagesDir, "index.html"), []byte(content), 0644); err != nil {
+		return fmt.Errorf("write epic index: %w", err)
+	}
+	return nil
+}
+
+// WriteEpicPages generates the per-epic pages in the wizard's bundle and
+// records them for the manifest.
+func (w *Wizard) WriteEpicPages(published []model.Issue, stats *analysis.GraphStats) ([]EpicPage, error) {
+	pages, err := WriteEpicPages(w.bundlePath, published, stats, w.config.Title)
+	if err != nil {
+		return nil, err
+	}
+	w.epicPages = pages
+	return pages, nil
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// childOf returns id's parent-child dependency on parent.
+func childOf(id, parent string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}
+}
+
+func TestWriteEpicPagesTwoEpics(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-a", Title: "Epic A", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "bv-b", Title: "Epic B", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "bv-a1", Title: "Task bv-a1", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("bv-a1", "bv-a")},
+		{ID: "bv-a2", Title: "Task bv-a2", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("bv-a2", "bv-a1")},
+		{ID: "bv-b1", Title: "Task bv-b1", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: childOf("bv-b1", "bv-b")},
+		{ID: "bv-loose", Title: "No epic", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+
+	bundle := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundle, "index.html"), []byte("<!doctype html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWizard("")
+	w.bundlePath = bundle
+	w.config.SplitByEpic = true
+	pages, err := w.WriteEpicPages(issues, nil)
+	if err != nil {
+		t.Fatalf("WriteEpicPages failed: %v", err)
+	}
+
+	var files []string
+	err = filepath.Walk(filepath.Join(bundle, EpicPagesDir), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(bundle, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	want := []string{"epics/bv-a.html", "epics/bv-b.html", "epics/index.html"}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("epic files = %v, want %v", files, want)
+	}
+
+	if len(pages) != 2 || pages[0].IssueCount != 3 || pages[1].IssueCount != 2 {
+		t.Errorf("unexpected pages: %+v", pages)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(bundle, "epics", "index.html"))
+	for _, link := range []string{`href="bv-a.html"`, `href="bv-b.html"`} {
+		if !strings.Contains(string(index), link) {
+			t.Errorf("index missing link %s", link)
+		}
+	}
+	pageA, _ := os.ReadFile(filepath.Join(bundle, "epics", "bv-a.html"))
+	if ids := singleFileIssueIDs(t, string(pageA)); !reflect.DeepEqual(ids, []string{"bv-a", "bv-a1", "bv-a2"}) {
+		t.Errorf("epic page should hold exactly its own subtree, got %v", ids)
+	}
+
+	manifest, err := w.WriteManifest(issues)
+	if err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	if len(manifest.EpicPages) != 2 {
+		t.Errorf("manifest should list epic pages, got %+v", manifest.EpicPages)
+	}
+	if err := ValidateBundle(bundle, manifest); err != nil {
+		t.Errorf("ValidateBundle on complete bundle: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(bundle, "epics", "bv-b.html")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateBundle(bundle, manifest); err == nil || !strings.Contains(err.Error(), "epics/bv-b.html") {
+		t.Errorf("ValidateBundle should report the missing epic page, got %v", err)
+	}
+}
+
+func TestGenerateEpicPageHTMLSharesBundleAssets(t *testing.T) {
+	out, err := GenerateEpicPageHTML(SingleFileOptions{
+		Issues: []model.Issue{
+			{ID: "bv-a", Title: "Epic A", Status: model.StatusOpen, IssueType: model.TypeEpic},
+			{ID: "bv-a1", Title: "Task", Status: model.StatusOpen, IssueType: model.TypeTask,
+				Dependencies: childOf("bv-a1", "bv-a")},
+		},
+		Title: "bv-a: Epic A",
+	})
+	if err != nil {
+		t.Fatalf("GenerateEpicPageHTML failed: %v", err)
+	}
+	page := string(out)
+
+	// Only the epic's data is embedded; the viewer itself is shared.
+	if len(out) > 512*1024 {
+		t.Errorf("epic page is %d bytes; the viewer runtime should not be inlined", len(out))
+	}
+	for _, tag := range []string{`<script src="../viewer.js">`, `<link rel="stylesheet" href="../styles.css">`, `window.BV_ASSET_ROOT = '../'`} {
+		if !strings.Contains(page, tag) {
+			t.Errorf("epic page missing %s", tag)
+		}
+	}
+	for _, m := range scriptSrcPattern.FindAllStringSubmatch(page, -1) {
+		if !strings.HasPrefix(m[2], epicAssetRoot) {
+			t.Errorf("script %q is not loaded from the bundle root", m[2])
+			continue
+		}
+		if _, err := readViewerAsset(strings.TrimPrefix(m[2], epicAssetRoot)); err != nil {
+			t.Errorf("script %q does not resolve to a viewer asset: %v", m[2], err)
+		}
+	}
+	if ids := singleFileIssueIDs(t, page); !reflect.DeepEqual(ids, []string{"bv-a", "bv-a1"}) {
+		t.Errorf("embedded issues = %v, want [bv-a bv-a1]", ids)
+	}
+}
+
+func TestWriteEpicPagesDistinctFilesForCollidingIDs(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a/b", Title: "Slash", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "a_b", Title: "Underscore", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A_B", Title: "Upper", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "Index", Title: "Index", Status: model.StatusOpen, IssueType: model.TypeEpic},
+	}
+
+	bundle := t.TempDir()
+	pages, err := WriteEpicPages(bundle, issues, nil, "")
+	if err != nil {
+		t.Fatalf("WriteEpicPages failed: %v", err)
+	}
+
+	seen := make(map[string]string)
+	for _, page := range pages {
+		key := strings.ToLower(page.File)
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s share page file %s", other, page.EpicID, page.File)
+		}
+		seen[key] = page.EpicID
+		if page.File == EpicPagesDir+"/index.html" {
+			t.Errorf("%s overwrites the epic index", page.EpicID)
+		}
+		content, err := os.ReadFile(filepath.Join(bundle, filepath.FromSlash(page.File)))
+		if err != nil {
+			t.Fatalf("page for %s not written: %v", page.EpicID, err)
+		}
+		if ids := singleFileIssueIDs(t, string(content)); len(ids) != 1 || ids[0] != page.EpicID {
+			t.Errorf("%s holds %v, want [%s]", page.File, ids, page.EpicID)
+		}
+	}
+}
@@ -22,6 +22,10 @@ type GitHubDeployConfig struct {
 	// RepoName is the desired repository name (without owner)
 	RepoName string
 
+	// Org is an optional GitHub organization to own the repository.
+	// Empty means the authenticated user's personal account.
+	Org string
+
 	// Private indicates whether the repository should be private
 	Private bool
 
@@ -36,6 +40,10 @@ type GitHubDeployConfig struct {
 
 	// ForceOverwrite allows overwriting non-empty repositories
 	ForceOverwrite bool
+
+	// OnStep, if set, is called as each deploy stage starts and finishes
+	// (see StepRunning). All calls happen before DeployToGitHubPages returns.
+	OnStep func(stage, status string)
 }
 
 // GitHubDeployResult contains the result of a deployment.
@@ -46,8 +54,15 @@ type GitHubDeployResult struct {
 	// PagesURL is the GitHub Pages URL
 	PagesURL string
 
+	// PagesAlreadyEnabled is true when Pages was already configured to serve
+	// the deployed branch, so no Pages settings were changed.
+	PagesAlreadyEnabled bool
+
 	// GitRemote is the git remote URL
 	GitRemote string
+
+	// CommitSHA is the bundle commit that was pushed
+	CommitSHA string
 }
 
 // GitHubStatus represents the current status of gh CLI.
@@ -188,6 +203,18 @@ func AuthenticateGH() error {
 
 // CreateRepository creates a new GitHub repository.
 func CreateRepository(name string, private bool, description string) (string, error) {
+	cmd := exec.Command("gh", repoCreateArgs(name, private, description)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to create repository: %s", strings.TrimSpace(string(output)))
+	}
+
+	// Get full repo name (owner/repo)
+	return getRepoFullName(name)
+}
+
+// repoCreateArgs returns the gh arguments CreateRepository runs.
+func repoCreateArgs(name string, private bool, description string) []string {
 	visibility := "--public"
 	if private {
 		visibility = "--private"
@@ -197,16 +224,58 @@ func CreateRepository(name string, private bool, description string) (string, er
 	if description != "" {
 		args = append(args, "--description", description)
 	}
-	args = append(args, "--clone=false")
+	return append(args, "--clone=false")
+}
 
-	cmd := exec.Command("gh", args...)
-	output, err := cmd.CombinedOutput()
+// CheckOrgAccess verifies the authenticated user is an active member of org.
+func CheckOrgAccess(org string) error {
+	cmd := exec.Command("gh", "api",
+		fmt.Sprintf("user/memberships/orgs/%s", org),
+		"-q", ".state")
+	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to create repository: %s", strings.TrimSpace(string(output)))
+		return fmt.Errorf("no access to GitHub organization %q (is it spelled correctly and are you a member?)", org)
 	}
 
-	// Get full repo name (owner/repo)
-	return getRepoFullName(name)
+	state := strings.TrimSpace(string(output))
+	if state != "active" {
+		return fmt.Errorf("membership in GitHub organization %q is %q, not active", org, state)
+	}
+	return nil
+}
+
+// validateRepoName checks name against GitHub's repository naming rules:
+// 1-100 ASCII letters, digits, '-', '_' or '.', not starting or ending with a
+// dot. An owner prefix is rejected; the organization is configured separately.
+func validateRepoName(name string) error {
+	if name == "" {
+		return fmt.Errorf("repository name is required")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("repository name %q is longer than 100 characters", name)
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		case r == '/':
+			return fmt.Errorf("repository name %q must not include an owner; set the organization instead", name)
+		default:
+			return fmt.Errorf("repository name %q contains %q; use letters, digits, '-', '_' or '.'", name, r)
+		}
+	}
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return fmt.Errorf("repository name %q must not start or end with '.'", name)
+	}
+	return nil
+}
+
+// QualifyRepoName prefixes name with org ("org/name") unless org is empty or
+// name already includes an owner.
+func QualifyRepoName(org, name string) string {
+	if org == "" || strings.Contains(name, "/") {
+		return name
+	}
+	return org + "/" + name
 }
 
 // getRepoFullName retrieves the full name (owner/repo) of a repository.
@@ -248,6 +317,24 @@ func RepoHasContent(repoFullName string) (bool, error) {
 	return length != "" && length != "0", nil
 }
 
+// gitStep is one git invocation in the bundle, with its progress message.
+type gitStep struct {
+	args []string
+	desc string
+}
+
+// gitPublishSteps is the sequence of git commands InitAndPush runs in the
+// bundle before pushing to remoteURL.
+func gitPublishSteps(remoteURL string) []gitStep {
+	return []gitStep{
+		{[]string{"init"}, "Initializing git repository"},
+		{[]string{"add", "."}, "Staging files"},
+		{[]string{"commit", "-m", "Deploy static site via bv --pages"}, "Creating commit"},
+		{[]string{"branch", "-M", "main"}, "Setting main branch"},
+		{[]string{"remote", "add", "origin", remoteURL}, "Adding remote"},
+	}
+}
+
 // InitAndPush initializes a git repository and pushes to GitHub.
 func InitAndPush(bundlePath string, repoFullName string, forceOverwrite bool) error {
 	// Check if repo has existing content
@@ -261,18 +348,7 @@ func InitAndPush(bundlePath string, repoFullName string, forceOverwrite bool) er
 	}
 
 	remoteURL := fmt.Sprintf("https://github.com/%s.git", repoFullName)
-
-	// Sequence of git commands
-	commands := []struct {
-		args []string
-		desc string
-	}{
-		{[]string{"init"}, "Initializing git repository"},
-		{[]string{"add", "."}, "Staging files"},
-		{[]string{"commit", "-m", "Deploy static site via bv --pages"}, "Creating commit"},
-		{[]string{"branch", "-M", "main"}, "Setting main branch"},
-		{[]string{"remote", "add", "origin", remoteURL}, "Adding remote"},
-	}
+	commands := gitPublishSteps(remoteURL)
 
 	// Check if remote already exists
 	checkRemote := exec.Command("git", "remote", "get-url", "origin")
@@ -331,8 +407,45 @@ func InitAndPush(bundlePath string, repoFullName string, forceOverwrite bool) er
 	return nil
 }
 
+// pagesSetupDelay is how long to wait after enabling Pages before asking for
+// its URL. Tests shorten it.
+var pagesSetupDelay = 3 * time.Second
+
 // EnableGitHubPages enables GitHub Pages for a repository.
 func EnableGitHubPages(repoFullName string) (string, error) {
+	url, _, err := EnsureGitHubPages(repoFullName)
+	return url, err
+}
+
+// EnsureGitHubPages makes sure GitHub Pages serves the root of the main branch,
+// only enabling or updating the configuration when needed, so repeat deploys
+// are no-ops. It returns the Pages URL and whether Pages was already set up.
+func EnsureGitHubPages(repoFullName string) (string, bool, error) {
+	status, err := CheckGitHubPagesStatus(repoFullName)
+	if err == nil && status.Enabled {
+		if status.Branch == "main" && (status.Path == "/" || status.Path == "") {
+			fmt.Println("  -> GitHub Pages already enabled")
+			if status.URL != "" {
+				return status.URL, true, nil
+			}
+			url, err := getGitHubPagesURL(repoFullName)
+			return url, true, err
+		}
+
+		fmt.Println("  -> Updating GitHub Pages source to main...")
+		cmd := exec.Command("gh", "api",
+			fmt.Sprintf("repos/%s/pages", repoFullName),
+			"-X", "PUT",
+			"-f", "source[branch]=main",
+			"-f", "source[path]=/",
+		)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return "", false, fmt.Errorf("failed to update GitHub Pages: %s", strings.TrimSpace(string(output)))
+		}
+		url, err := getGitHubPagesURL(repoFullName)
+		return url, false, err
+	}
+
 	fmt.Println("  -> Enabling GitHub Pages...")
 
 	// Try to enable Pages via API
@@ -349,16 +462,18 @@ func EnableGitHubPages(repoFullName string) (string, error) {
 		if strings.Contains(string(output), "already exists") ||
 			strings.Contains(string(output), "409") {
 			fmt.Println("  -> GitHub Pages already enabled")
-			return getGitHubPagesURL(repoFullName)
+			url, err := getGitHubPagesURL(repoFullName)
+			return url, true, err
 		}
-		return "", fmt.Errorf("failed to enable GitHub Pages: %s", strings.TrimSpace(string(output)))
+		return "", false, fmt.Errorf("failed to enable GitHub Pages: %s", strings.TrimSpace(string(output)))
 	}
 
 	// Wait a moment for Pages to be configured
 	fmt.Println("  -> Waiting for Pages configuration...")
-	time.Sleep(3 * time.Second)
+	time.Sleep(pagesSetupDelay)
 
-	return getGitHubPagesURL(repoFullName)
+	url, err := getGitHubPagesURL(repoFullName)
+	return url, false, err
 }
 
 // getGitHubPagesURL retrieves the GitHub Pages URL for a repository.
@@ -389,8 +504,12 @@ func getGitHubPagesURL(repoFullName string) (string, error) {
 }
 
 // DeployToGitHubPages performs a complete deployment to GitHub Pages.
-func DeployToGitHubPages(config GitHubDeployConfig) (*GitHubDeployResult, error) {
+func DeployToGitHubPages(config GitHubDeployConfig) (_ *GitHubDeployResult, err error) {
+	step := stepReporter{onStep: config.OnStep}
+	defer func() { step.finish(err) }()
+
 	// 1. Check gh CLI status
+	step.start(StageCheckAuth)
 	status, err := CheckGHStatus()
 	if err != nil {
 		return nil, fmt.Errorf("failed to check GitHub status: %w", err)
@@ -443,12 +562,20 @@ func DeployToGitHubPages(config GitHubDeployConfig) (*GitHubDeployResult, error)
 		return nil, fmt.Errorf("bundle path does not exist: %s", config.BundlePath)
 	}
 
-	// 6. Create or use existing repository
+	// 6. Create or use existing repository (under the org when one is set)
+	step.start(StageCreateRepo)
+	if config.Org != "" {
+		if err := CheckOrgAccess(config.Org); err != nil {
+			return nil, err
+		}
+	}
+	repoName := QualifyRepoName(config.Org, config.RepoName)
+
 	var repoFullName string
-	repoExists := RepoExists(config.RepoName)
+	repoExists := RepoExists(repoName)
 
 	if repoExists {
-		repoFullName, err = getRepoFullName(config.RepoName)
+		repoFullName, err = getRepoFullName(repoName)
 		if err != nil {
 			return nil, err
 		}
@@ -465,8 +592,8 @@ func DeployToGitHubPages(config GitHubDeployConfig) (*GitHubDeployResult, error)
 			config.ForceOverwrite = true
 		}
 	} else {
-		fmt.Printf("\nCreating repository: %s\n", config.RepoName)
-		repoFullName, err = CreateRepository(config.RepoName, config.Private, config.Description)
+		fmt.Printf("\nCreating repository: %s\n", repoName)
+		repoFullName, err = CreateRepository(repoName, config.Private, config.Description)
 		if err != nil {
 			return nil, err
 		}
@@ -474,24 +601,68 @@ func DeployToGitHubPages(config GitHubDeployConfig) (*GitHubDeployResult, error)
 	}
 
 	// 7. Initialize and push
+	step.start(StagePush)
 	fmt.Println("\nDeploying to GitHub...")
 	if err := InitAndPush(config.BundlePath, repoFullName, config.ForceOverwrite); err != nil {
 		return nil, err
 	}
 
+	commitSHA, err := BundleCommitSHA(config.BundlePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// 8. Enable GitHub Pages
-	pagesURL, err := EnableGitHubPages(repoFullName)
+	step.start(StageEnablePages)
+	pagesURL, alreadyEnabled, err := EnsureGitHubPages(repoFullName)
 	if err != nil {
 		return nil, err
 	}
 
 	return &GitHubDeployResult{
-		RepoFullName: repoFullName,
-		PagesURL:     pagesURL,
-		GitRemote:    fmt.Sprintf("https://github.com/%s.git", repoFullName),
+		RepoFullName:        repoFullName,
+		PagesURL:            pagesURL,
+		PagesAlreadyEnabled: alreadyEnabled,
+		GitRemote:           fmt.Sprintf("https://github.com/%s.git", repoFullName),
+		CommitSHA:           commitSHA,
 	}, nil
 }
 
+// gitHubDeployCommands lists the command lines DeployToGitHubPages runs for
+// config, for dry runs. Without an org the owner is the authenticated gh user,
+// shown as "<you>" since a dry run does not query gh.
+func gitHubDeployCommands(config GitHubDeployConfig) []string {
+	repoName := QualifyRepoName(config.Org, config.RepoName)
+	repoFullName := repoName
+	if !strings.Contains(repoFullName, "/") {
+		repoFullName = "<you>/" + repoFullName
+	}
+
+	cmds := []string{
+		commandLine("gh", repoCreateArgs(repoName, config.Private, config.Description)...) + "  # unless it exists",
+	}
+	for _, step := range gitPublishSteps(fmt.Sprintf("https://github.com/%s.git", repoFullName)) {
+		cmds = append(cmds, "cd "+commandLine(config.BundlePath)+" && "+commandLine("git", step.args...))
+	}
+	return append(cmds,
+		"cd "+commandLine(config.BundlePath)+" && "+commandLine("git", "push", "-u", "origin", "main"),
+		commandLine("gh", "api", fmt.Sprintf("repos/%s/pages", repoFullName),
+			"-X", "POST", "-f", "source[branch]=main", "-f", "source[path]=/"),
+	)
+}
+
+// BundleCommitSHA returns the HEAD commit of the git repository in bundlePath,
+// i.e. the commit InitAndPush pushed.
+func BundleCommitSHA(bundlePath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = bundlePath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read pushed commit: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // confirmPrompt asks for user confirmation.
 func confirmPrompt(question string) bool {
 	reader := bufio.NewReader(os.Stdin)
@@ -3,6 +3,7 @@ package export
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -244,3 +245,29 @@ func TestSuggestRepoName_RegularDir(t *testing.T) {
 		t.Errorf("Expected 'my-static-site', got %s", result)
 	}
 }
+
+func TestValidateRepoName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"beads-viewer-pages", true},
+		{"my_repo.v2", true},
+		{"a", true},
+		{strings.Repeat("a", 100), true},
+		{"", false},
+		{strings.Repeat("a", 101), false},
+		{"my repo", false},
+		{"owner/repo", false},
+		{"pagés", false},
+		{"看板", false},
+		{".hidden", false},
+		{"trailing.", false},
+	}
+	for _, tt := range tests {
+		err := validateRepoName(tt.name)
+		if (err == nil) != tt.valid {
+			t.Errorf("validateRepoName(%q) = %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements the export manifest: a manifest.json written to the
+// bundle root recording exactly which issues were published and which filter
+// settings decided that, so "is ticket X on the dashboard?" has a definitive
+// answer after the fact.
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ManifestFileName is the name of the manifest file in the bundle root.
+const ManifestFileName = "manifest.json"
+
+// ExportManifest describes the contents of a published bundle.
+type ExportManifest struct {
+	GeneratedAt    time.Time       `json:"generated_at"`
+	PublishedCount int             `json:"published_count"`
+	IssueIDs       []string        `json:"issue_ids"` // Sorted for stable diffs
+	Filters        ManifestFilters `json:"filters"`
+	EpicPages      []EpicPage      `json:"epic_pages,omitempty"` // Set when the bundle is split per epic
+}
+
+// ManifestFilters records the export options that shaped the bundle.
+type ManifestFilters struct {
+	IncludeClosed   bool           `json:"include_closed"`
+	IncludeStatuses []model.Status `json:"include_statuses,omitempty"`
+	IncludeHistory  bool           `json:"include_history"`
+	SplitByEpic     bool           `json:"split_by_epic,omitempty"`
+
+	// Exclusions are recorded by label and count only: listing the excluded
+	// IDs would reveal the very tickets they keep off a public dashboard.
+	ExcludeLabels   []string `json:"exclude_labels,omitempty"`
+	ExcludedIDCount int      `json:"excluded_id_count,omitempty"`
+}
+
+// FilterExportIssues applies the wizard's export filters to issues and
+// returns the subset that should be published. Excluded labels and IDs are
+// dropped whatever the issue's status, along with the published issues'
+// dependencies on them.
+func FilterExportIssues(issues []model.Issue, config *WizardConfig) []model.Issue {
+	if config == nil || (config.IncludeClosed && len(config.IncludeStatuses) == 0 && len(config.ExcludeLabels) == 0 && len(config.ExcludeIDs) == 0) {
+		return issues
+	}
+
+	excludedIDs := make(map[string]bool, len(config.ExcludeIDs))
+	for _, id := range config.ExcludeIDs {
+		excludedIDs[id] = true
+	}
+	excludedLabels := make(map[string]bool, len(config.ExcludeLabels))
+	for _, label := range config.ExcludeLabels {
+		excludedLabels[strings.ToLower(label)] = true
+	}
+
+	var filtered []model.Issue
+	for _, issue := range issues {
+		if hasExcludedLabel(issue, excludedLabels) {
+			excludedIDs[issue.ID] = true
+		}
+		if excludedIDs[issue.ID] || !config.StatusIncluded(issue.Status) {
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	for i := range filtered {
+		filtered[i].Dependencies = withoutExcludedTargets(filtered[i].Dependencies, excludedIDs)
+	}
+	return filtered
+}
+
+// withoutExcludedTargets drops the dependencies on excluded issues, so a
+// published issue does not reveal their IDs. deps is copied, not modified.
+func withoutExcludedTargets(deps []*model.Dependency, excluded map[string]bool) []*model.Dependency {
+	if !slices.ContainsFunc(deps, func(dep *model.Dependency) bool { return dep != nil && excluded[dep.DependsOnID] }) {
+		return deps
+	}
+	kept := make([]*model.Dependency, 0, len(deps))
+	for _, dep := range deps {
+		if dep == nil || !excluded[dep.DependsOnID] {
+			kept = append(kept, dep)
+		}
+	}
+	return kept
+}
+
+// StatusIncluded reports whether issues with status s are published. A
+// non-empty IncludeStatuses decides; otherwise, as in configs saved before it
+// existed, every status is published except closed when IncludeClosed is false.
+func (c *WizardConfig) StatusIncluded(s model.Status) bool {
+	if len(c.IncludeStatuses) == 0 {
+		return c.IncludeClosed || s != model.StatusClosed
+	}
+	return slices.Contains(c.IncludeStatuses, s)
+}
+
+// SetIncludeClosed sets IncludeClosed and, when IncludeStatuses is in use,
+// adds or removes the closed status to match.
+func (c *WizardConfig) SetIncludeClosed(include bool) {
+	c.IncludeClosed = include
+	if len(c.IncludeStatuses) == 0 {
+		return
+	}
+	c.IncludeStatuses = slices.DeleteFunc(c.IncludeStatuses, func(s model.Status) bool {
+		return s == model.StatusClosed
+	})
+	if include {
+		c.IncludeStatuses = append(c.IncludeStatuses, model.StatusClosed)
+	}
+}
+
+// syncIncludeClosed sets IncludeClosed from IncludeStatuses when that is in
+// use, for configs written by hand that set only the latter.
+func (c *WizardConfig) syncIncludeClosed() {
+	if len(c.IncludeStatuses) > 0 {
+		c.IncludeClosed = slices.Contains(c.IncludeStatuses, model.StatusClosed)
+	}
+}
+
+// hasExcludedLabel reports whether issue carries any label in excluded
+// (lowercased keys); label matching is case-insensitive.
+func hasExcludedLabel(issue model.Issue, excluded map[string]bool) bool {
+	for _, label := range issue.Labels {
+		if excluded[strings.ToLower(label)] {
+			return true
+		}
+	}
+	return false
+}
+
+// NewExportManifest builds a manifest for the given published issues.
+func NewExportManifest(published []model.Issue, config *WizardConfig) *ExportManifest {
+	ids := make([]string, 0, len(published))
+	for _, issue := range published {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+
+	manifest := &ExportManifest{
+		GeneratedAt:    time.Now().UTC(),
+		PublishedCount: len(ids),
+		IssueIDs:       ids,
+	}
+	if config != nil {
+		manifest.Filters = ManifestFilters{
+			IncludeClosed:   config.StatusIncluded(model.StatusClosed),
+			IncludeStatuses: config.IncludeStatuses,
+			IncludeHistory:  config.IncludeHistory,
+			SplitByEpic:     config.SplitByEpic,
+			ExcludeLabels:   config.ExcludeLabels,
+			ExcludedIDCount: len(config.ExcludeIDs),
+		}
+	}
+	return manifest
+}
+
+// WriteExportManifest writes manifest.json for the published issues into bundlePath.
+func WriteExportManifest(bundlePath string, published []model.Issue, config *WizardConfig) (*ExportManifest, error) {
+	manifest := NewExportManifest(published, config)
+	if err := writeJSON(filepath.Join(bundlePath, ManifestFileName), manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// WriteManifest records the published issues in the wizard's bundle and
+// remembers the count for the deploy result.
+func (w *Wizard) WriteManifest(published []model.Issue) (*ExportManifest, error) {
+	manifest := NewExportManifest(published, w.config)
+	manifest.EpicPages = w.epicPages
+	if err := writeJSON(filepath.Join(w.bundlePath, ManifestFileName), manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	w.publishedCount = manifest.PublishedCount
+	return manifest, nil
+}
+
+// ValidateBundle checks that a bundle contains the pages its manifest
+// promises: the root index.html and, for split bundles, the epic index and
+// every per-epic page. A nil manifest checks only the root index.
+func ValidateBundle(bundlePath string, manifest *ExportManifest) error {
+	required := []string{"index.html"}
+	if manifest != nil && (manifest.Filters.SplitByEpic || len(manifest.EpicPages) > 0) {
+		required = append(required, EpicPagesDir+"/index.html")
+		for _, page := range manifest.EpicPages {
+			required = append(required, page.File)
+		}
+	}
+
+	var missing []string
+	for _, rel := range required {
+		if _, err := os.Stat(filepath.Join(bundlePath, filepath.FromSlash(rel))); err != nil {
+			missing = append(missing, rel)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("bundle %s is missing: %s", bundlePath, strings.Join(missing, ", "))
+	}
+	return nil
+}
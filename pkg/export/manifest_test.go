@@ -0,0 +1,239 @@
+package export
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWriteManifestExcludesClosedIssues(t *testing.T) {
+	bundle := t.TempDir()
+	issues := []model.Issue{
+		{ID: "bv-2", Title: "Open", Status: model.StatusOpen},
+		{ID: "bv-1", Title: "Closed", Status: model.StatusClosed},
+		{ID: "bv-3", Title: "In progress", Status: model.StatusInProgress},
+	}
+
+	wizard := NewWizard(bundle)
+	wizard.config.IncludeClosed = false
+	wizard.PerformExport(bundle)
+
+	published := FilterExportIssues(issues, wizard.GetConfig())
+	if _, err := wizard.WriteManifest(published); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(bundle, ManifestFileName))
+	if err != nil {
+		t.Fatalf("manifest not written: %v", err)
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest JSON: %v", err)
+	}
+
+	if manifest.Filters.IncludeClosed {
+		t.Error("expected manifest to record include_closed=false")
+	}
+	if manifest.GeneratedAt.IsZero() {
+		t.Error("expected generated_at to be set")
+	}
+	want := []string{"bv-2", "bv-3"}
+	if len(manifest.IssueIDs) != len(want) {
+		t.Fatalf("IssueIDs = %v, want %v", manifest.IssueIDs, want)
+	}
+	for i, id := range want {
+		if manifest.IssueIDs[i] != id {
+			t.Errorf("IssueIDs[%d] = %q, want %q", i, manifest.IssueIDs[i], id)
+		}
+	}
+
+	wizard.config.DeployTarget = "local"
+	wizard.SetDeployLogPath(filepath.Join(t.TempDir(), "deploys.jsonl"))
+	result, err := wizard.PerformDeploy()
+	if err != nil {
+		t.Fatalf("PerformDeploy failed: %v", err)
+	}
+	if result.PublishedCount != 2 {
+		t.Errorf("PublishedCount = %d, want 2", result.PublishedCount)
+	}
+}
+
+func TestFilterExportIssuesIncludeClosed(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen},
+		{ID: "b", Status: model.StatusClosed},
+	}
+	got := FilterExportIssues(issues, &WizardConfig{IncludeClosed: true})
+	if len(got) != 2 {
+		t.Errorf("expected all issues with IncludeClosed, got %d", len(got))
+	}
+}
+
+func TestExcludedLabelIssueAbsentFromBundle(t *testing.T) {
+	bundle := t.TempDir()
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Public", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepBlocks},
+			{IssueID: "bv-1", DependsOnID: "bv-3", Type: model.DepBlocks},
+			{IssueID: "bv-1", DependsOnID: "bv-4", Type: model.DepBlocks},
+		}},
+		{ID: "bv-2", Title: "Embargoed CVE", Status: model.StatusOpen, Labels: []string{"Security"}},
+		{ID: "bv-3", Title: "Customer escalation", Status: model.StatusInProgress},
+		{ID: "bv-4", Title: "Public blocker", Status: model.StatusOpen},
+	}
+
+	wizard := NewWizard(bundle)
+	wizard.config.ExcludeLabels = []string{"security"}
+	wizard.config.ExcludeIDs = []string{"bv-3"}
+	wizard.PerformExport(bundle)
+
+	published := FilterExportIssues(issues, wizard.GetConfig())
+	if len(issues[0].Dependencies) != 3 {
+		t.Fatal("FilterExportIssues must not modify the caller's issues")
+	}
+	if err := newBundleExporter(published, nil, nil).Export(bundle); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	manifest, err := wizard.WriteManifest(published)
+	if err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(bundle, "beads.sqlite3"))
+	if err != nil {
+		t.Fatalf("open bundle database: %v", err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues WHERE id IN ('bv-2', 'bv-3')`).Scan(&count); err != nil {
+		t.Fatalf("query bundle: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("excluded issues found in bundle database: %d", count)
+	}
+	var targets []string
+	rows, err := db.Query(`SELECT depends_on_id FROM dependencies ORDER BY depends_on_id`)
+	if err != nil {
+		t.Fatalf("query dependencies: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var target string
+		if err := rows.Scan(&target); err != nil {
+			t.Fatal(err)
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) != 1 || targets[0] != "bv-4" {
+		t.Errorf("dependency targets = %v, want only the published bv-4", targets)
+	}
+
+	if len(manifest.IssueIDs) != 2 || manifest.IssueIDs[0] != "bv-1" || manifest.IssueIDs[1] != "bv-4" {
+		t.Errorf("IssueIDs = %v, want [bv-1 bv-4]", manifest.IssueIDs)
+	}
+	if got := manifest.Filters.ExcludeLabels; len(got) != 1 || got[0] != "security" {
+		t.Errorf("manifest exclude_labels = %v, want [security]", got)
+	}
+	if manifest.Filters.ExcludedIDCount != 1 {
+		t.Errorf("manifest excluded_id_count = %d, want 1", manifest.Filters.ExcludedIDCount)
+	}
+	data, err := os.ReadFile(filepath.Join(bundle, ManifestFileName))
+	if err != nil {
+		t.Fatalf("manifest not written: %v", err)
+	}
+	if strings.Contains(string(data), "bv-3") {
+		t.Error("manifest should not list excluded issue IDs")
+	}
+	err = filepath.WalkDir(bundle, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, id := range []string{"bv-2", "bv-3"} {
+			if strings.Contains(string(content), id) {
+				t.Errorf("%s mentions excluded issue %s", filepath.Base(path), id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFilterExportIssuesIncludeStatuses(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Status: model.StatusOpen},
+		{ID: "bv-2", Status: model.StatusClosed},
+		{ID: "bv-3", Status: model.StatusTombstone},
+		{ID: "bv-4", Status: model.StatusBlocked},
+	}
+	config := &WizardConfig{
+		IncludeClosed:   true,
+		IncludeStatuses: []model.Status{model.StatusOpen, model.StatusClosed},
+	}
+
+	if got := issueIDs(FilterExportIssues(issues, config)); got != "bv-1,bv-2" {
+		t.Errorf("published = %s, want bv-1,bv-2", got)
+	}
+
+	// The IncludeClosed shim removes and re-adds closed from the status set
+	config.SetIncludeClosed(false)
+	if got := issueIDs(FilterExportIssues(issues, config)); got != "bv-1" {
+		t.Errorf("published after SetIncludeClosed(false) = %s, want bv-1", got)
+	}
+	config.SetIncludeClosed(true)
+	if got := issueIDs(FilterExportIssues(issues, config)); got != "bv-1,bv-2" {
+		t.Errorf("published after SetIncludeClosed(true) = %s, want bv-1,bv-2", got)
+	}
+
+	// Without a status set, IncludeClosed alone decides as before
+	legacy := &WizardConfig{IncludeClosed: false}
+	if got := issueIDs(FilterExportIssues(issues, legacy)); got != "bv-1,bv-3,bv-4" {
+		t.Errorf("legacy published = %s, want bv-1,bv-3,bv-4", got)
+	}
+}
+
+func TestManifestIncludeClosedFollowsIncludeStatuses(t *testing.T) {
+	// A hand-written config that lists statuses but leaves include_closed at
+	// its default publishes no closed issues, and the manifest must say so.
+	cfg := WizardConfig{
+		IncludeClosed:   true,
+		IncludeStatuses: []model.Status{model.StatusOpen, model.StatusInProgress},
+		OutputPath:      t.TempDir(),
+	}
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Open", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Closed", Status: model.StatusClosed},
+	}
+
+	if manifest := NewExportManifest(issues[:1], &cfg); manifest.Filters.IncludeClosed {
+		t.Error("manifest include_closed should be false when include_statuses omits closed")
+	}
+
+	wizard := NewWizard(t.TempDir())
+	if _, err := wizard.BuildOnly(cfg, issues); err != nil {
+		t.Fatalf("BuildOnly failed: %v", err)
+	}
+	if wizard.GetConfig().IncludeClosed {
+		t.Error("BuildOnly should derive IncludeClosed from IncludeStatuses")
+	}
+}
+
+func issueIDs(issues []model.Issue) string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return strings.Join(ids, ",")
+}
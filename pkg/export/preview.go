@@ -44,18 +44,9 @@ func (p *PreviewServer) Start() error {
 		return fmt.Errorf("no index.html found in bundle: %s", p.bundlePath)
 	}
 
-	mux := http.NewServeMux()
-
-	// Static file server with no-cache middleware
-	fs := http.FileServer(http.Dir(p.bundlePath))
-	mux.Handle("/", noCacheMiddleware(fs))
-
-	// Status endpoint
-	mux.HandleFunc("/__preview__/status", p.statusHandler)
-
 	p.server = &http.Server{
 		Addr:    fmt.Sprintf("127.0.0.1:%d", p.port),
-		Handler: mux,
+		Handler: p.handler(),
 	}
 
 	// Open browser after short delay
@@ -75,6 +66,20 @@ func (p *PreviewServer) Start() error {
 	return p.server.ListenAndServe()
 }
 
+// handler serves the bundle with no-cache headers plus the status endpoint.
+func (p *PreviewServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// Static file server with no-cache middleware
+	fs := http.FileServer(http.Dir(p.bundlePath))
+	mux.Handle("/", noCacheMiddleware(fs))
+
+	// Status endpoint
+	mux.HandleFunc("/__preview__/status", p.statusHandler)
+
+	return mux
+}
+
 // StartWithGracefulShutdown starts the server with signal handling for clean shutdown.
 func (p *PreviewServer) StartWithGracefulShutdown() error {
 	// Channel to receive OS signals
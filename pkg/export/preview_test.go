@@ -276,3 +276,53 @@ func (w *testResponseWriter) Write(data []byte) (int, error) {
 func (w *testResponseWriter) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
 }
+
+func TestWizard_ServePreview(t *testing.T) {
+	bundle := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(bundle, "data"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "data", "meta.json"), []byte(`{"ok":true}`), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	wizard := NewWizard("/tmp/test")
+	wizard.PerformExport(bundle)
+
+	stop, url, err := wizard.ServePreview("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ServePreview: %v", err)
+	}
+
+	// The listener is bound on return, so no retry loop is needed
+	resp, err := http.Get(url + "/data/meta.json")
+	if err != nil {
+		stop()
+		t.Fatalf("GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != `{"ok":true}` {
+		t.Errorf("GET data/meta.json = %d %q", resp.StatusCode, body)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc == "" {
+		t.Error("Expected no-cache headers on preview responses")
+	}
+
+	stop()
+	if _, err := http.Get(url + "/index.html"); err == nil {
+		t.Error("Expected requests to fail after stop")
+	}
+}
+
+func TestWizard_ServePreview_MissingIndex(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+	wizard.PerformExport(t.TempDir())
+
+	if _, _, err := wizard.ServePreview("127.0.0.1:0"); err == nil {
+		t.Error("Expected ServePreview to fail without index.html")
+	}
+}
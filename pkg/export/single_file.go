@@ -0,0 +1,308 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements the single-file HTML export: the regular viewer bundle
+// (index.html, its scripts and styles, the SQLite database and JSON data)
+// folded into one portable .html, so a dashboard can be handed to someone and
+// opened straight from disk (file://) with no server and no network access.
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SingleFileOptions configures single-file HTML generation.
+type SingleFileOptions struct {
+	Issues []model.Issue
+	Stats  *analysis.GraphStats // Optional; computed from Issues when nil
+	Title  string
+}
+
+// singleFileRuntimeAssets are the viewer assets loaded at runtime (by fetch,
+// a script element or a dynamic import) rather than from index.html. They
+// are served from the embedded file map instead of inlined as elements.
+var singleFileRuntimeAssets = []string{
+	"graph.js",
+	"vendor/sql-wasm.js",
+	"vendor/sql-wasm.wasm",
+	"vendor/bv_graph.js",
+	"vendor/bv_graph_bg.wasm",
+}
+
+// singleFileRewrites point the runtime loads in the viewer scripts at the
+// embedded file map, keyed by asset path. readViewerAsset applies them.
+var singleFileRewrites = map[string][][2]string{
+	"viewer.js": {
+		{"script.src = `${ASSET_ROOT}vendor/sql-wasm.js`;", `script.src = __bvAsset('vendor/sql-wasm.js');`},
+		{`import('./vendor/bv_graph.js')`, `import(__bvAsset('vendor/bv_graph.js'))`},
+		{`import('./graph.js')`, `import(__bvAsset('graph.js'))`},
+	},
+	"vendor/bv_graph.js": {
+		{`new URL('bv_graph_bg.wasm', import.meta.url)`, `__bvAsset('vendor/bv_graph_bg.wasm')`},
+	},
+}
+
+// singleFileCSP widens the viewer's Content-Security-Policy for the blob and
+// data URLs the inlined page loads from.
+var singleFileCSP = [][2]string{
+	{`script-src 'self' 'unsafe-eval' 'unsafe-inline';`, `script-src 'self' 'unsafe-eval' 'unsafe-inline' blob:;`},
+	{`font-src 'self';`, `font-src 'self' data:;`},
+	{`connect-src 'self';`, `connect-src 'self' blob: data:;`},
+}
+
+var (
+	scriptSrcPattern  = regexp.MustCompile(`<script([^>]*?)\ssrc="([^"]+)"([^>]*)></script>`)
+	stylesheetPattern = regexp.MustCompile(`<link rel="stylesheet" href="([^"]+)">`)
+	cssURLPattern     = regexp.MustCompile(`url\((['"]?)([^'")]+)(['"]?)\)`)
+	scriptEndPattern  = regexp.MustCompile(`(?i)</(script)`)
+)
+
+// singleFileShim serves the embedded file map: fetch() of a mapped path
+// answers from the map, and __bvAsset turns a mapped path into a blob URL for
+// script elements and dynamic imports. Unmapped requests fall through.
+const singleFileShim = `<script>
+(function() {
+  var files = {{FILES}};
+  var urls = {};
+  function key(p) { return String(p).replace(/^\.\//, ''); }
+  function bytes(f) {
+    var bin = atob(f.data), out = new Uint8Array(bin.length);
+    for (var i = 0; i < bin.length; i++) out[i] = bin.charCodeAt(i);
+    return out;
+  }
+  window.__bvAsset = function(p) {
+    var k = key(p), f = files[k];
+    if (!f) return p;
+    if (!urls[k]) urls[k] = URL.createObjectURL(new Blob([bytes(f)], { type: f.type }));
+    return urls[k];
+  };
+  var nativeFetch = window.fetch.bind(window);
+  window.fetch = function(input, init) {
+    var f = files[key(input instanceof Request ? input.url : input)];
+    if (!f) return nativeFetch(input, init);
+    return Promise.resolve(new Response(bytes(f), { status: 200, headers: { 'Content-Type': f.type } }));
+  };
+})();
+</script>
+`
+
+// singleFileEntry is one file in the embedded map.
+type singleFileEntry struct {
+	Type string `json:"type"`
+	Data string `json:"data"` // base64
+}
+
+// GenerateSingleFileHTML renders the viewer for the issues as one
+// self-contained HTML page: scripts and styles are inlined, fonts become data
+// URLs, and the database, JSON data and runtime-loaded assets are embedded as
+// a file map. The page makes no external requests.
+func GenerateSingleFileHTML(opts SingleFileOptions) ([]byte, error) {
+	files, err := exportDataFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range singleFileRuntimeAssets {
+		content, err := readViewerAsset(name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = newSingleFileEntry(name, content)
+	}
+
+	index, err := readViewerAsset("index.html")
+	if err != nil {
+		return nil, err
+	}
+	page := replaceTitle(string(index), opts.Title)
+	for _, r := range singleFileCSP {
+		page = strings.Replace(page, r[0], r[1], 1)
+	}
+
+	page, err = inlineStylesheets(page)
+	if err != nil {
+		return nil, err
+	}
+	page, err = inlineScripts(page)
+	if err != nil {
+		return nil, err
+	}
+	return injectFileMap(page, files, "")
+}
+
+// exportDataFiles exports the issues' database and JSON data as they would
+// appear in a bundle, keyed by bundle-relative path.
+func exportDataFiles(opts SingleFileOptions) (map[string]singleFileEntry, error) {
+	stats := opts.Stats
+	if stats == nil {
+		stats = analysis.NewAnalyzer(opts.Issues).AnalyzeAsync(context.Background())
+		stats.WaitForPhase2()
+	}
+	triage := analysis.ComputeTriage(opts.Issues)
+
+	dataDir, err := os.MkdirTemp("", "bv-single-file-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	exporter := newBundleExporter(opts.Issues, stats, &triage)
+	exporter.Config.Title = opts.Title
+	exporter.Config.ChunkThreshold = math.MaxInt64 // One database file, no chunks
+	if err := exporter.Export(dataDir); err != nil {
+		return nil, fmt.Errorf("export failed: %w", err)
+	}
+
+	files := make(map[string]singleFileEntry)
+	err = filepath.WalkDir(dataDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dataDir, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = newSingleFileEntry(rel, content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read exported data: %w", err)
+	}
+	return files, nil
+}
+
+// injectFileMap adds the shim serving files to the top of the page's head,
+// followed by any extra head markup, so every script sees the patched fetch.
+func injectFileMap(page string, files map[string]singleFileEntry, extra string) ([]byte, error) {
+	// json.Marshal escapes <, > and & so the map cannot end the element early.
+	filesJSON, err := json.Marshal(files)
+	if err != nil {
+		return nil, fmt.Errorf("marshal embedded files: %w", err)
+	}
+	shim := strings.Replace(singleFileShim, "{{FILES}}", string(filesJSON), 1)
+	return []byte(strings.Replace(page, "<head>\n", "<head>\n"+shim+extra, 1)), nil
+}
+
+// inlineStylesheets replaces each stylesheet link with a <style> element,
+// turning the url() references in the CSS into data URLs.
+func inlineStylesheets(page string) (string, error) {
+	var firstErr error
+	page = stylesheetPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		name := stylesheetPattern.FindStringSubmatch(tag)[1]
+		css, err := readViewerAsset(name)
+		if err != nil {
+			firstErr = err
+			return tag
+		}
+		inlined := cssURLPattern.ReplaceAllStringFunc(string(css), func(ref string) string {
+			target := cssURLPattern.FindStringSubmatch(ref)[2]
+			if strings.HasPrefix(target, "data:") || strings.HasPrefix(target, "#") {
+				return ref
+			}
+			asset := path.Join(path.Dir(name), target)
+			content, err := readViewerAsset(asset)
+			if err != nil {
+				firstErr = err
+				return ref
+			}
+			return "url('data:" + assetType(asset) + ";base64," + base64.StdEncoding.EncodeToString(content) + "')"
+		})
+		return "<style>\n" + inlined + "\n</style>"
+	})
+	return page, firstErr
+}
+
+// inlineScripts replaces each <script src> with the script's content. Deferred
+// scripts move to the end of the body, where the browser would have run them.
+func inlineScripts(page string) (string, error) {
+	var firstErr error
+	var deferred []string
+	page = scriptSrcPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := scriptSrcPattern.FindStringSubmatch(tag)
+		attrs, name := m[1]+m[3], m[2]
+		content, err := readViewerAsset(name)
+		if err != nil {
+			firstErr = err
+			return tag
+		}
+		// Keep the script text from ending its element or entering the
+		// HTML comment-escaped state.
+		script := scriptEndPattern.ReplaceAllString(string(content), `<\/$1`)
+		script = strings.ReplaceAll(script, "<!--", `<\!--`)
+
+		if strings.Contains(attrs, " defer") {
+			attrs = strings.Replace(attrs, " defer", "", 1)
+			deferred = append(deferred, "<script"+attrs+">\n"+script+"\n</script>")
+			return ""
+		}
+		return "<script" + attrs + ">\n" + script + "\n</script>"
+	})
+	// The last </body> is the page's own; inlined scripts may contain others.
+	if end := strings.LastIndex(page, "</body>"); end >= 0 && len(deferred) > 0 {
+		page = page[:end] + strings.Join(deferred, "\n") + "\n" + page[end:]
+	}
+	return page, firstErr
+}
+
+// readViewerAsset reads a file from the embedded viewer assets, applying the
+// single-file rewrites for runtime-loaded scripts.
+func readViewerAsset(name string) ([]byte, error) {
+	content, err := ViewerAssetsFS.ReadFile(path.Join("viewer_assets", name))
+	if err != nil {
+		return nil, fmt.Errorf("read viewer asset %s: %w", name, err)
+	}
+	if rewrites, ok := singleFileRewrites[name]; ok {
+		s := string(content)
+		for _, r := range rewrites {
+			s = strings.Replace(s, r[0], r[1], 1)
+		}
+		content = []byte(s)
+	}
+	return content, nil
+}
+
+func newSingleFileEntry(name string, content []byte) singleFileEntry {
+	return singleFileEntry{Type: assetType(name), Data: base64.StdEncoding.EncodeToString(content)}
+}
+
+// assetType is the MIME type served for an embedded file.
+func assetType(name string) string {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// WriteSingleFileHTML generates the page and writes it to path, creating
+// parent directories as needed.
+func WriteSingleFileHTML(path string, opts SingleFileOptions) error {
+	content, err := GenerateSingleFileHTML(opts)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create dir: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	_ "modernc.org/sqlite"
+)
+
+// singleFileMap extracts the embedded file map from a generated page.
+func singleFileMap(t *testing.T, page string) map[string]singleFileEntry {
+	t.Helper()
+	start := strings.Index(page, "var files = ")
+	if start < 0 {
+		t.Fatal("embedded file map not found")
+	}
+	blob := page[start+len("var files = "):]
+	blob = blob[:strings.Index(blob, ";\n")]
+	var files map[string]singleFileEntry
+	if err := json.Unmarshal([]byte(blob), &files); err != nil {
+		t.Fatalf("embedded file map is not valid JSON: %v", err)
+	}
+	return files
+}
+
+// singleFileIssueIDs returns the sorted issue IDs in a page's embedded database.
+func singleFileIssueIDs(t *testing.T, page string) []string {
+	t.Helper()
+	entry, ok := singleFileMap(t, page)["beads.sqlite3"]
+	if !ok {
+		t.Fatal("database not embedded")
+	}
+	content, err := base64.StdEncoding.DecodeString(entry.Data)
+	if err != nil {
+		t.Fatalf("database is not valid base64: %v", err)
+	}
+	dbPath := filepath.Join(t.TempDir(), "beads.sqlite3")
+	if err := os.WriteFile(dbPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id FROM issues")
+	if err != nil {
+		t.Fatalf("query embedded database: %v", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestGenerateSingleFileHTMLHasNoExternalAssets(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Root task", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-2", Title: "Sneaky </script><script>alert(1)</script>", Status: model.StatusBlocked, IssueType: model.TypeBug,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+
+	out, err := GenerateSingleFileHTML(SingleFileOptions{Issues: issues, Title: "My <Dashboard>"})
+	if err != nil {
+		t.Fatalf("GenerateSingleFileHTML failed: %v", err)
+	}
+	page := string(out)
+
+	if strings.Contains(page, "file://") {
+		t.Error("output contains a file:// reference")
+	}
+	if !strings.Contains(page, "<title>My &lt;Dashboard&gt;</title>") {
+		t.Error("title should be HTML-escaped")
+	}
+
+	// Every src/href in the markup must be a fragment or a data URL; script
+	// and style bodies are inlined code, checked separately below.
+	markup := regexp.MustCompile(`(?s)(<script[^>]*>).*?(</script>)`).ReplaceAllString(page, "$1$2")
+	markup = regexp.MustCompile(`(?s)(<style[^>]*>).*?(</style>)`).ReplaceAllString(markup, "$1$2")
+	if opens, closes := strings.Count(markup, "<script"), strings.Count(markup, "</script>"); opens != closes {
+		t.Errorf("inlined content must not close its element early: %d <script tags, %d </script> tags", opens, closes)
+	}
+	for _, m := range regexp.MustCompile(`\s(src|href)="([^"]*)"`).FindAllStringSubmatch(markup, -1) {
+		if !strings.HasPrefix(m[2], "#") && !strings.HasPrefix(m[2], "data:") {
+			t.Errorf("output references an asset: %s=%q", m[1], m[2])
+		}
+	}
+	for _, style := range regexp.MustCompile(`(?s)<style[^>]*>(.*?)</style>`).FindAllStringSubmatch(page, -1) {
+		for _, m := range cssURLPattern.FindAllStringSubmatch(style[1], -1) {
+			if !strings.HasPrefix(m[2], "#") && !strings.HasPrefix(m[2], "data:") {
+				t.Errorf("stylesheet references an asset: url(%s)", m[2])
+			}
+		}
+		if strings.Contains(style[1], "@import") {
+			t.Error("stylesheet imports another stylesheet")
+		}
+	}
+
+	// Runtime loads must resolve from the embedded map, and the map must
+	// carry the database.
+	files := singleFileMap(t, page)
+	for _, m := range regexp.MustCompile(`__bvAsset\('([^']+)'\)`).FindAllStringSubmatch(page, -1) {
+		if _, ok := files[m[1]]; !ok {
+			t.Errorf("%s is loaded at runtime but not embedded", m[1])
+		}
+	}
+	for _, r := range singleFileRewrites["viewer.js"] {
+		if strings.Contains(page, r[0]) {
+			t.Errorf("viewer still loads %q from disk", r[0])
+		}
+	}
+	if ids := singleFileIssueIDs(t, page); len(ids) != 2 || ids[0] != "bv-1" || ids[1] != "bv-2" {
+		t.Errorf("embedded issues = %v, want [bv-1 bv-2]", ids)
+	}
+}
+
+func TestWriteSingleFileHTMLCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dash.html")
+	err := WriteSingleFileHTML(path, SingleFileOptions{
+		Issues: []model.Issue{{ID: "bv-1", Title: "Only", Status: model.StatusOpen}},
+	})
+	if err != nil {
+		t.Fatalf("WriteSingleFileHTML failed: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("file not written: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "<!DOCTYPE html>") {
+		t.Error("expected an HTML document")
+	}
+}
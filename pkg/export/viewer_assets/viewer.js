@@ -332,6 +332,12 @@ if (typeof window !== 'undefined') {
   });
 }
 
+/**
+ * Root of the viewer's own assets, relative to the page. Pages served from a
+ * subdirectory of the bundle (the per-epic pages) set window.BV_ASSET_ROOT.
+ */
+const ASSET_ROOT = (typeof window !== 'undefined' && window.BV_ASSET_ROOT) || './';
+
 /**
  * Initialize sql.js library
  */
@@ -349,7 +355,7 @@ async function initSqlJs() {
       let sqlJs;
       try {
         const script = document.createElement('script');
-        script.src = './vendor/sql-wasm.js';
+        script.src = `${ASSET_ROOT}vendor/sql-wasm.js`;
         document.head.appendChild(script);
         await new Promise((res, rej) => {
           script.onload = res;
@@ -374,7 +380,7 @@ async function initSqlJs() {
         locateFile: file => {
           // Prefer local vendored wasm when available for offline use
           if (usedLocal) {
-            return `./vendor/${file}`;
+            return `${ASSET_ROOT}vendor/${file}`;
           }
           return `https://cdn.jsdelivr.net/npm/sql.js@1.10.3/dist/${file}`;
         }
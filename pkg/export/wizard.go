@@ -7,37 +7,54 @@ package export
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/huh"
 	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
 )
 
 // WizardConfig holds configuration for the deployment wizard.
 type WizardConfig struct {
 	// Export options
-	IncludeClosed  bool   `json:"include_closed"`
-	IncludeHistory bool   `json:"include_history"`
-	Title          string `json:"title"`
-	Subtitle       string `json:"subtitle,omitempty"`
+	IncludeClosed  bool   `json:"include_closed" yaml:"include_closed"` // Kept in sync with IncludeStatuses; see SetIncludeClosed
+	IncludeHistory bool   `json:"include_history" yaml:"include_history"`
+	Title          string `json:"title" yaml:"title"`
+	Subtitle       string `json:"subtitle,omitempty" yaml:"subtitle,omitempty"`
+	SplitByEpic    bool   `json:"split_by_epic,omitempty" yaml:"split_by_epic,omitempty"` // Also write a page per top-level epic
+
+	// IncludeStatuses, when non-empty, lists the statuses to publish (see StatusIncluded)
+	IncludeStatuses []model.Status `json:"include_statuses,omitempty" yaml:"include_statuses,omitempty"`
+
+	// Exclusions keep sensitive issues off the dashboard regardless of status
+	ExcludeLabels []string `json:"exclude_labels,omitempty" yaml:"exclude_labels,omitempty"` // Drop issues carrying any of these labels
+	ExcludeIDs    []string `json:"exclude_ids,omitempty" yaml:"exclude_ids,omitempty"`       // Drop these issues by ID
 
 	// Deployment target
-	DeployTarget string `json:"deploy_target"` // "github", "cloudflare", "local"
+	DeployTarget string `json:"deploy_target" yaml:"deploy_target"` // "github", "cloudflare", "local", "single-file"
 
 	// GitHub options
-	RepoName        string `json:"repo_name,omitempty"`
-	RepoPrivate     bool   `json:"repo_private,omitempty"`
-	RepoDescription string `json:"repo_description,omitempty"`
+	RepoName        string `json:"repo_name,omitempty" yaml:"repo_name,omitempty"`
+	RepoOrg         string `json:"repo_org,omitempty" yaml:"repo_org,omitempty"` // Optional organization owner
+	RepoPrivate     bool   `json:"repo_private,omitempty" yaml:"repo_private,omitempty"`
+	RepoDescription string `json:"repo_description,omitempty" yaml:"repo_description,omitempty"`
 
 	// Cloudflare options
-	CloudflareProject string `json:"cloudflare_project,omitempty"`
-	CloudflareBranch  string `json:"cloudflare_branch,omitempty"`
+	CloudflareProject string `json:"cloudflare_project,omitempty" yaml:"cloudflare_project,omitempty"`
+	CloudflareBranch  string `json:"cloudflare_branch,omitempty" yaml:"cloudflare_branch,omitempty"`
+
+	// Output path for bundle (or the .html file for "single-file")
+	OutputPath string `json:"output_path,omitempty" yaml:"output_path,omitempty"`
 
-	// Output path for bundle
-	OutputPath string `json:"output_path,omitempty"`
+	// DryRun makes PerformDeploy print the commands it would run instead
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
 }
 
 // WizardResult contains the result of running the wizard.
@@ -46,17 +63,46 @@ type WizardResult struct {
 	RepoFullName string
 	PagesURL     string
 	DeployTarget string
+	// CommitSHA is the bundle commit pushed by git-based targets
+	CommitSHA string
+	// PagesAlreadyEnabled is set when GitHub Pages already served the
+	// deployed branch, so its settings were left unchanged
+	PagesAlreadyEnabled bool
 	// Cloudflare-specific
 	CloudflareProject string
 	CloudflareURL     string
+	// PublishedCount is the number of issues written to the bundle (see manifest.json)
+	PublishedCount int
+	// DryRun is set when nothing was deployed; Commands lists what would have run
+	DryRun   bool
+	Commands []string
 }
 
 // Wizard handles the interactive deployment flow.
 type Wizard struct {
+	// BrowserOpener opens the preview URL during OfferPreview. NewWizard sets
+	// it to OpenInBrowser; nil disables opening a browser (headless servers).
+	BrowserOpener func(url string) error
+	// BrowserOpenDelay gives the preview server time to start before the
+	// browser opens. With no delay the opener runs synchronously.
+	BrowserOpenDelay time.Duration
+	// DryRun makes PerformDeploy only report what it would do; so does
+	// WizardConfig.DryRun.
+	DryRun bool
+	// OnStep, if set, receives PerformDeploy's progress for the github and
+	// cloudflare targets as (stage, status) pairs, e.g. ("push", "running").
+	OnStep func(stage, status string)
+
 	config     *WizardConfig
 	beadsPath  string
 	bundlePath string
 	isUpdate   bool // true when updating an existing deployment
+
+	publishedCount int                                 // issues recorded by WriteManifest
+	epicPages      []EpicPage                          // pages recorded by WriteEpicPages
+	deployLogPath  string                              // deploy audit log; empty means DeployLogPath()
+	assetCopier    func(outputDir, title string) error // viewer asset copy; nil means CopyEmbeddedAssets
+	nonInteractive bool                                // set by RunWithConfig; prompts become errors
 }
 
 // NewWizard creates a new deployment wizard.
@@ -66,7 +112,9 @@ func NewWizard(beadsPath string) *Wizard {
 			IncludeClosed:  true, // Include all issues by default
 			IncludeHistory: true, // Include git history by default
 		},
-		beadsPath: beadsPath,
+		beadsPath:        beadsPath,
+		BrowserOpener:    OpenInBrowser,
+		BrowserOpenDelay: 500 * time.Millisecond,
 	}
 }
 
@@ -92,7 +140,7 @@ func (w *Wizard) offerSavedConfig(saved *WizardConfig) (bool, error) {
 	switch saved.DeployTarget {
 	case "github":
 		fmt.Printf("  Target:     GitHub Pages\n")
-		fmt.Printf("  Repository: %s\n", saved.RepoName)
+		fmt.Printf("  Repository: %s\n", QualifyRepoName(saved.RepoOrg, saved.RepoName))
 		if saved.Title != "" {
 			fmt.Printf("  Title:      %s\n", saved.Title)
 		}
@@ -105,6 +153,20 @@ func (w *Wizard) offerSavedConfig(saved *WizardConfig) (bool, error) {
 	case "local":
 		fmt.Printf("  Target: Local export\n")
 		fmt.Printf("  Path:   %s\n", saved.OutputPath)
+	case "single-file":
+		fmt.Printf("  Target: Single HTML file\n")
+		fmt.Printf("  Path:   %s\n", saved.OutputPath)
+	}
+	if history, err := w.DeployHistory(); err == nil && len(history) > 0 {
+		last := history[len(history)-1]
+		outcome := "succeeded"
+		if !last.Success {
+			outcome = "failed"
+		}
+		fmt.Printf("  Last deploy: %s (%s)\n", formatDeployAge(last.Timestamp, time.Now()), outcome)
+	}
+	if len(saved.ExcludeLabels) > 0 || len(saved.ExcludeIDs) > 0 {
+		fmt.Printf("  Excluding: labels [%s], %d issue ID(s)\n", strings.Join(saved.ExcludeLabels, ", "), len(saved.ExcludeIDs))
 	}
 	fmt.Println("")
 
@@ -205,6 +267,69 @@ func (w *Wizard) Run() (*WizardResult, error) {
 	}, nil
 }
 
+// RunWithConfig runs the wizard without prompts, for CI and release
+// pipelines. cfg replaces the collected configuration (see
+// LoadWizardConfigFile) and must carry every field its DeployTarget needs.
+// Prerequisite checks still run but fail instead of offering to install or
+// authenticate, and OfferPreview deploys without previewing.
+func (w *Wizard) RunWithConfig(cfg WizardConfig) (*WizardResult, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.DeployTarget == "cloudflare" && cfg.CloudflareBranch == "" {
+		cfg.CloudflareBranch = "main"
+	}
+	if cfg.DeployTarget == "single-file" && !strings.HasSuffix(strings.ToLower(cfg.OutputPath), ".html") {
+		cfg.OutputPath += ".html"
+	}
+	cfg.syncIncludeClosed()
+	w.config = &cfg
+	w.nonInteractive = true
+
+	w.printBanner()
+
+	if cfg.DeployTarget == "github" && cfg.RepoOrg != "" {
+		if err := CheckOrgAccess(cfg.RepoOrg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.checkPrerequisites(); err != nil {
+		return nil, err
+	}
+
+	return &WizardResult{
+		DeployTarget: w.config.DeployTarget,
+	}, nil
+}
+
+// Validate reports the first field the DeployTarget requires that is missing,
+// so a non-interactive run fails up front instead of waiting on a prompt.
+func (c WizardConfig) Validate() error {
+	switch c.DeployTarget {
+	case "":
+		return fmt.Errorf("wizard config: deploy_target is required")
+	case "github":
+		if strings.TrimSpace(c.RepoName) == "" {
+			return fmt.Errorf("wizard config: repo_name is required for deploy_target github")
+		}
+		if err := validateRepoName(c.RepoName); err != nil {
+			return fmt.Errorf("wizard config: %w", err)
+		}
+	case "cloudflare":
+		if strings.TrimSpace(c.CloudflareProject) == "" {
+			return fmt.Errorf("wizard config: cloudflare_project is required for deploy_target cloudflare")
+		}
+	case "local", "single-file":
+		if strings.TrimSpace(c.OutputPath) == "" {
+			return fmt.Errorf("wizard config: output_path is required for deploy_target %s", c.DeployTarget)
+		}
+	default:
+		return fmt.Errorf("wizard config: unknown deploy_target %q", c.DeployTarget)
+	}
+	return nil
+}
+
 // GetConfig returns the collected wizard configuration.
 func (w *Wizard) GetConfig() *WizardConfig {
 	return w.config
@@ -232,17 +357,39 @@ func (w *Wizard) collectExportOptions() error {
 	// Default title
 	defaultTitle := "Project Issues"
 	title := defaultTitle
+	excludeLabels := strings.Join(w.config.ExcludeLabels, ", ")
+	excludeIDs := strings.Join(w.config.ExcludeIDs, ", ")
+
+	var statuses []model.Status
+	statusOptions := make([]huh.Option[model.Status], 0, len(exportStatuses))
+	for _, s := range exportStatuses {
+		statusOptions = append(statusOptions, huh.NewOption(s.label, s.status))
+		if w.config.StatusIncluded(s.status) {
+			statuses = append(statuses, s.status)
+		}
+	}
 
 	form := newForm(
 		huh.NewGroup(
-			huh.NewConfirm().
-				Title("Include closed issues?").
-				Description("Export both open and closed issues").
-				Value(&w.config.IncludeClosed),
+			huh.NewMultiSelect[model.Status]().
+				Title("Which issues should be published?").
+				Description("Select the statuses to include").
+				Options(statusOptions...).
+				Value(&statuses).
+				Validate(func(selected []model.Status) error {
+					if len(selected) == 0 {
+						return fmt.Errorf("select at least one status")
+					}
+					return nil
+				}),
 			huh.NewConfirm().
 				Title("Include git history?").
 				Description("Export git commit history for each issue").
 				Value(&w.config.IncludeHistory),
+			huh.NewConfirm().
+				Title("Generate a page per epic?").
+				Description("Adds epics/index.html linking to one page per top-level epic").
+				Value(&w.config.SplitByEpic),
 			huh.NewInput().
 				Title("Site title").
 				Value(&title).
@@ -251,6 +398,16 @@ func (w *Wizard) collectExportOptions() error {
 				Title("Site subtitle (optional)").
 				Value(&w.config.Subtitle).
 				Placeholder(""),
+			huh.NewInput().
+				Title("Exclude labels (optional)").
+				Description("Comma-separated; issues with any of these labels are never published").
+				Value(&excludeLabels).
+				Placeholder("security, internal"),
+			huh.NewInput().
+				Title("Exclude issue IDs (optional)").
+				Description("Comma-separated issue IDs to leave out").
+				Value(&excludeIDs).
+				Placeholder(""),
 		),
 	)
 
@@ -263,11 +420,38 @@ func (w *Wizard) collectExportOptions() error {
 	} else {
 		w.config.Title = defaultTitle
 	}
+	w.config.IncludeStatuses = statuses
+	w.config.syncIncludeClosed()
+	w.config.ExcludeLabels = splitCommaList(excludeLabels)
+	w.config.ExcludeIDs = splitCommaList(excludeIDs)
 
 	fmt.Println("")
 	return nil
 }
 
+// exportStatuses are the statuses offered by the wizard's status prompt.
+var exportStatuses = []struct {
+	status model.Status
+	label  string
+}{
+	{model.StatusOpen, "Open"},
+	{model.StatusInProgress, "In progress"},
+	{model.StatusBlocked, "Blocked"},
+	{model.StatusClosed, "Closed"},
+	{model.StatusTombstone, "Archived (tombstoned)"},
+}
+
+// splitCommaList splits a comma-separated answer into trimmed, non-empty items.
+func splitCommaList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func (w *Wizard) collectDeployTarget() error {
 	fmt.Println("Step 2: Deployment Target")
 	fmt.Println("────────────────────────────")
@@ -280,6 +464,7 @@ func (w *Wizard) collectDeployTarget() error {
 					huh.NewOption("GitHub Pages (create/update repository)", "github"),
 					huh.NewOption("Cloudflare Pages (requires wrangler CLI)", "cloudflare"),
 					huh.NewOption("Export locally only", "local"),
+					huh.NewOption("Single self-contained HTML file (no server needed)", "single-file"),
 				).
 				Value(&w.config.DeployTarget),
 		),
@@ -301,6 +486,8 @@ func (w *Wizard) collectTargetConfig() error {
 		return w.collectCloudflareConfig()
 	case "local":
 		return w.collectLocalConfig()
+	case "single-file":
+		return w.collectSingleFileConfig()
 	}
 	return nil
 }
@@ -317,6 +504,7 @@ func (w *Wizard) collectGitHubConfig() error {
 	}
 	suggestedName := base + "-pages"
 	repoName := suggestedName
+	org := w.config.RepoOrg
 	description := "Issue tracker dashboard"
 
 	form := newForm(
@@ -324,7 +512,17 @@ func (w *Wizard) collectGitHubConfig() error {
 			huh.NewInput().
 				Title("Repository name").
 				Value(&repoName).
-				Placeholder(suggestedName),
+				Placeholder(suggestedName).
+				Validate(func(name string) error {
+					if name == "" {
+						name = suggestedName
+					}
+					return validateRepoName(name)
+				}),
+			huh.NewInput().
+				Title("Organization (optional)").
+				Description("Leave blank to create the repository under your personal account").
+				Value(&org),
 			huh.NewConfirm().
 				Title("Make repository private?").
 				Value(&w.config.RepoPrivate),
@@ -344,6 +542,13 @@ func (w *Wizard) collectGitHubConfig() error {
 	} else {
 		w.config.RepoName = suggestedName
 	}
+	w.config.RepoOrg = strings.TrimSpace(org)
+	if w.config.RepoOrg != "" {
+		if err := CheckOrgAccess(w.config.RepoOrg); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Access to organization %s confirmed\n", w.config.RepoOrg)
+	}
 	w.config.RepoDescription = description
 
 	fmt.Println("")
@@ -431,6 +636,38 @@ func (w *Wizard) collectLocalConfig() error {
 	return nil
 }
 
+func (w *Wizard) collectSingleFileConfig() error {
+	fmt.Println("Step 3: Single-File Export Configuration")
+	fmt.Println("────────────────────────────")
+
+	defaultPath := "./bv-dashboard.html"
+	outputPath := defaultPath
+
+	form := newForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Output file").
+				Value(&outputPath).
+				Placeholder(defaultPath),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		outputPath = defaultPath
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".html") {
+		outputPath += ".html"
+	}
+	w.config.OutputPath = outputPath
+
+	fmt.Println("")
+	return nil
+}
+
 func (w *Wizard) checkPrerequisites() error {
 	fmt.Println("Step 4: Prerequisites Check")
 	fmt.Println("────────────────────────────")
@@ -454,6 +691,9 @@ func (w *Wizard) checkPrerequisites() error {
 		if !status.Authenticated {
 			fmt.Println("✗ gh CLI not authenticated")
 			fmt.Println("")
+			if w.nonInteractive {
+				return fmt.Errorf("GitHub authentication required (run 'gh auth login')")
+			}
 
 			var doAuth bool
 			form := newForm(
@@ -510,6 +750,9 @@ func (w *Wizard) checkPrerequisites() error {
 				return fmt.Errorf("npm is required to install wrangler CLI")
 			}
 			ShowWranglerInstallInstructions()
+			if w.nonInteractive {
+				return fmt.Errorf("wrangler CLI is required for Cloudflare Pages deployment")
+			}
 
 			var doInstall bool
 			form := newForm(
@@ -545,6 +788,9 @@ func (w *Wizard) checkPrerequisites() error {
 		if !status.Authenticated {
 			fmt.Println("✗ wrangler not authenticated")
 			fmt.Println("")
+			if w.nonInteractive {
+				return fmt.Errorf("cloudflare authentication required (run 'wrangler login')")
+			}
 
 			var doAuth bool
 			form := newForm(
@@ -600,6 +846,11 @@ func (w *Wizard) PerformExport(bundlePath string) error {
 func (w *Wizard) OfferPreview() (string, error) {
 	fmt.Println("Step 6: Preview")
 	fmt.Println("────────────────────────────")
+	if w.nonInteractive {
+		fmt.Println("Skipping preview (non-interactive)")
+		fmt.Println("")
+		return "deploy", nil
+	}
 
 	var doPreview bool = true
 	form := newForm(
@@ -626,24 +877,13 @@ func (w *Wizard) OfferPreview() (string, error) {
 	fmt.Println("")
 
 	// Start preview server
-	port, err := FindAvailablePort(PreviewPortRangeStart, PreviewPortRangeEnd)
+	stop, url, err := w.ServePreview("")
 	if err != nil {
-		return "", fmt.Errorf("could not find available port: %w", err)
+		return "", err
 	}
+	defer stop()
 
-	server := NewPreviewServer(w.bundlePath, port)
-
-	// Open browser
-	go func() {
-		time.Sleep(500 * time.Millisecond)
-		url := server.URL()
-		OpenInBrowser(url)
-	}()
-
-	// Start server in goroutine
-	go func() {
-		server.Start()
-	}()
+	w.openPreview(url)
 
 	// Wait for user to press enter with a simple huh form
 	var cont bool = true // Default to continue after preview
@@ -659,13 +899,9 @@ func (w *Wizard) OfferPreview() (string, error) {
 	)
 
 	if err := waitForm.Run(); err != nil {
-		server.Stop()
 		return "", err
 	}
 
-	// Stop server
-	server.Stop()
-
 	if !cont {
 		return "cancel", nil
 	}
@@ -674,25 +910,178 @@ func (w *Wizard) OfferPreview() (string, error) {
 	return "deploy", nil
 }
 
-// PerformDeploy deploys the bundle to the configured target.
+// ServePreview serves the bundle over HTTP, as it will be served once
+// deployed, until stop is called. An empty addr picks a free port in the
+// preview range on 127.0.0.1. The listener is bound before ServePreview
+// returns, so url can be opened right away.
+func (w *Wizard) ServePreview(addr string) (stop func(), url string, err error) {
+	if _, err := os.Stat(filepath.Join(w.bundlePath, "index.html")); err != nil {
+		return nil, "", fmt.Errorf("no index.html found in bundle: %s", w.bundlePath)
+	}
+
+	if addr == "" {
+		port, err := FindAvailablePort(PreviewPortRangeStart, PreviewPortRangeEnd)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not find available port: %w", err)
+		}
+		addr = fmt.Sprintf("127.0.0.1:%d", port)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", fmt.Errorf("preview server: %w", err)
+	}
+
+	tcpAddr := listener.Addr().(*net.TCPAddr)
+	host := tcpAddr.IP.String()
+	if tcpAddr.IP.IsUnspecified() {
+		host = "127.0.0.1"
+	}
+
+	preview := NewPreviewServer(w.bundlePath, tcpAddr.Port)
+	preview.server = &http.Server{Handler: preview.handler()}
+	go preview.server.Serve(listener)
+
+	stop = func() { preview.Stop() }
+	return stop, "http://" + net.JoinHostPort(host, strconv.Itoa(tcpAddr.Port)), nil
+}
+
+// openPreview opens url with BrowserOpener after BrowserOpenDelay.
+func (w *Wizard) openPreview(url string) {
+	if w.BrowserOpener == nil {
+		fmt.Printf("Open %s in your browser\n", url)
+		return
+	}
+	open := func() {
+		if err := w.BrowserOpener(url); err != nil {
+			fmt.Printf("Could not open browser: %v\n", err)
+			fmt.Printf("Open %s in your browser\n", url)
+		}
+	}
+	if w.BrowserOpenDelay <= 0 {
+		open()
+		return
+	}
+	go func() {
+		time.Sleep(w.BrowserOpenDelay)
+		open()
+	}()
+}
+
+// PerformDeploy deploys the bundle to the configured target and appends the
+// outcome to the deploy audit log (see DeployHistory).
 func (w *Wizard) PerformDeploy() (*WizardResult, error) {
+	if w.DryRun || w.config.DryRun {
+		return w.dryRunDeploy(), nil
+	}
+	result, err := w.performDeploy()
+	w.recordDeploy(result, err)
+	return result, err
+}
+
+// CancelDeploy records a deploy the user declined after the preview and
+// returns the result for the bundle left on disk.
+func (w *Wizard) CancelDeploy() *WizardResult {
+	result := &WizardResult{
+		BundlePath:     w.bundlePath,
+		DeployTarget:   "local",
+		PublishedCount: w.publishedCount,
+	}
+	w.recordDeploy(result, fmt.Errorf("deploy cancelled after preview"))
+	return result
+}
+
+// dryRunBundlePlaceholder stands in for the bundle directory in dry-run
+// commands when a real run would build it in a temp directory.
+const dryRunBundlePlaceholder = "<bundle-dir>"
+
+// dryRunDeploy prints the commands PerformDeploy would run for the configured
+// target without running them. Dry runs are not recorded in the deploy log.
+func (w *Wizard) dryRunDeploy() *WizardResult {
+	fmt.Println("Step 7: Deploy (dry run)")
+	fmt.Println("────────────────────────────")
+
+	// A dry run may build the bundle in a scratch directory that is gone by
+	// the time anyone reads the commands, so they name where a real run puts
+	// it: the output path, or a fresh temp directory when there is none.
+	bundlePath := w.config.OutputPath
+	if bundlePath == "" {
+		bundlePath = dryRunBundlePlaceholder
+	}
+
+	result := &WizardResult{
+		BundlePath:     bundlePath,
+		DeployTarget:   w.config.DeployTarget,
+		PublishedCount: w.publishedCount,
+		DryRun:         true,
+	}
+
+	switch w.config.DeployTarget {
+	case "github":
+		result.RepoFullName = QualifyRepoName(w.config.RepoOrg, w.config.RepoName)
+		result.Commands = gitHubDeployCommands(GitHubDeployConfig{
+			RepoName:    w.config.RepoName,
+			Org:         w.config.RepoOrg,
+			Private:     w.config.RepoPrivate,
+			Description: w.config.RepoDescription,
+			BundlePath:  bundlePath,
+		})
+	case "cloudflare":
+		result.CloudflareProject = w.config.CloudflareProject
+		result.Commands = cloudflareDeployCommands(CloudflareDeployConfig{
+			ProjectName: w.config.CloudflareProject,
+			BundlePath:  bundlePath,
+			Branch:      w.config.CloudflareBranch,
+		})
+	case "local":
+		fmt.Printf("Bundle would be exported to: %s\n", bundlePath)
+	case "single-file":
+		fmt.Printf("Dashboard would be written to: %s\n", bundlePath)
+	}
+
+	if len(result.Commands) > 0 {
+		fmt.Println("Would run:")
+		for _, cmd := range result.Commands {
+			fmt.Printf("  $ %s\n", cmd)
+		}
+	}
+	fmt.Println("")
+	return result
+}
+
+// commandLine formats a command for display, quoting arguments the shell
+// would split or expand.
+func commandLine(name string, args ...string) string {
+	parts := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{name}, args...) {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (w *Wizard) performDeploy() (*WizardResult, error) {
 	fmt.Println("Step 7: Deploy")
 	fmt.Println("────────────────────────────")
 
 	result := &WizardResult{
-		BundlePath:   w.bundlePath,
-		DeployTarget: w.config.DeployTarget,
+		BundlePath:     w.bundlePath,
+		DeployTarget:   w.config.DeployTarget,
+		PublishedCount: w.publishedCount,
 	}
 
 	switch w.config.DeployTarget {
 	case "github":
 		deployConfig := GitHubDeployConfig{
 			RepoName:         w.config.RepoName,
+			Org:              w.config.RepoOrg,
 			Private:          w.config.RepoPrivate,
 			Description:      w.config.RepoDescription,
 			BundlePath:       w.bundlePath,
 			SkipConfirmation: true,           // Already confirmed in wizard prerequisites
 			ForceOverwrite:   w.isUpdate,     // Auto-overwrite when updating existing deployment
+			OnStep:           w.OnStep,
 		}
 
 		deployResult, err := DeployToGitHubPages(deployConfig)
@@ -702,6 +1091,8 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 
 		result.RepoFullName = deployResult.RepoFullName
 		result.PagesURL = deployResult.PagesURL
+		result.CommitSHA = deployResult.CommitSHA
+		result.PagesAlreadyEnabled = deployResult.PagesAlreadyEnabled
 
 	case "cloudflare":
 		deployConfig := CloudflareDeployConfig{
@@ -709,6 +1100,7 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 			BundlePath:       w.bundlePath,
 			Branch:           w.config.CloudflareBranch,
 			SkipConfirmation: true, // Already confirmed in prerequisites
+			OnStep:           w.OnStep,
 		}
 
 		deployResult, err := DeployToCloudflarePages(deployConfig)
@@ -723,6 +1115,9 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 	case "local":
 		fmt.Printf("Bundle exported to: %s\n", w.bundlePath)
 		result.BundlePath = w.bundlePath
+
+	case "single-file":
+		fmt.Printf("Dashboard written to: %s\n", w.bundlePath)
 	}
 
 	return result, nil
@@ -732,24 +1127,39 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 func (w *Wizard) PrintSuccess(result *WizardResult) {
 	// Build content lines first to calculate required width
 	var lines []string
-	lines = append(lines, "Deployment Complete!")
-
-	switch result.DeployTarget {
-	case "github":
-		lines = append(lines, "Repository: https://github.com/"+result.RepoFullName)
-		lines = append(lines, "Live site:  "+result.PagesURL)
-		lines = append(lines, "")
-		lines = append(lines, "Note: GitHub Pages may take 1-2 minutes to become available")
-	case "cloudflare":
-		lines = append(lines, "Project:    "+result.CloudflareProject)
-		lines = append(lines, "Live site:  "+result.CloudflareURL)
-		lines = append(lines, "")
-		lines = append(lines, "Cloudflare Pages deploys are typically available immediately")
-	case "local":
+	if result.DryRun {
+		lines = append(lines, "Dry Run - Nothing Deployed")
+		lines = append(lines, "Target: "+result.DeployTarget)
 		lines = append(lines, "Bundle: "+result.BundlePath)
 		lines = append(lines, "")
-		lines = append(lines, "To preview:")
-		lines = append(lines, "  bv --preview-pages "+result.BundlePath)
+		lines = append(lines, "Run again without dry run to deploy")
+	} else {
+		lines = append(lines, "Deployment Complete!")
+
+		switch result.DeployTarget {
+		case "github":
+			lines = append(lines, "Repository: https://github.com/"+result.RepoFullName)
+			lines = append(lines, "Live site:  "+result.PagesURL)
+			if result.PagesAlreadyEnabled {
+				lines = append(lines, "Pages was already enabled; settings left unchanged")
+			}
+			lines = append(lines, "")
+			lines = append(lines, "Note: GitHub Pages may take 1-2 minutes to become available")
+		case "cloudflare":
+			lines = append(lines, "Project:    "+result.CloudflareProject)
+			lines = append(lines, "Live site:  "+result.CloudflareURL)
+			lines = append(lines, "")
+			lines = append(lines, "Cloudflare Pages deploys are typically available immediately")
+		case "local":
+			lines = append(lines, "Bundle: "+result.BundlePath)
+			lines = append(lines, "")
+			lines = append(lines, "To preview:")
+			lines = append(lines, "  bv --preview-pages "+result.BundlePath)
+		case "single-file":
+			lines = append(lines, "File: "+result.BundlePath)
+			lines = append(lines, "")
+			lines = append(lines, "Open it directly in a browser; no server needed")
+		}
 	}
 
 	// Calculate width: max line length + 4 (for "║  " prefix and " ║" suffix)
@@ -830,6 +1240,33 @@ func LoadWizardConfig() (*WizardConfig, error) {
 	return &config, nil
 }
 
+// LoadWizardConfigFile reads a wizard configuration for RunWithConfig from a
+// JSON file, or YAML for .yaml/.yml files. Export options missing from the
+// file keep the wizard defaults.
+func LoadWizardConfigFile(path string) (WizardConfig, error) {
+	config := WizardConfig{
+		IncludeClosed:  true,
+		IncludeHistory: true,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return WizardConfig{}, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &config)
+	default:
+		err = json.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return WizardConfig{}, fmt.Errorf("parsing wizard config %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
 // SaveWizardConfig saves wizard configuration for future runs.
 func SaveWizardConfig(config *WizardConfig) error {
 	path := WizardConfigPath()
@@ -2,6 +2,8 @@ package export
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -13,6 +15,7 @@ func TestWizard_PerformDeploy_Local(t *testing.T) {
 	wizard := NewWizard("/tmp/test")
 	wizard.config.DeployTarget = "local"
 	wizard.bundlePath = "/tmp/bundle"
+	wizard.SetDeployLogPath(filepath.Join(t.TempDir(), "deploys.jsonl"))
 
 	result, err := wizard.PerformDeploy()
 	if err != nil {
@@ -29,6 +32,81 @@ func TestWizard_PerformDeploy_Local(t *testing.T) {
 	}
 }
 
+func TestDeployLogPathDefault(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if got, want := DeployLogPath(), filepath.Join(home, ".beads_viewer", "deploys.jsonl"); got != want {
+		t.Errorf("DeployLogPath() = %q, want %q", got, want)
+	}
+}
+
+func TestWizard_PerformDeploy_AppendsDeployRecord(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "logs", "deploys.jsonl")
+	wizard := NewWizard(t.TempDir())
+	wizard.config.DeployTarget = "local"
+	wizard.bundlePath = "/tmp/bundle"
+	wizard.publishedCount = 7
+	wizard.SetDeployLogPath(logPath)
+
+	history, err := wizard.DeployHistory()
+	if err != nil || len(history) != 0 {
+		t.Fatalf("expected empty history before deploying, got %v, %v", history, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := wizard.PerformDeploy(); err != nil {
+			t.Fatalf("PerformDeploy returned error: %v", err)
+		}
+	}
+
+	history, err = wizard.DeployHistory()
+	if err != nil {
+		t.Fatalf("DeployHistory returned error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(history))
+	}
+	last := history[1]
+	if last.Target != "local" || !last.Success || last.PublishedCount != 7 || last.URL != "/tmp/bundle" {
+		t.Errorf("unexpected record: %+v", last)
+	}
+	if last.Timestamp.IsZero() {
+		t.Error("record should carry a timestamp")
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("expected 2 JSON lines, got %d", lines)
+	}
+}
+
+func TestWizard_CancelDeploy_RecordsCancellation(t *testing.T) {
+	wizard := NewWizard(t.TempDir())
+	wizard.config.DeployTarget = "github"
+	wizard.bundlePath = "/tmp/bundle"
+	wizard.publishedCount = 3
+	wizard.SetDeployLogPath(filepath.Join(t.TempDir(), "deploys.jsonl"))
+
+	result := wizard.CancelDeploy()
+	if result.DeployTarget != "local" || result.BundlePath != "/tmp/bundle" || result.PublishedCount != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	history, err := wizard.DeployHistory()
+	if err != nil {
+		t.Fatalf("DeployHistory returned error: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(history))
+	}
+	if rec := history[0]; rec.Target != "github" || rec.Success || !strings.Contains(rec.Error, "cancelled") || rec.URL != "/tmp/bundle" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+}
+
 func TestWizard_collectTargetConfig_NoTarget(t *testing.T) {
 	wizard := NewWizard("/tmp/test")
 	// Empty deploy target should return nil error
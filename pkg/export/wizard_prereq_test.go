@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -109,3 +110,32 @@ exit 0
 		t.Fatalf("checkPrerequisites returned error: %v", err)
 	}
 }
+
+func TestWizard_RunWithConfig_GitHubNotAuthedFailsWithoutPrompt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+
+	// gh stub that reports not logged in
+	ghScript := `#!/bin/sh
+case "${1-}" in
+  auth)
+    echo "You are not logged into any GitHub hosts." >&2
+    exit 1
+    ;;
+esac
+exit 0
+`
+	writeExecutable(t, binDir, "gh", ghScript)
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+
+	wizard := NewWizard("/tmp/test")
+	_, err := wizard.RunWithConfig(WizardConfig{DeployTarget: "github", RepoName: "pages"})
+	if err == nil || !strings.Contains(err.Error(), "authentication required") {
+		t.Fatalf("RunWithConfig error = %v, want authentication required", err)
+	}
+}
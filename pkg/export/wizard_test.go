@@ -1,8 +1,10 @@
 package export
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -222,6 +224,41 @@ func TestWizard_PrintSuccess_GitHub(t *testing.T) {
 	wizard.PrintSuccess(result)
 }
 
+func TestWizard_PrintSuccess_GitHubPagesAlreadyEnabled(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+	result := &WizardResult{
+		RepoFullName: "user/repo",
+		PagesURL:     "https://user.github.io/repo/",
+		DeployTarget: "github",
+	}
+
+	printed := func() string {
+		t.Helper()
+		orig := os.Stdout
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Pipe: %v", err)
+		}
+		os.Stdout = w
+		wizard.PrintSuccess(result)
+		os.Stdout = orig
+		_ = w.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+		return string(out)
+	}
+
+	if strings.Contains(printed(), "already enabled") {
+		t.Error("freshly enabled Pages should not be reported as already enabled")
+	}
+	result.PagesAlreadyEnabled = true
+	if !strings.Contains(printed(), "Pages was already enabled") {
+		t.Error("expected the success box to report that Pages was already enabled")
+	}
+}
+
 func TestWizard_PrintSuccess_Local(t *testing.T) {
 	wizard := NewWizard("/tmp/test")
 
@@ -247,3 +284,109 @@ func TestWizard_PrintSuccess_Cloudflare(t *testing.T) {
 	// Just verify it doesn't panic
 	wizard.PrintSuccess(result)
 }
+
+func TestLoadWizardConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pages.yaml": "deploy_target: local\noutput_path: ./site\ninclude_closed: false\nexclude_labels: [secret]\n",
+		"pages.json": `{"deploy_target": "local", "output_path": "./site", "include_closed": false, "exclude_labels": ["secret"]}`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+			config, err := LoadWizardConfigFile(path)
+			if err != nil {
+				t.Fatalf("LoadWizardConfigFile: %v", err)
+			}
+			if config.DeployTarget != "local" || config.OutputPath != "./site" {
+				t.Errorf("target/path = %q/%q", config.DeployTarget, config.OutputPath)
+			}
+			if config.IncludeClosed {
+				t.Error("Expected include_closed from the file to override the default")
+			}
+			if !config.IncludeHistory {
+				t.Error("Expected IncludeHistory to keep its default when absent")
+			}
+			if len(config.ExcludeLabels) != 1 || config.ExcludeLabels[0] != "secret" {
+				t.Errorf("ExcludeLabels = %v", config.ExcludeLabels)
+			}
+		})
+	}
+
+	bad := filepath.Join(dir, "bad.yml")
+	if err := os.WriteFile(bad, []byte("deploy_target: [\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadWizardConfigFile(bad); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestWizard_RunWithConfig_RequiresTargetFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		config WizardConfig
+		want   string
+	}{
+		{"no target", WizardConfig{}, "deploy_target"},
+		{"unknown target", WizardConfig{DeployTarget: "ftp"}, "unknown deploy_target"},
+		{"github", WizardConfig{DeployTarget: "github"}, "repo_name"},
+		{"github bad name", WizardConfig{DeployTarget: "github", RepoName: "my pages"}, "contains"},
+		{"cloudflare", WizardConfig{DeployTarget: "cloudflare"}, "cloudflare_project"},
+		{"local", WizardConfig{DeployTarget: "local"}, "output_path"},
+		{"single-file", WizardConfig{DeployTarget: "single-file"}, "output_path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWizard("/tmp/test").RunWithConfig(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RunWithConfig error = %v, want mention of %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWizard_RunWithConfig_SingleFile(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+	result, err := wizard.RunWithConfig(WizardConfig{DeployTarget: "single-file", OutputPath: "dash"})
+	if err != nil {
+		t.Fatalf("RunWithConfig: %v", err)
+	}
+	if result.DeployTarget != "single-file" {
+		t.Errorf("DeployTarget = %q", result.DeployTarget)
+	}
+	if got := wizard.GetConfig().OutputPath; got != "dash.html" {
+		t.Errorf("OutputPath = %q, want dash.html", got)
+	}
+	if action, err := wizard.OfferPreview(); err != nil || action != "deploy" {
+		t.Errorf("OfferPreview = %q, %v; want deploy without prompting", action, err)
+	}
+}
+
+func TestWizard_OpenPreview(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+	if wizard.BrowserOpener == nil || wizard.BrowserOpenDelay <= 0 {
+		t.Fatal("Expected NewWizard to default to a delayed OS browser opener")
+	}
+
+	var opened []string
+	wizard.BrowserOpener = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	wizard.BrowserOpenDelay = 0
+	wizard.openPreview("http://127.0.0.1:9000")
+	if len(opened) != 1 || opened[0] != "http://127.0.0.1:9000" {
+		t.Errorf("opened = %v, want the preview URL once", opened)
+	}
+
+	// A nil opener leaves the browser closed (headless servers)
+	wizard.BrowserOpener = nil
+	wizard.openPreview("http://127.0.0.1:9001")
+	if len(opened) != 1 {
+		t.Errorf("opened = %v after disabling the opener", opened)
+	}
+}
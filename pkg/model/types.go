@@ -122,8 +122,16 @@ const (
 	StatusTombstone  Status = "tombstone"
 )
 
-// IsValid returns true if the status is a recognized value
+// IsValid returns true if the status is non-empty.
+// Any non-empty status is considered valid so teams can use their own
+// workflow states (e.g., "in-review", "deferred"). The UI draws unrecognized
+// statuses with a default style unless the theme registers one.
 func (s Status) IsValid() bool {
+	return s != ""
+}
+
+// IsKnownStatus returns true if the status is one of the standard bv statuses.
+func (s Status) IsKnownStatus() bool {
 	switch s {
 	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone:
 		return true
@@ -17,7 +17,9 @@ func TestStatus_IsValid(t *testing.T) {
 		{"InProgress", StatusInProgress, true},
 		{"Blocked", StatusBlocked, true},
 		{"Closed", StatusClosed, true},
-		{"Invalid", "unknown", false},
+		// Any non-empty status is valid (custom workflow states)
+		{"Custom", "in-review", true},
+		// Only empty is invalid
 		{"Empty", "", false},
 	}
 	for _, tt := range tests {
@@ -29,6 +31,19 @@ func TestStatus_IsValid(t *testing.T) {
 	}
 }
 
+func TestStatus_IsKnownStatus(t *testing.T) {
+	for _, s := range []Status{StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone} {
+		if !s.IsKnownStatus() {
+			t.Errorf("%q should be a known status", s)
+		}
+	}
+	for _, s := range []Status{"in-review", "deferred", ""} {
+		if s.IsKnownStatus() {
+			t.Errorf("%q should not be a known status", s)
+		}
+	}
+}
+
 func TestStatus_IsClosed(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -275,15 +290,25 @@ func TestIssue_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Invalid Status",
+			name: "Empty Status",
 			issue: Issue{
 				ID:        "TEST-1",
 				Title:     "Valid Issue",
-				Status:    "invalid",
+				Status:    "",
 				IssueType: TypeBug,
 			},
 			wantErr: true,
 		},
+		{
+			name: "Custom Status Allowed",
+			issue: Issue{
+				ID:        "TEST-1",
+				Title:     "Valid Issue",
+				Status:    "in-review",
+				IssueType: TypeBug,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Empty Type",
 			issue: Issue{
@@ -3,6 +3,7 @@ package search
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -105,6 +106,62 @@ func SearchConfigFromEnv() (SearchConfig, error) {
 	return cfg, nil
 }
 
+// Per-component weight overrides read by LoadWeightsFromEnv.
+const (
+	EnvWeightText     = "BV_WEIGHT_TEXT"
+	EnvWeightPageRank = "BV_WEIGHT_PAGERANK"
+	EnvWeightStatus   = "BV_WEIGHT_STATUS"
+	EnvWeightImpact   = "BV_WEIGHT_IMPACT"
+	EnvWeightPriority = "BV_WEIGHT_PRIORITY"
+	EnvWeightRecency  = "BV_WEIGHT_RECENCY"
+)
+
+// LoadWeightsFromEnv reads scorer weights from the BV_WEIGHT_* variables.
+// Unset components keep their default preset value, and the result is
+// normalized to sum to 1.0. Values that are not finite non-negative numbers,
+// or that leave every weight at zero, are reported as errors.
+func LoadWeightsFromEnv() (Weights, error) {
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		return Weights{}, err
+	}
+
+	fields := []struct {
+		env   string
+		value *float64
+	}{
+		{EnvWeightText, &weights.TextRelevance},
+		{EnvWeightPageRank, &weights.PageRank},
+		{EnvWeightStatus, &weights.Status},
+		{EnvWeightImpact, &weights.Impact},
+		{EnvWeightPriority, &weights.Priority},
+		{EnvWeightRecency, &weights.Recency},
+	}
+	for _, field := range fields {
+		raw := strings.TrimSpace(os.Getenv(field.env))
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return Weights{}, fmt.Errorf("invalid %s: %q (expected a number)", field.env, raw)
+		}
+		if value < 0 {
+			return Weights{}, fmt.Errorf("invalid %s: %q (must be non-negative)", field.env, raw)
+		}
+		*field.value = value
+	}
+
+	if weights.sum() == 0 {
+		return Weights{}, fmt.Errorf("weights from environment are all zero")
+	}
+	weights = weights.Normalize()
+	if err := weights.Validate(); err != nil {
+		return Weights{}, err
+	}
+	return weights, nil
+}
+
 // ParseWeightsJSON parses a JSON string into Weights, enforcing required keys.
 func ParseWeightsJSON(raw string) (Weights, error) {
 	var payload map[string]float64
@@ -481,6 +481,79 @@ func TestEnvironmentVariableConstants(t *testing.T) {
 	}
 }
 
+func TestLoadWeightsFromEnv_FullSet(t *testing.T) {
+	t.Setenv(EnvWeightText, "4")
+	t.Setenv(EnvWeightPageRank, "2")
+	t.Setenv(EnvWeightStatus, "1")
+	t.Setenv(EnvWeightImpact, "1")
+	t.Setenv(EnvWeightPriority, "1")
+	t.Setenv(EnvWeightRecency, "1")
+
+	weights, err := LoadWeightsFromEnv()
+	if err != nil {
+		t.Fatalf("LoadWeightsFromEnv() error: %v", err)
+	}
+	want := Weights{TextRelevance: 0.4, PageRank: 0.2, Status: 0.1, Impact: 0.1, Priority: 0.1, Recency: 0.1}
+	for i, c := range weights.components() {
+		if diff := c.value - want.components()[i].value; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s = %f, want %f", c.name, c.value, want.components()[i].value)
+		}
+	}
+}
+
+func TestLoadWeightsFromEnv_PartialFallsBackToDefault(t *testing.T) {
+	// Unset everything but text; the rest come from the default preset.
+	for _, env := range []string{EnvWeightPageRank, EnvWeightStatus, EnvWeightImpact, EnvWeightPriority, EnvWeightRecency} {
+		t.Setenv(env, "")
+	}
+	t.Setenv(EnvWeightText, "1.6")
+
+	weights, err := LoadWeightsFromEnv()
+	if err != nil {
+		t.Fatalf("LoadWeightsFromEnv() error: %v", err)
+	}
+	if err := weights.Validate(); err != nil {
+		t.Fatalf("result should be normalized: %v", err)
+	}
+	// Default sums to 1.0 with text 0.4; raising text to 1.6 gives 1.6 / 2.2.
+	if want := 1.6 / 2.2; weights.TextRelevance < want-1e-9 || weights.TextRelevance > want+1e-9 {
+		t.Errorf("TextRelevance = %f, want %f", weights.TextRelevance, want)
+	}
+	if weights.PageRank <= 0 {
+		t.Errorf("PageRank should fall back to the default, got %f", weights.PageRank)
+	}
+}
+
+func TestLoadWeightsFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		value   string
+		wantErr string
+	}{
+		{"not a number", EnvWeightStatus, "high", EnvWeightStatus},
+		{"negative", EnvWeightImpact, "-0.2", "non-negative"},
+		{"nan", EnvWeightRecency, "NaN", EnvWeightRecency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			if _, err := LoadWeightsFromEnv(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadWeightsFromEnv() error = %v, want mention of %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("all zero", func(t *testing.T) {
+		for _, env := range []string{EnvWeightText, EnvWeightPageRank, EnvWeightStatus, EnvWeightImpact, EnvWeightPriority, EnvWeightRecency} {
+			t.Setenv(env, "0")
+		}
+		if _, err := LoadWeightsFromEnv(); err == nil {
+			t.Error("expected error when every weight is zero")
+		}
+	})
+}
+
 func TestDefaultEmbeddingDim(t *testing.T) {
 	// Verify default dim is 384 (common sentence-transformers dimension)
 	if DefaultEmbeddingDim != 384 {
@@ -0,0 +1,77 @@
+package search
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+// Grouping modes accepted by GroupResults.
+const (
+	GroupByType = "type"
+	GroupByEpic = "epic"
+)
+
+// UngroupedKey collects results that have no group: unknown issues, issues
+// without a type, or issues with no epic ancestor. Unknown modes put every
+// result here.
+const UngroupedKey = "(none)"
+
+// GroupResults buckets ranked search results by issue type ("type") or by the
+// ID of their root epic ("epic"), following parent-child links upward and
+// taking the topmost epic. Within each group, results keep their input order,
+// so a score-sorted input yields score-sorted groups.
+func GroupResults(results []HybridScore, issues []model.Issue, by string) map[string][]HybridScore {
+	groups := make(map[string][]HybridScore)
+	if len(results) == 0 {
+		return groups
+	}
+
+	issueByID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueByID[issue.ID] = issue
+	}
+
+	for _, result := range results {
+		key := UngroupedKey
+		if issue, ok := issueByID[result.IssueID]; ok {
+			switch by {
+			case GroupByType:
+				if issue.IssueType != "" {
+					key = string(issue.IssueType)
+				}
+			case GroupByEpic:
+				if epic := rootEpic(issue, issueByID); epic != "" {
+					key = epic
+				}
+			}
+		}
+		groups[key] = append(groups[key], result)
+	}
+	return groups
+}
+
+// rootEpic returns the ID of the topmost epic among issue and its parent-child
+// ancestors, or "" if there is none. Cycles in parent links are tolerated.
+func rootEpic(issue model.Issue, issueByID map[string]model.Issue) string {
+	epic := ""
+	seen := make(map[string]bool)
+	current, ok := issue, true
+	for ok && !seen[current.ID] {
+		seen[current.ID] = true
+		if current.IssueType == model.TypeEpic {
+			epic = current.ID
+		}
+		current, ok = parentOf(current, issueByID)
+	}
+	return epic
+}
+
+// parentOf returns the first existing parent referenced by a parent-child dependency.
+func parentOf(issue model.Issue, issueByID map[string]model.Issue) (model.Issue, bool) {
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.Type != model.DepParentChild {
+			continue
+		}
+		if parent, ok := issueByID[dep.DependsOnID]; ok {
+			return parent, true
+		}
+	}
+	return model.Issue{}, false
+}
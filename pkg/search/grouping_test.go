@@ -0,0 +1,59 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// childOf returns id's parent-child dependency on parent.
+func childOf(id, parent string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}
+}
+
+func TestGroupResults(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic-1", IssueType: model.TypeEpic},
+		{ID: "epic-2", IssueType: model.TypeEpic, Dependencies: childOf("epic-2", "epic-1")},
+		{ID: "bug-1", IssueType: model.TypeBug, Dependencies: childOf("bug-1", "epic-2")},
+		{ID: "task-1", IssueType: model.TypeTask, Dependencies: childOf("task-1", "epic-1")},
+		{ID: "bug-2", IssueType: model.TypeBug},
+	}
+	results := []HybridScore{
+		{IssueID: "bug-1", FinalScore: 0.9},
+		{IssueID: "task-1", FinalScore: 0.8},
+		{IssueID: "bug-2", FinalScore: 0.7},
+		{IssueID: "missing", FinalScore: 0.6},
+	}
+
+	ids := func(group []HybridScore) []string {
+		out := make([]string, len(group))
+		for i, r := range group {
+			out[i] = r.IssueID
+		}
+		return out
+	}
+
+	byType := GroupResults(results, issues, GroupByType)
+	if got := ids(byType["bug"]); !reflect.DeepEqual(got, []string{"bug-1", "bug-2"}) {
+		t.Errorf("bug group = %v, want [bug-1 bug-2] in score order", got)
+	}
+	if got := ids(byType["task"]); !reflect.DeepEqual(got, []string{"task-1"}) {
+		t.Errorf("task group = %v", got)
+	}
+	if got := ids(byType[UngroupedKey]); !reflect.DeepEqual(got, []string{"missing"}) {
+		t.Errorf("ungrouped = %v, want [missing]", got)
+	}
+
+	byEpic := GroupResults(results, issues, GroupByEpic)
+	if got := ids(byEpic["epic-1"]); !reflect.DeepEqual(got, []string{"bug-1", "task-1"}) {
+		t.Errorf("epic-1 group = %v, want nested bug-1 under its root epic", got)
+	}
+	if _, ok := byEpic["epic-2"]; ok {
+		t.Error("results should group under the root epic, not the nearest one")
+	}
+	if got := ids(byEpic[UngroupedKey]); !reflect.DeepEqual(got, []string{"bug-2", "missing"}) {
+		t.Errorf("ungrouped = %v, want [bug-2 missing]", got)
+	}
+}
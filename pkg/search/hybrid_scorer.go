@@ -6,6 +6,13 @@ type HybridScorer interface {
 	// Returns the final score and component breakdown.
 	Score(issueID string, textScore float64) (HybridScore, error)
 
+	// ScoreAll scores a result set in one pass and returns it sorted by
+	// FinalScore descending, ties broken by issue ID.
+	ScoreAll(results []TextResult) ([]HybridScore, error)
+
+	// Explain returns the weighted breakdown behind Score for one issue.
+	Explain(issueID string, textScore float64) (ScoreExplanation, error)
+
 	// Configure sets the weights for hybrid scoring.
 	Configure(weights Weights) error
 
@@ -13,6 +20,12 @@ type HybridScorer interface {
 	GetWeights() Weights
 }
 
+// TextResult pairs an issue with its text relevance score.
+type TextResult struct {
+	IssueID   string
+	TextScore float64
+}
+
 // HybridScore contains the final score and component breakdown for transparency.
 type HybridScore struct {
 	IssueID         string             `json:"issue_id"`
@@ -20,3 +33,19 @@ type HybridScore struct {
 	TextScore       float64            `json:"text_score"`
 	ComponentScores map[string]float64 `json:"component_scores,omitempty"`
 }
+
+// ScoreExplanation breaks a hybrid score into its weighted components. The
+// contributions sum to FinalScore.
+type ScoreExplanation struct {
+	IssueID    string                  `json:"issue_id"`
+	FinalScore float64                 `json:"score"`
+	Components []ComponentContribution `json:"components"`
+}
+
+// ComponentContribution is one term of a hybrid score.
+type ComponentContribution struct {
+	Name         string  `json:"name"`         // "text", "pagerank", "status", "impact", "priority" or "recency"
+	Value        float64 `json:"value"`        // Normalized component value
+	Weight       float64 `json:"weight"`       // Weight applied to Value
+	Contribution float64 `json:"contribution"` // Weight × Value
+}
@@ -32,7 +32,7 @@ func BenchmarkNormalizers(b *testing.B) {
 	})
 	b.Run("priority", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_ = normalizePriority(2)
+			_ = normalizePriority(2, 4)
 		}
 	})
 	b.Run("recency", func(b *testing.B) {
@@ -1,14 +1,58 @@
 package search
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
 
 type hybridScorer struct {
-	weights Weights
-	cache   MetricsCache
+	weights          Weights
+	cache            MetricsCache
+	textRecencyDecay bool
+	statusScores     map[string]float64
+	recencyHalfLife  time.Duration
+}
+
+// HybridScorerOption configures optional hybrid scorer behavior.
+type HybridScorerOption func(*hybridScorer)
+
+// WithTextRecencyDecay pre-multiplies the incoming text score by the issue's
+// recency factor (same decay as the recency component) before weighting, so
+// fresh matches outrank older, textually stronger ones. This is separate from
+// the Recency weight, which adds recency as its own component.
+func WithTextRecencyDecay() HybridScorerOption {
+	return func(s *hybridScorer) {
+		s.textRecencyDecay = true
+	}
+}
+
+// WithRecencyHalfLife sets how long it takes an issue's recency score to
+// halve; slow-moving projects want a longer half-life than fast ones.
+// Non-positive values keep DefaultRecencyHalfLife.
+func WithRecencyHalfLife(halfLife time.Duration) HybridScorerOption {
+	return func(s *hybridScorer) {
+		if halfLife > 0 {
+			s.recencyHalfLife = halfLife
+		}
+	}
+}
+
+// WithStatusScores registers status scores in [0, 1] for custom workflow
+// states (e.g. "in-review") or to override the built-in ones. Statuses not in
+// scores fall back to normalizeStatus; out-of-range scores are clamped.
+func WithStatusScores(scores map[string]float64) HybridScorerOption {
+	return func(s *hybridScorer) {
+		s.statusScores = make(map[string]float64, len(scores))
+		for status, score := range scores {
+			s.statusScores[status] = math.Max(0, math.Min(1, score))
+		}
+	}
 }
 
 // NewHybridScorer creates a scorer with the given weights and metrics cache.
-func NewHybridScorer(weights Weights, cache MetricsCache) HybridScorer {
+func NewHybridScorer(weights Weights, cache MetricsCache, opts ...HybridScorerOption) HybridScorer {
 	normalized := weights.Normalize()
 	if err := normalized.Validate(); err != nil {
 		if preset, presetErr := GetPreset(PresetDefault); presetErr == nil {
@@ -17,10 +61,15 @@ func NewHybridScorer(weights Weights, cache MetricsCache) HybridScorer {
 			normalized = Weights{TextRelevance: 1.0}
 		}
 	}
-	return &hybridScorer{
-		weights: normalized,
-		cache:   cache,
+	s := &hybridScorer{
+		weights:         normalized,
+		cache:           cache,
+		recencyHalfLife: DefaultRecencyHalfLife,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *hybridScorer) Score(issueID string, textScore float64) (HybridScore, error) {
@@ -44,33 +93,126 @@ func (s *hybridScorer) Score(issueID string, textScore float64) (HybridScore, er
 			TextScore:  textScore,
 		}, nil
 	}
+	return s.scoreMetrics(issueID, textScore, metrics, s.cache.MaxPriority(), s.cache.MaxBlockerCount(), time.Now()), nil
+}
+
+// ScoreAll reads the normalization maxima and the clock once, then scores each
+// result as Score would. Sharing the clock keeps recency from splitting ties.
+// Metrics are looked up with Get rather than GetBatch because GetBatch may
+// substitute defaults for unknown issues, which Score treats as text-only.
+func (s *hybridScorer) ScoreAll(results []TextResult) ([]HybridScore, error) {
+	for i, r := range results {
+		if r.IssueID == "" {
+			return nil, fmt.Errorf("issueID is required (result %d)", i)
+		}
+	}
 
-	statusScore := normalizeStatus(metrics.Status)
-	priorityScore := normalizePriority(metrics.Priority)
-	impactScore := normalizeImpact(metrics.BlockerCount, s.cache.MaxBlockerCount())
-	recencyScore := normalizeRecency(metrics.UpdatedAt)
+	// Look everything up first: Get may refresh the cache, which moves the
+	// maxima.
+	metrics := make([]IssueMetrics, len(results))
+	found := make([]bool, len(results))
+	var maxPriority, maxBlockers int
+	if s.cache != nil {
+		for i, r := range results {
+			metrics[i], found[i] = s.cache.Get(r.IssueID)
+		}
+		maxPriority = s.cache.MaxPriority()
+		maxBlockers = s.cache.MaxBlockerCount()
+	}
 
-	final := s.weights.TextRelevance*textScore +
-		s.weights.PageRank*metrics.PageRank +
-		s.weights.Status*statusScore +
-		s.weights.Impact*impactScore +
-		s.weights.Priority*priorityScore +
-		s.weights.Recency*recencyScore
+	now := time.Now()
+	scores := make([]HybridScore, len(results))
+	for i, r := range results {
+		if !found[i] {
+			scores[i] = HybridScore{IssueID: r.IssueID, FinalScore: r.TextScore, TextScore: r.TextScore}
+			continue
+		}
+		scores[i] = s.scoreMetrics(r.IssueID, r.TextScore, metrics[i], maxPriority, maxBlockers, now)
+	}
 
-	return HybridScore{
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].FinalScore != scores[j].FinalScore {
+			return scores[i].FinalScore > scores[j].FinalScore
+		}
+		return scores[i].IssueID < scores[j].IssueID
+	})
+	return scores, nil
+}
+
+// Explain returns the per-component breakdown behind Score. When there is no
+// cache or no metrics for the issue, the text score is the whole result.
+func (s *hybridScorer) Explain(issueID string, textScore float64) (ScoreExplanation, error) {
+	if issueID == "" {
+		return ScoreExplanation{}, fmt.Errorf("issueID is required")
+	}
+	if s.cache != nil {
+		if metrics, found := s.cache.Get(issueID); found {
+			return s.explainMetrics(issueID, textScore, metrics, s.cache.MaxPriority(), s.cache.MaxBlockerCount(), time.Now()), nil
+		}
+	}
+	return ScoreExplanation{
 		IssueID:    issueID,
-		FinalScore: final,
-		TextScore:  textScore,
-		ComponentScores: map[string]float64{
-			"pagerank": metrics.PageRank,
-			"status":   statusScore,
-			"impact":   impactScore,
-			"priority": priorityScore,
-			"recency":  recencyScore,
+		FinalScore: textScore,
+		Components: []ComponentContribution{
+			{Name: "text", Value: textScore, Weight: 1, Contribution: textScore},
 		},
 	}, nil
 }
 
+// scoreMetrics combines textScore with an issue's metrics using the given
+// normalization maxima, measuring recency from now.
+func (s *hybridScorer) scoreMetrics(issueID string, textScore float64, metrics IssueMetrics, maxPriority, maxBlockers int, now time.Time) HybridScore {
+	explanation := s.explainMetrics(issueID, textScore, metrics, maxPriority, maxBlockers, now)
+	components := make(map[string]float64, len(explanation.Components)-1)
+	for _, c := range explanation.Components[1:] {
+		components[c.Name] = c.Value
+	}
+	return HybridScore{
+		IssueID:         issueID,
+		FinalScore:      explanation.FinalScore,
+		TextScore:       textScore,
+		ComponentScores: components,
+	}
+}
+
+// explainMetrics computes each weighted component, text first. FinalScore is
+// the sum of the contributions in order; the explicit float64 conversions
+// stop the compiler fusing multiply-adds, so the sum is exact.
+func (s *hybridScorer) explainMetrics(issueID string, textScore float64, metrics IssueMetrics, maxPriority, maxBlockers int, now time.Time) ScoreExplanation {
+	statusScore, ok := s.statusScores[metrics.Status]
+	if !ok {
+		statusScore = normalizeStatus(metrics.Status)
+	}
+	priorityScore := normalizePriority(metrics.Priority, maxPriority)
+	impactScore := normalizeImpact(metrics.BlockerCount, maxBlockers)
+	recencyScore := normalizeRecencyAt(metrics.UpdatedAt, now, s.recencyHalfLife)
+
+	weightedText := textScore
+	if s.textRecencyDecay {
+		weightedText *= recencyScore
+	}
+
+	components := []ComponentContribution{
+		{Name: "text", Value: weightedText, Weight: s.weights.TextRelevance},
+		{Name: "pagerank", Value: metrics.PageRank, Weight: s.weights.PageRank},
+		{Name: "status", Value: statusScore, Weight: s.weights.Status},
+		{Name: "impact", Value: impactScore, Weight: s.weights.Impact},
+		{Name: "priority", Value: priorityScore, Weight: s.weights.Priority},
+		{Name: "recency", Value: recencyScore, Weight: s.weights.Recency},
+	}
+	var final float64
+	for i := range components {
+		components[i].Contribution = float64(components[i].Weight * components[i].Value)
+		final += components[i].Contribution
+	}
+
+	return ScoreExplanation{
+		IssueID:    issueID,
+		FinalScore: final,
+		Components: components,
+	}
+}
+
 func (s *hybridScorer) Configure(weights Weights) error {
 	if err := weights.Validate(); err != nil {
 		return err
@@ -49,7 +49,7 @@ func TestHybridScorer_RealMetrics(t *testing.T) {
 		weights.PageRank*metrics.PageRank +
 		weights.Status*normalizeStatus(metrics.Status) +
 		weights.Impact*normalizeImpact(metrics.BlockerCount, cache.MaxBlockerCount()) +
-		weights.Priority*normalizePriority(metrics.Priority) +
+		weights.Priority*normalizePriority(metrics.Priority, cache.MaxPriority()) +
 		weights.Recency*normalizeRecency(metrics.UpdatedAt)
 
 	if diff := result.FinalScore - expected; diff > 1e-9 || diff < -1e-9 {
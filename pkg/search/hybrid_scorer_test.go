@@ -2,6 +2,7 @@ package search
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -9,6 +10,7 @@ import (
 type stubMetricsCache struct {
 	metrics         map[string]IssueMetrics
 	maxBlockerCount int
+	maxPriority     int
 	missing         bool
 }
 
@@ -43,6 +45,10 @@ func (s *stubMetricsCache) MaxBlockerCount() int {
 	return s.maxBlockerCount
 }
 
+func (s *stubMetricsCache) MaxPriority() int {
+	return s.maxPriority
+}
+
 func TestHybridScorer_Score(t *testing.T) {
 	cache := &stubMetricsCache{
 		metrics: map[string]IssueMetrics{
@@ -74,7 +80,7 @@ func TestHybridScorer_Score(t *testing.T) {
 	}
 
 	impactScore := normalizeImpact(2, 4)
-	priorityScore := normalizePriority(1)
+	priorityScore := normalizePriority(1, cache.maxPriority)
 	statusScore := normalizeStatus("open")
 	recencyScore := normalizeRecency(cache.metrics["A"].UpdatedAt)
 
@@ -134,3 +140,256 @@ func TestHybridScorer_Configure(t *testing.T) {
 		t.Fatalf("expected weights updated")
 	}
 }
+
+func TestHybridScorer_TextRecencyDecay(t *testing.T) {
+	cache := &stubMetricsCache{
+		metrics: map[string]IssueMetrics{
+			"fresh": {IssueID: "fresh", Status: "open", Priority: 2, UpdatedAt: time.Now()},
+			"stale": {IssueID: "stale", Status: "open", Priority: 2, UpdatedAt: time.Now().Add(-90 * 24 * time.Hour)},
+		},
+	}
+	weights := Weights{TextRelevance: 1.0}
+
+	plain := NewHybridScorer(weights, cache)
+	decayed := NewHybridScorer(weights, cache, WithTextRecencyDecay())
+
+	score := func(s HybridScorer, id string, text float64) float64 {
+		t.Helper()
+		result, err := s.Score(id, text)
+		if err != nil {
+			t.Fatalf("Score(%s) error: %v", id, err)
+		}
+		return result.FinalScore
+	}
+
+	// Equal text match: tie without the boost, fresh wins with it.
+	if a, b := score(plain, "fresh", 0.8), score(plain, "stale", 0.8); math.Abs(a-b) > 1e-9 {
+		t.Errorf("without decay, equal matches should tie: fresh=%f stale=%f", a, b)
+	}
+	if a, b := score(decayed, "fresh", 0.8), score(decayed, "stale", 0.8); a <= b {
+		t.Errorf("with decay, fresh should outrank stale: fresh=%f stale=%f", a, b)
+	}
+
+	// A stronger old match still loses to a fresh one once decay is on.
+	if a, b := score(plain, "fresh", 0.7), score(plain, "stale", 0.9); a >= b {
+		t.Errorf("without decay, stronger stale match should win: fresh=%f stale=%f", a, b)
+	}
+	if a, b := score(decayed, "fresh", 0.7), score(decayed, "stale", 0.9); a <= b {
+		t.Errorf("with decay, fresh match should win: fresh=%f stale=%f", a, b)
+	}
+
+	// TextScore reports the raw input, not the decayed value.
+	if result, _ := decayed.Score("stale", 0.9); result.TextScore != 0.9 {
+		t.Errorf("TextScore = %f, want raw 0.9", result.TextScore)
+	}
+}
+
+func TestHybridScorer_StatusScores(t *testing.T) {
+	cache := &stubMetricsCache{
+		metrics: map[string]IssueMetrics{
+			"review": {IssueID: "review", Status: "in-review", Priority: 2, UpdatedAt: time.Now()},
+			"open":   {IssueID: "open", Status: "open", Priority: 2, UpdatedAt: time.Now()},
+		},
+	}
+	weights := Weights{TextRelevance: 0.5, Status: 0.5}
+
+	status := func(s HybridScorer, id string) float64 {
+		t.Helper()
+		result, err := s.Score(id, 0.5)
+		if err != nil {
+			t.Fatalf("Score(%s) error: %v", id, err)
+		}
+		return result.ComponentScores["status"]
+	}
+
+	plain := NewHybridScorer(weights, cache)
+	if got := status(plain, "review"); got != 0.5 {
+		t.Errorf("unregistered custom status should fall back to 0.5, got %f", got)
+	}
+
+	custom := NewHybridScorer(weights, cache, WithStatusScores(map[string]float64{"in-review": 0.9, "open": 2}))
+	if got := status(custom, "review"); got != 0.9 {
+		t.Errorf("registered in-review score = %f, want 0.9", got)
+	}
+	if got := status(custom, "open"); got != 1 {
+		t.Errorf("out-of-range override should clamp to 1, got %f", got)
+	}
+}
+
+func TestHybridScorer_ScoreAll(t *testing.T) {
+	updated := time.Now().Add(-24 * time.Hour)
+	cache := &stubMetricsCache{
+		metrics: map[string]IssueMetrics{
+			"A": {IssueID: "A", PageRank: 0.9, Status: "open", Priority: 0, BlockerCount: 4, UpdatedAt: updated},
+			"B": {IssueID: "B", PageRank: 0.1, Status: "closed", Priority: 4, BlockerCount: 0, UpdatedAt: updated},
+			"C": {IssueID: "C", PageRank: 0.1, Status: "closed", Priority: 4, BlockerCount: 0, UpdatedAt: updated},
+		},
+		maxBlockerCount: 4,
+		maxPriority:     4,
+	}
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+	scorer := NewHybridScorer(weights, cache)
+
+	results := []TextResult{
+		{IssueID: "C", TextScore: 0.5},
+		{IssueID: "missing", TextScore: 0.95},
+		{IssueID: "B", TextScore: 0.5},
+		{IssueID: "A", TextScore: 0.5},
+	}
+	scores, err := scorer.ScoreAll(results)
+	if err != nil {
+		t.Fatalf("ScoreAll: %v", err)
+	}
+
+	// missing has no metrics, so it keeps its raw text score; B and C tie
+	// and are ordered by ID
+	var order []string
+	for _, s := range scores {
+		order = append(order, s.IssueID)
+	}
+	if got, want := strings.Join(order, ","), "missing,A,B,C"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+
+	// Batch scores match incremental scoring
+	for _, s := range scores {
+		var text float64
+		for _, r := range results {
+			if r.IssueID == s.IssueID {
+				text = r.TextScore
+			}
+		}
+		single, err := scorer.Score(s.IssueID, text)
+		if err != nil {
+			t.Fatalf("Score(%s): %v", s.IssueID, err)
+		}
+		if math.Abs(single.FinalScore-s.FinalScore) > 1e-9 {
+			t.Errorf("%s: ScoreAll %v != Score %v", s.IssueID, s.FinalScore, single.FinalScore)
+		}
+	}
+
+	if _, err := scorer.ScoreAll([]TextResult{{IssueID: ""}}); err == nil {
+		t.Error("expected error for empty issue ID")
+	}
+}
+
+func TestHybridScorer_Explain(t *testing.T) {
+	cache := &stubMetricsCache{
+		metrics: map[string]IssueMetrics{
+			"A": {IssueID: "A", PageRank: 0.7, Status: "in_progress", Priority: 1, BlockerCount: 3, UpdatedAt: time.Now().Add(-72 * time.Hour)},
+		},
+		maxBlockerCount: 5,
+		maxPriority:     4,
+	}
+	weights, err := GetPreset(PresetBugHunting)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+	scorer := NewHybridScorer(weights, cache, WithTextRecencyDecay())
+
+	exp, err := scorer.Explain("A", 0.6)
+	if err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+	if len(exp.Components) != 6 || exp.Components[0].Name != "text" {
+		t.Fatalf("components = %+v", exp.Components)
+	}
+	var sum float64
+	for _, c := range exp.Components {
+		if c.Contribution != c.Weight*c.Value {
+			t.Errorf("%s: contribution %v != weight %v × value %v", c.Name, c.Contribution, c.Weight, c.Value)
+		}
+		sum += c.Contribution
+	}
+	if sum != exp.FinalScore {
+		t.Errorf("contributions sum to %v, FinalScore is %v", sum, exp.FinalScore)
+	}
+	score, err := scorer.Score("A", 0.6)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if math.Abs(score.FinalScore-exp.FinalScore) > 1e-9 {
+		t.Errorf("Explain FinalScore %v != Score %v", exp.FinalScore, score.FinalScore)
+	}
+
+	// Cache miss and no cache put everything on text relevance
+	for name, s := range map[string]HybridScorer{
+		"miss":     scorer,
+		"no cache": NewHybridScorer(weights, nil),
+	} {
+		exp, err := s.Explain("unknown", 0.42)
+		if err != nil {
+			t.Fatalf("%s: Explain: %v", name, err)
+		}
+		if exp.FinalScore != 0.42 || len(exp.Components) != 1 || exp.Components[0].Contribution != 0.42 || exp.Components[0].Weight != 1 {
+			t.Errorf("%s: explanation = %+v, want all of 0.42 on text", name, exp)
+		}
+	}
+
+	if _, err := scorer.Explain("", 0.5); err == nil {
+		t.Error("expected error for empty issue ID")
+	}
+}
+
+func TestHybridScorer_RecencyHalfLife(t *testing.T) {
+	cache := &stubMetricsCache{
+		metrics: map[string]IssueMetrics{
+			"A": {IssueID: "A", Status: "open", UpdatedAt: time.Now().Add(-90 * 24 * time.Hour)},
+		},
+		maxBlockerCount: 1,
+		maxPriority:     4,
+	}
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+
+	fast, err := NewHybridScorer(weights, cache).Score("A", 0.5)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	slow, err := NewHybridScorer(weights, cache, WithRecencyHalfLife(90*24*time.Hour)).Score("A", 0.5)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if got := slow.ComponentScores["recency"]; math.Abs(got-0.5) > 1e-6 {
+		t.Errorf("recency with 90-day half-life at 90 days = %f, want 0.5", got)
+	}
+	if fast.ComponentScores["recency"] >= slow.ComponentScores["recency"] {
+		t.Errorf("default half-life should decay faster: default %f, 90-day %f",
+			fast.ComponentScores["recency"], slow.ComponentScores["recency"])
+	}
+}
+
+func TestHybridScorer_ScoreAll_UnknownIssueIsTextOnly(t *testing.T) {
+	// The default cache's GetBatch fills in defaults for unknown IDs; ScoreAll
+	// must still treat them as misses, like Score does.
+	loader := &stubMetricsLoader{
+		hash: "h",
+		metrics: map[string]IssueMetrics{
+			"A": {IssueID: "A", PageRank: 0.4, Status: "open", Priority: 2, BlockerCount: 1},
+		},
+	}
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+	scorer := NewHybridScorer(weights, NewMetricsCache(loader))
+
+	scores, err := scorer.ScoreAll([]TextResult{{IssueID: "A", TextScore: 0.3}, {IssueID: "ghost", TextScore: 0.3}})
+	if err != nil {
+		t.Fatalf("ScoreAll: %v", err)
+	}
+	for _, got := range scores {
+		want, err := scorer.Score(got.IssueID, 0.3)
+		if err != nil {
+			t.Fatalf("Score: %v", err)
+		}
+		if math.Abs(got.FinalScore-want.FinalScore) > 1e-9 || len(got.ComponentScores) != len(want.ComponentScores) {
+			t.Errorf("%s: ScoreAll %+v, Score %+v", got.IssueID, got, want)
+		}
+	}
+}
@@ -7,8 +7,15 @@ import (
 
 const shortQueryDocBoost = 0.35
 
+// lexicalTermSaturation is the BM25 k1 parameter for the literal-match boost:
+// how quickly extra occurrences stop adding score.
+const lexicalTermSaturation = 1.2
+
 // ShortQueryLexicalBoost returns a literal-match boost for short queries.
-// It operates on the same document text used for indexing.
+// It operates on the same document text used for indexing. The boost grows
+// with the number of occurrences using BM25 term-frequency saturation, so a
+// single match earns shortQueryDocBoost and repeated matches approach, but
+// never exceed, (k1+1) times that.
 func ShortQueryLexicalBoost(query string, doc string) float64 {
 	if !IsShortQuery(query) {
 		return 0
@@ -17,10 +24,11 @@ func ShortQueryLexicalBoost(query string, doc string) float64 {
 	if needle == "" || doc == "" {
 		return 0
 	}
-	if strings.Contains(strings.ToLower(doc), needle) {
-		return shortQueryDocBoost
+	tf := float64(strings.Count(strings.ToLower(doc), needle))
+	if tf == 0 {
+		return 0
 	}
-	return 0
+	return shortQueryDocBoost * tf * (lexicalTermSaturation + 1) / (tf + lexicalTermSaturation)
 }
 
 // ApplyShortQueryLexicalBoost adds a literal-match boost to short-query results and re-sorts.
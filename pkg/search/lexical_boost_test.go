@@ -1,6 +1,9 @@
 package search
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestShortQueryLexicalBoost(t *testing.T) {
 	doc := "Performance benchmarks for graph rendering"
@@ -12,6 +15,36 @@ func TestShortQueryLexicalBoost(t *testing.T) {
 	}
 }
 
+func TestShortQueryLexicalBoostTermFrequency(t *testing.T) {
+	once := "Fix deadlock in worker pool shutdown"
+	five := "Fix deadlock in worker pool shutdown: deadlock on close, deadlock on drain, deadlock on retry, deadlock on cancel"
+
+	if boost := ShortQueryLexicalBoost("deadlock", once); boost != shortQueryDocBoost {
+		t.Errorf("single occurrence boost = %f, want %f", boost, shortQueryDocBoost)
+	}
+
+	results := []SearchResult{
+		{IssueID: "once", Score: 0.3},
+		{IssueID: "five", Score: 0.3},
+	}
+	updated := ApplyShortQueryLexicalBoost(results, "deadlock", map[string]string{"once": once, "five": five})
+	if updated[0].IssueID != "five" {
+		t.Fatalf("expected five-mention issue first, got %s", updated[0].IssueID)
+	}
+
+	// Saturation: keyword stuffing stays bounded and adds little over a few mentions.
+	stuffed := strings.Repeat("deadlock ", 500)
+	ceiling := shortQueryDocBoost * (lexicalTermSaturation + 1)
+	boostFive := ShortQueryLexicalBoost("deadlock", five)
+	boostStuffed := ShortQueryLexicalBoost("deadlock", stuffed)
+	if boostStuffed >= ceiling {
+		t.Errorf("stuffed boost %f should stay below ceiling %f", boostStuffed, ceiling)
+	}
+	if boostStuffed-boostFive > boostFive-shortQueryDocBoost {
+		t.Errorf("495 extra mentions added %f, more than the first 4 extra (%f)", boostStuffed-boostFive, boostFive-shortQueryDocBoost)
+	}
+}
+
 func TestApplyShortQueryLexicalBoostResorts(t *testing.T) {
 	results := []SearchResult{
 		{IssueID: "a", Score: 0.2},
@@ -7,7 +7,7 @@ type IssueMetrics struct {
 	IssueID      string    `json:"issue_id"`
 	PageRank     float64   `json:"pagerank"`      // 0.0-1.0, from graph analysis
 	Status       string    `json:"status"`        // open|in_progress|blocked|closed
-	Priority     int       `json:"priority"`      // 0-4 (P0=0, P4=4)
+	Priority     int       `json:"priority"`      // Usually 0-4 (P0=0, P4=4); wider ranges are normalized by MaxPriority
 	BlockerCount int       `json:"blocker_count"` // How many issues this blocks
 	UpdatedAt    time.Time `json:"updated_at"`    // For recency calculation
 }
@@ -28,6 +28,9 @@ type MetricsCache interface {
 
 	// MaxBlockerCount returns the maximum blocker count for normalization.
 	MaxBlockerCount() int
+
+	// MaxPriority returns the largest priority value for normalization.
+	MaxPriority() int
 }
 
 // MetricsLoader abstracts the source of metrics (graph analysis or direct DB).
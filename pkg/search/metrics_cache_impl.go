@@ -17,6 +17,7 @@ type metricsCache struct {
 	metrics         map[string]IssueMetrics
 	dataHash        string
 	maxBlockerCount int
+	maxPriority     int
 	loader          MetricsLoader
 }
 
@@ -156,17 +157,22 @@ func (c *metricsCache) Refresh() error {
 
 	copied := make(map[string]IssueMetrics, len(metrics))
 	maxBlocker := 0
+	maxPriority := 0
 	for id, metric := range metrics {
 		copied[id] = metric
 		if metric.BlockerCount > maxBlocker {
 			maxBlocker = metric.BlockerCount
 		}
+		if metric.Priority > maxPriority {
+			maxPriority = metric.Priority
+		}
 	}
 
 	c.mu.Lock()
 	c.metrics = copied
 	c.dataHash = hash
 	c.maxBlockerCount = maxBlocker
+	c.maxPriority = maxPriority
 	c.mu.Unlock()
 
 	return nil
@@ -186,6 +192,13 @@ func (c *metricsCache) MaxBlockerCount() int {
 	return c.maxBlockerCount
 }
 
+// MaxPriority returns the largest priority value for normalization.
+func (c *metricsCache) MaxPriority() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.maxPriority
+}
+
 func (c *metricsCache) ensureFresh() error {
 	if c.loader == nil {
 		return fmt.Errorf("metrics loader is nil")
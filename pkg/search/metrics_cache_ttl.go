@@ -0,0 +1,114 @@
+package search
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLMetricsCache is a MetricsCache filled with Put whose entries expire ttl
+// after they were stored. Expired entries read as misses, so a hybrid scorer
+// falls back to text-only scoring instead of ranking on stale graph data.
+type TTLMetricsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlEntry
+	now     func() time.Time
+}
+
+type ttlEntry struct {
+	metrics  IssueMetrics
+	storedAt time.Time
+}
+
+// NewTTLMetricsCache creates an empty cache whose entries live for ttl. A
+// non-positive ttl keeps entries until they are replaced.
+func NewTTLMetricsCache(ttl time.Duration) *TTLMetricsCache {
+	return &TTLMetricsCache{
+		ttl:     ttl,
+		entries: make(map[string]ttlEntry),
+		now:     time.Now,
+	}
+}
+
+// Put stores metrics for an issue, restarting its TTL.
+func (c *TTLMetricsCache) Put(issueID string, m IssueMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[issueID] = ttlEntry{metrics: m, storedAt: c.now()}
+}
+
+// Get returns metrics for an issue, evicting the entry if it has expired.
+func (c *TTLMetricsCache) Get(issueID string) (IssueMetrics, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[issueID]
+	if !ok {
+		return defaultIssueMetrics(issueID), false
+	}
+	if c.expired(entry, c.now()) {
+		delete(c.entries, issueID)
+		return defaultIssueMetrics(issueID), false
+	}
+	return entry.metrics, true
+}
+
+// GetBatch returns metrics for multiple issues, with defaults for unknown or
+// expired ones as in the loader-backed cache.
+func (c *TTLMetricsCache) GetBatch(issueIDs []string) map[string]IssueMetrics {
+	results := make(map[string]IssueMetrics, len(issueIDs))
+	for _, id := range issueIDs {
+		results[id], _ = c.Get(id)
+	}
+	return results
+}
+
+// Refresh evicts every expired entry. There is no source to reload from;
+// callers refresh data with Put.
+func (c *TTLMetricsCache) Refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictExpired(c.now())
+	return nil
+}
+
+// DataHash returns "" since the cache is not tied to a data source.
+func (c *TTLMetricsCache) DataHash() string {
+	return ""
+}
+
+// MaxBlockerCount returns the maximum blocker count among unexpired entries.
+func (c *TTLMetricsCache) MaxBlockerCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictExpired(c.now())
+	maxBlocker := 0
+	for _, entry := range c.entries {
+		maxBlocker = max(maxBlocker, entry.metrics.BlockerCount)
+	}
+	return maxBlocker
+}
+
+// MaxPriority returns the largest priority value among unexpired entries.
+func (c *TTLMetricsCache) MaxPriority() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictExpired(c.now())
+	maxPriority := 0
+	for _, entry := range c.entries {
+		maxPriority = max(maxPriority, entry.metrics.Priority)
+	}
+	return maxPriority
+}
+
+func (c *TTLMetricsCache) expired(entry ttlEntry, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(entry.storedAt) >= c.ttl
+}
+
+// evictExpired removes expired entries; c.mu must be held.
+func (c *TTLMetricsCache) evictExpired(now time.Time) {
+	for id, entry := range c.entries {
+		if c.expired(entry, now) {
+			delete(c.entries, id)
+		}
+	}
+}
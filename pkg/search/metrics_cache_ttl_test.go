@@ -0,0 +1,90 @@
+package search
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLMetricsCache_ExpiresEntries(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewTTLMetricsCache(10 * time.Minute)
+	cache.now = func() time.Time { return clock }
+
+	cache.Put("old", IssueMetrics{IssueID: "old", PageRank: 0.9, BlockerCount: 7, Priority: 4})
+	clock = clock.Add(6 * time.Minute)
+	cache.Put("new", IssueMetrics{IssueID: "new", PageRank: 0.2, BlockerCount: 2, Priority: 1})
+
+	if got := cache.MaxBlockerCount(); got != 7 {
+		t.Fatalf("MaxBlockerCount = %d, want 7 before expiry", got)
+	}
+	if m, ok := cache.Get("old"); !ok || m.PageRank != 0.9 {
+		t.Fatalf("Get(old) = %+v, %v before expiry", m, ok)
+	}
+
+	// "old" is now 10 minutes old and expires; "new" has 6 minutes left
+	clock = clock.Add(4 * time.Minute)
+	if got := cache.MaxBlockerCount(); got != 2 {
+		t.Errorf("MaxBlockerCount = %d, want 2 once the old entry expired", got)
+	}
+	if got := cache.MaxPriority(); got != 1 {
+		t.Errorf("MaxPriority = %d, want 1 once the old entry expired", got)
+	}
+	if _, ok := cache.Get("old"); ok {
+		t.Error("expected expired entry to miss")
+	}
+	if batch := cache.GetBatch([]string{"old", "new"}); batch["old"].PageRank != defaultPageRank || batch["new"].PageRank != 0.2 {
+		t.Errorf("GetBatch = %+v", batch)
+	}
+
+	// Put restarts the TTL
+	cache.Put("new", IssueMetrics{IssueID: "new", BlockerCount: 3})
+	clock = clock.Add(9 * time.Minute)
+	if m, ok := cache.Get("new"); !ok || m.BlockerCount != 3 {
+		t.Errorf("Get(new) = %+v, %v after re-Put", m, ok)
+	}
+}
+
+func TestTTLMetricsCache_ScorerFallsBackToText(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewTTLMetricsCache(time.Minute)
+	cache.now = func() time.Time { return clock }
+	cache.Put("A", IssueMetrics{IssueID: "A", PageRank: 1, Status: "open", BlockerCount: 1})
+
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+	scorer := NewHybridScorer(weights, cache)
+
+	fresh, err := scorer.Score("A", 0.2)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if fresh.ComponentScores == nil {
+		t.Fatal("expected graph components while the entry is fresh")
+	}
+
+	clock = clock.Add(time.Minute)
+	stale, err := scorer.Score("A", 0.2)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if stale.FinalScore != 0.2 || stale.ComponentScores != nil {
+		t.Errorf("stale score = %+v, want text-only 0.2", stale)
+	}
+}
+
+func TestTTLMetricsCache_ZeroTTLNeverExpires(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewTTLMetricsCache(0)
+	cache.now = func() time.Time { return clock }
+	cache.Put("A", IssueMetrics{IssueID: "A", BlockerCount: 1})
+
+	clock = clock.Add(365 * 24 * time.Hour)
+	if _, ok := cache.Get("A"); !ok {
+		t.Error("expected entry to survive with ttl 0")
+	}
+	if err := cache.Refresh(); err != nil || cache.MaxBlockerCount() != 1 {
+		t.Errorf("Refresh = %v, MaxBlockerCount = %d", err, cache.MaxBlockerCount())
+	}
+}
@@ -21,8 +21,21 @@ func normalizeStatus(status string) float64 {
 	}
 }
 
-// normalizePriority maps P0-P4 to [0.2, 1.0] range.
-func normalizePriority(priority int) float64 {
+// standardMaxPriority is the top of the usual P0-P4 scale.
+const standardMaxPriority = 4
+
+// normalizePriority maps priorities to the [0.2, 1.0] range, 0 being highest.
+// On the standard P0-P4 scale (maxPriority <= 4) it uses fixed steps; data
+// with a wider range (e.g. 0-100) is scaled linearly across 0..maxPriority so
+// distinct priorities keep distinct scores.
+func normalizePriority(priority, maxPriority int) float64 {
+	if maxPriority > standardMaxPriority && priority >= 0 {
+		if priority >= maxPriority {
+			return 0.2
+		}
+		return 1.0 - 0.8*float64(priority)/float64(maxPriority)
+	}
+
 	switch priority {
 	case 0:
 		return 1.0
@@ -53,11 +66,27 @@ func normalizeImpact(blockerCount, maxBlockerCount int) float64 {
 	return float64(blockerCount) / float64(maxBlockerCount)
 }
 
-// normalizeRecency applies exponential decay (half-life ~30 days).
+// DefaultRecencyHalfLife is the recency half-life scorers use unless
+// configured otherwise (see WithRecencyHalfLife). It equals the original
+// exp(-days/30) curve, whose half-life is 30·ln2 ≈ 20.8 days, rounded to the
+// nanosecond.
+const DefaultRecencyHalfLife = 499*time.Hour + 3*time.Minute + 57492011378*time.Nanosecond
+
+// normalizeRecency applies exponential decay with DefaultRecencyHalfLife.
 func normalizeRecency(updatedAt time.Time) float64 {
+	return normalizeRecencyAt(updatedAt, time.Now(), DefaultRecencyHalfLife)
+}
+
+// normalizeRecencyAt is exp(-ln2·age/halfLife) with age measured from now,
+// so a batch can share one clock reading. A non-positive halfLife falls back
+// to DefaultRecencyHalfLife.
+func normalizeRecencyAt(updatedAt, now time.Time, halfLife time.Duration) float64 {
 	if updatedAt.IsZero() {
 		return 0.5
 	}
-	daysSinceUpdate := time.Since(updatedAt).Hours() / 24
-	return math.Exp(-daysSinceUpdate / 30)
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+	age := now.Sub(updatedAt)
+	return math.Exp(-math.Ln2 * float64(age) / float64(halfLife))
 }
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"math"
 	"testing"
 	"time"
@@ -31,12 +32,41 @@ func TestNormalizePriority(t *testing.T) {
 		9: 0.5,
 	}
 	for priority, expected := range cases {
-		if got := normalizePriority(priority); got != expected {
+		if got := normalizePriority(priority, standardMaxPriority); got != expected {
 			t.Fatalf("priority %d: expected %f, got %f", priority, expected, got)
 		}
 	}
 }
 
+func TestNormalizePriority_WideRangeSpreadsScores(t *testing.T) {
+	priorities := []int{0, 5, 10, 25, 40, 50}
+	cache := &stubMetricsCache{metrics: map[string]IssueMetrics{}, maxPriority: 50}
+	for _, p := range priorities {
+		id := fmt.Sprintf("p%d", p)
+		cache.metrics[id] = IssueMetrics{IssueID: id, Status: "open", Priority: p}
+	}
+	scorer := NewHybridScorer(Weights{TextRelevance: 0.5, Priority: 0.5}, cache)
+
+	prev := math.Inf(1)
+	for _, p := range priorities {
+		result, err := scorer.Score(fmt.Sprintf("p%d", p), 0.5)
+		if err != nil {
+			t.Fatalf("score p%d: %v", p, err)
+		}
+		got := result.ComponentScores["priority"]
+		if got >= prev {
+			t.Fatalf("priority %d: score %f should be below the previous %f", p, got, prev)
+		}
+		prev = got
+	}
+	if got := normalizePriority(0, 50); got != 1.0 {
+		t.Errorf("highest priority should score 1.0, got %f", got)
+	}
+	if got := normalizePriority(50, 50); got != 0.2 {
+		t.Errorf("lowest priority should score 0.2, got %f", got)
+	}
+}
+
 func TestNormalizeImpact(t *testing.T) {
 	if got := normalizeImpact(1, 0); got != 0.5 {
 		t.Fatalf("expected neutral impact for max=0, got %f", got)
@@ -68,3 +98,24 @@ func TestNormalizeRecency(t *testing.T) {
 		t.Fatalf("expected recency %f for 30 days ago, got %f", expected, got)
 	}
 }
+
+func TestNormalizeRecencyHalfLife(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	halfLife := 180 * 24 * time.Hour
+
+	if got := normalizeRecencyAt(now, now, halfLife); got != 1 {
+		t.Errorf("age 0: got %f, want 1", got)
+	}
+	if got := normalizeRecencyAt(now.Add(-halfLife), now, halfLife); math.Abs(got-0.5) > 1e-12 {
+		t.Errorf("age = half-life: got %f, want 0.5", got)
+	}
+	if got := normalizeRecencyAt(now.Add(-20*halfLife), now, halfLife); got > 1e-6 {
+		t.Errorf("age = 20 half-lives: got %g, want ~0", got)
+	}
+
+	// Non-positive half-life keeps the default curve
+	monthAgo := now.Add(-30 * 24 * time.Hour)
+	if got := normalizeRecencyAt(monthAgo, now, 0); math.Abs(got-math.Exp(-1)) > 1e-9 {
+		t.Errorf("default half-life: got %f, want %f", got, math.Exp(-1))
+	}
+}
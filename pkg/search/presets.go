@@ -1,6 +1,10 @@
 package search
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // PresetName identifies a named weight configuration.
 type PresetName string
@@ -13,6 +17,38 @@ const (
 	PresetTextOnly       PresetName = "text-only"
 )
 
+// PresetInfo describes a preset for display, e.g. in a settings dropdown.
+type PresetInfo struct {
+	Name        PresetName
+	Description string
+	Weights     Weights
+	Builtin     bool
+}
+
+// builtinPresets lists the shipped presets in display order.
+var builtinPresets = []PresetName{
+	PresetDefault,
+	PresetBugHunting,
+	PresetSprintPlanning,
+	PresetImpactFirst,
+	PresetTextOnly,
+}
+
+var presetDescriptions = map[PresetName]string{
+	PresetDefault:        "Balanced mix of text match and graph signals",
+	PresetBugHunting:     "Favors high-priority issues",
+	PresetSprintPlanning: "Favors actionable issues by status",
+	PresetImpactFirst:    "Favors central, high-impact issues",
+	PresetTextOnly:       "Text relevance only",
+}
+
+// presetMu guards presets and customPresets, which RegisterPreset extends at
+// runtime.
+var presetMu sync.RWMutex
+
+// customPresets lists registered presets in registration order.
+var customPresets []PresetName
+
 var presets = map[PresetName]Weights{
 	PresetDefault: {
 		TextRelevance: 0.40,
@@ -58,6 +94,8 @@ var presets = map[PresetName]Weights{
 
 // GetPreset returns the weights for a named preset.
 func GetPreset(name PresetName) (Weights, error) {
+	presetMu.RLock()
+	defer presetMu.RUnlock()
 	weights, ok := presets[name]
 	if !ok {
 		return Weights{}, fmt.Errorf("unknown preset %q", name)
@@ -65,13 +103,49 @@ func GetPreset(name PresetName) (Weights, error) {
 	return weights, nil
 }
 
-// ListPresets returns all available preset names.
-func ListPresets() []PresetName {
-	return []PresetName{
-		PresetDefault,
-		PresetBugHunting,
-		PresetSprintPlanning,
-		PresetImpactFirst,
-		PresetTextOnly,
+// ListPresets returns all available presets: the built-in ones first, then
+// registered ones in registration order.
+func ListPresets() []PresetInfo {
+	presetMu.RLock()
+	defer presetMu.RUnlock()
+
+	infos := make([]PresetInfo, 0, len(builtinPresets)+len(customPresets))
+	for _, name := range builtinPresets {
+		infos = append(infos, PresetInfo{
+			Name:        name,
+			Description: presetDescriptions[name],
+			Weights:     presets[name],
+			Builtin:     true,
+		})
+	}
+	for _, name := range customPresets {
+		infos = append(infos, PresetInfo{
+			Name:        name,
+			Description: "Custom preset",
+			Weights:     presets[name],
+		})
+	}
+	return infos
+}
+
+// RegisterPreset adds a custom preset, or replaces one registered earlier.
+// The weights must pass Validate, and built-in presets cannot be replaced.
+func RegisterPreset(name PresetName, w Weights) error {
+	if strings.TrimSpace(string(name)) == "" {
+		return fmt.Errorf("preset name must not be empty")
+	}
+	if err := w.Validate(); err != nil {
+		return fmt.Errorf("preset %q: %w", name, err)
+	}
+
+	presetMu.Lock()
+	defer presetMu.Unlock()
+	if _, ok := presetDescriptions[name]; ok {
+		return fmt.Errorf("preset %q is built in and cannot be replaced", name)
+	}
+	if _, exists := presets[name]; !exists {
+		customPresets = append(customPresets, name)
 	}
+	presets[name] = w
+	return nil
 }
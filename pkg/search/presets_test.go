@@ -12,7 +12,12 @@ import (
 func TestPresetsMatchJavaScript(t *testing.T) {
 	jsPresets := loadJSPresets(t)
 
-	goPresets := ListPresets()
+	var goPresets []PresetName
+	for _, preset := range ListPresets() {
+		if preset.Builtin {
+			goPresets = append(goPresets, preset.Name)
+		}
+	}
 	if len(jsPresets) != len(goPresets) {
 		t.Fatalf("preset count mismatch: js=%d go=%d", len(jsPresets), len(goPresets))
 	}
@@ -174,3 +179,49 @@ func TestPresetsMatchJavaScript_ParseGuard(t *testing.T) {
 		t.Fatalf("expected JS presets file: %v", err)
 	}
 }
+
+func TestRegisterPreset(t *testing.T) {
+	const name PresetName = "recency-focused"
+	t.Cleanup(func() {
+		presetMu.Lock()
+		defer presetMu.Unlock()
+		delete(presets, name)
+		customPresets = nil
+	})
+
+	recency := Weights{TextRelevance: 0.40, PageRank: 0.10, Status: 0.10, Impact: 0.05, Priority: 0.05, Recency: 0.30}
+	if err := RegisterPreset(name, recency); err != nil {
+		t.Fatalf("RegisterPreset: %v", err)
+	}
+	if got, err := GetPreset(name); err != nil || got != recency {
+		t.Fatalf("GetPreset(%q) = %+v, %v", name, got, err)
+	}
+
+	infos := ListPresets()
+	last := infos[len(infos)-1]
+	if last.Name != name || last.Builtin || last.Weights != recency {
+		t.Errorf("last preset = %+v, want the registered custom preset", last)
+	}
+	if first := infos[0]; first.Name != PresetDefault || !first.Builtin || first.Description == "" {
+		t.Errorf("first preset = %+v, want the described default", first)
+	}
+
+	// Re-registering replaces the weights without duplicating the entry
+	recency.Recency, recency.TextRelevance = 0.35, 0.35
+	if err := RegisterPreset(name, recency); err != nil {
+		t.Fatalf("re-register: %v", err)
+	}
+	if n := len(ListPresets()); n != len(infos) {
+		t.Errorf("ListPresets has %d entries after re-register, want %d", n, len(infos))
+	}
+
+	if err := RegisterPreset("lopsided", Weights{TextRelevance: 0.9, Recency: 0.9}); err == nil {
+		t.Error("expected weights that fail Validate to be rejected")
+	}
+	if err := RegisterPreset(PresetDefault, recency); err == nil {
+		t.Error("expected built-in presets to be protected")
+	}
+	if err := RegisterPreset(" ", recency); err == nil {
+		t.Error("expected an empty name to be rejected")
+	}
+}
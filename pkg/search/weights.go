@@ -8,6 +8,12 @@ import (
 
 const weightSumTolerance = 0.001
 
+// Thresholds for Warnings, applied to normalized weights.
+const (
+	weightDominantThreshold = 0.9
+	weightNegligibleMax     = 0.01
+)
+
 // Weights defines the relative importance of each ranking factor.
 // All weights should sum to 1.0 for normalized scoring.
 //
@@ -62,6 +68,47 @@ func (w Weights) Normalize() Weights {
 	}
 }
 
+// Warnings returns advice about weight profiles that are valid but likely to
+// produce degenerate rankings: one component dominating the rest, or a
+// component so small it has no effect and was probably meant to be 0 or
+// larger. Weights are normalized first; nil means nothing looks suspicious.
+func (w Weights) Warnings() []string {
+	if w.sum() <= 0 {
+		return []string{"all weights are zero; every result will score the same"}
+	}
+
+	n := w.Normalize()
+	var warnings []string
+	for _, c := range n.components() {
+		if c.value > weightDominantThreshold {
+			warnings = append(warnings, fmt.Sprintf("%s weight %.2f dominates; other factors barely affect ranking", c.name, c.value))
+		}
+	}
+	for _, c := range n.components() {
+		if c.value > 0 && c.value < weightNegligibleMax {
+			warnings = append(warnings, fmt.Sprintf("%s weight %.3f is effectively zero; set it to 0 or raise it", c.name, c.value))
+		}
+	}
+	return warnings
+}
+
+type weightComponent struct {
+	name  string
+	value float64
+}
+
+// components lists the weights in declaration order, named by their JSON keys.
+func (w Weights) components() []weightComponent {
+	return []weightComponent{
+		{"text", w.TextRelevance},
+		{"pagerank", w.PageRank},
+		{"status", w.Status},
+		{"impact", w.Impact},
+		{"priority", w.Priority},
+		{"recency", w.Recency},
+	}
+}
+
 func (w Weights) sum() float64 {
 	return w.TextRelevance + w.PageRank + w.Status + w.Impact + w.Priority + w.Recency
 }
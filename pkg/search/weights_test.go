@@ -10,12 +10,12 @@ import (
 
 func TestWeightsValidate_Presets(t *testing.T) {
 	for _, preset := range ListPresets() {
-		weights, err := GetPreset(preset)
+		weights, err := GetPreset(preset.Name)
 		if err != nil {
-			t.Fatalf("expected preset %q, got error: %v", preset, err)
+			t.Fatalf("expected preset %q, got error: %v", preset.Name, err)
 		}
 		if err := weights.Validate(); err != nil {
-			t.Fatalf("preset %q should validate, got error: %v", preset, err)
+			t.Fatalf("preset %q should validate, got error: %v", preset.Name, err)
 		}
 	}
 }
@@ -95,3 +95,36 @@ func TestWeightsNormalize_ZeroSum(t *testing.T) {
 		t.Fatalf("expected zero-sum weights to remain unchanged")
 	}
 }
+
+func TestWeightsWarnings_Balanced(t *testing.T) {
+	weights, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatalf("GetPreset: %v", err)
+	}
+	if warnings := weights.Warnings(); len(warnings) != 0 {
+		t.Fatalf("expected no warnings for default preset, got %v", warnings)
+	}
+}
+
+func TestWeightsWarnings_Degenerate(t *testing.T) {
+	weights := Weights{
+		TextRelevance: 0.99,
+		PageRank:      0.005,
+		Recency:       0.005,
+	}
+	warnings := weights.Warnings()
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got %v", warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "text weight 0.99 dominates") {
+		t.Errorf("unexpected dominance warning: %q", warnings[0])
+	}
+	if !strings.HasPrefix(warnings[1], "pagerank weight 0.005 is effectively zero") ||
+		!strings.HasPrefix(warnings[2], "recency weight 0.005 is effectively zero") {
+		t.Errorf("unexpected negligible-weight warnings: %v", warnings[1:])
+	}
+
+	if warnings := (Weights{}).Warnings(); len(warnings) != 1 {
+		t.Errorf("expected a single all-zero warning, got %v", warnings)
+	}
+}
@@ -798,7 +798,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	}
 
 	// Theme
-	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	theme := ThemeFromEnv(lipgloss.NewRenderer(os.Stdout))
 
 	// Default dimensions for immediate ready state (updated when WindowSizeMsg arrives)
 	// This eliminates the "Initializing..." phase entirely, fixing slow startup issues
@@ -2492,6 +2492,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "esc":
 				// Escape closes modals and goes back
+				if m.focused == focusTree && m.tree.ShowingHelp() {
+					m.tree.ToggleHelp()
+					return m, nil
+				}
 				if m.showDetails && !m.isSplitView {
 					m.showDetails = false
 					m.focused = focusList
@@ -3310,7 +3314,17 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 
 // handleTreeKeys handles keyboard input when tree view is focused (bv-gllx)
 func (m Model) handleTreeKeys(msg tea.KeyMsg) Model {
+	if m.tree.ShowingHelp() {
+		// The overlay swallows keys until it is closed
+		if msg.String() == "K" {
+			m.tree.ToggleHelp()
+		}
+		return m
+	}
+
 	switch msg.String() {
+	case "K":
+		m.tree.ToggleHelp()
 	case "j", "down":
 		m.tree.MoveDown()
 	case "k", "up":
@@ -3326,14 +3340,34 @@ func (m Model) handleTreeKeys(msg tea.KeyMsg) Model {
 		m.tree.JumpToTop()
 	case "G":
 		m.tree.JumpToBottom()
+	case "*":
+		m.tree.ExpandSubtree()
 	case "o":
 		m.tree.ExpandAll()
 	case "O":
 		m.tree.CollapseAll()
+	case "C":
+		// Collapse subtrees with no open work remaining
+		m.tree.CollapseCompleted()
+	case "B":
+		// Narrow to what blocks the selected issue, or restore the full tree
+		if m.tree.IsFocused() {
+			m.tree.ClearFocus()
+		} else {
+			m.tree.FocusBlockingChain()
+		}
+	case "v":
+		// Show the selected issue's description under its row
+		m.tree.SetShowBodyPreview(!m.tree.ShowBodyPreview())
 	case "ctrl+d", "pgdown":
 		m.tree.PageDown()
 	case "ctrl+u", "pgup":
 		m.tree.PageUp()
+	case "<":
+		m.tree.ScrollLeft()
+	case ">":
+		// Pan row content to read titles cut off on deep rows
+		m.tree.ScrollRight()
 	case "E", "esc":
 		// Return to list view
 		m.focused = focusList
@@ -5756,11 +5790,11 @@ func nextHybridPreset(current search.PresetName) search.PresetName {
 		return search.PresetDefault
 	}
 	for i, preset := range presets {
-		if preset == current {
-			return presets[(i+1)%len(presets)]
+		if preset.Name == current {
+			return presets[(i+1)%len(presets)].Name
 		}
 	}
-	return presets[0]
+	return presets[0].Name
 }
 
 // getDiffStatus returns the diff status for an issue if time-travel mode is active
@@ -46,6 +46,12 @@ type metricsCacheHolder struct {
 	cache search.MetricsCache
 }
 
+// semanticScope restricts search to a set of issue IDs; nil IDs means no limit.
+type semanticScope struct {
+	EpicID string
+	IDs    map[string]bool
+}
+
 // SemanticScore captures semantic/hybrid scoring details for a single issue.
 type SemanticScore struct {
 	Score      float64
@@ -59,6 +65,7 @@ type SemanticSearch struct {
 	scores       atomic.Value // *semanticScoreCache
 	hybridConfig atomic.Value // semanticHybridConfig
 	metricsCache atomic.Value // *metricsCacheHolder
+	scope        atomic.Value // semanticScope
 }
 
 func NewSemanticSearch() *SemanticSearch {
@@ -67,6 +74,7 @@ func NewSemanticSearch() *SemanticSearch {
 	s.cache.Store(&semanticResultCache{results: make(map[string][]list.Rank)})
 	s.scores.Store(&semanticScoreCache{scores: make(map[string]SemanticScore)})
 	s.metricsCache.Store(&metricsCacheHolder{})
+	s.scope.Store(semanticScope{})
 	defaultWeights, err := search.GetPreset(search.PresetDefault)
 	if err != nil {
 		defaultWeights = search.Weights{TextRelevance: 1.0}
@@ -164,6 +172,57 @@ func (s *SemanticSearch) SetMetricsCache(cache search.MetricsCache) {
 	s.metricsCache.Store(&metricsCacheHolder{cache: cache})
 }
 
+func (s *SemanticSearch) getScope() semanticScope {
+	v := s.scope.Load()
+	if v == nil {
+		return semanticScope{}
+	}
+	return v.(semanticScope)
+}
+
+// ScopeToSubtree limits search to the issues under epicID: only IDs in
+// descendants are scored and returned (see analysis.Analyzer.Descendants).
+// Cached results are dropped since they may include out-of-scope issues.
+func (s *SemanticSearch) ScopeToSubtree(epicID string, descendants map[string]bool) {
+	ids := make(map[string]bool, len(descendants))
+	for id, ok := range descendants {
+		if ok {
+			ids[id] = true
+		}
+	}
+	s.scope.Store(semanticScope{EpicID: epicID, IDs: ids})
+	s.ResetCache()
+}
+
+// ClearScope removes any subtree scope so search covers every issue again.
+func (s *SemanticSearch) ClearScope() {
+	if s.getScope().IDs == nil {
+		return
+	}
+	s.scope.Store(semanticScope{})
+	s.ResetCache()
+}
+
+// ScopeEpicID returns the epic search is scoped to, or "" when unscoped.
+func (s *SemanticSearch) ScopeEpicID() string {
+	return s.getScope().EpicID
+}
+
+// filterRanksToScope drops ranks whose issue falls outside the current scope.
+func (s *SemanticSearch) filterRanksToScope(ranks []list.Rank, ids []string) []list.Rank {
+	scope := s.getScope()
+	if scope.IDs == nil {
+		return ranks
+	}
+	out := ranks[:0:0]
+	for _, rank := range ranks {
+		if rank.Index < len(ids) && scope.IDs[ids[rank.Index]] {
+			out = append(out, rank)
+		}
+	}
+	return out
+}
+
 // ResetCache clears cached semantic results and scores.
 func (s *SemanticSearch) ResetCache() {
 	s.cache.Store(&semanticResultCache{results: make(map[string][]list.Rank)})
@@ -269,7 +328,6 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 		// If we don't have a stable ID mapping, fall back to fuzzy filtering.
 		return list.DefaultFilter(term, targets)
 	}
-
 	// Check cache first - return immediately if we have cached results
 	c := s.getCache()
 	if cached, ok := c.results[term]; ok {
@@ -286,7 +344,7 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	s.cache.Store(newCache)
 
 	// Return fuzzy results immediately so UI stays responsive
-	return list.DefaultFilter(term, targets)
+	return s.filterRanksToScope(list.DefaultFilter(term, targets), snap.IDs)
 }
 
 // ComputeSemanticResults computes semantic similarity results synchronously.
@@ -323,9 +381,13 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 		hasVector bool
 	}
 
-	scoredItems := make([]scored, len(snap.IDs))
+	scope := s.getScope()
+	scoredItems := make([]scored, 0, len(snap.IDs))
 	scoreMap := make(map[string]SemanticScore, len(snap.IDs))
 	for i, id := range snap.IDs {
+		if scope.IDs != nil && !scope.IDs[id] {
+			continue
+		}
 		entry, ok := snap.Index.Get(id)
 		textScore := 0.0
 		score := 0.0
@@ -341,13 +403,13 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 			}
 			score = textScore
 		}
-		scoredItems[i] = scored{
+		scoredItems = append(scoredItems, scored{
 			index:     i,
 			id:        id,
 			score:     score,
 			textScore: textScore,
 			hasVector: ok,
-		}
+		})
 		scoreMap[id] = SemanticScore{
 			Score:     score,
 			TextScore: textScore,
@@ -462,15 +524,20 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 		}
 
 		maxBlocker := 0
+		maxPriority := 0
 		for _, metric := range metrics {
 			if metric.BlockerCount > maxBlocker {
 				maxBlocker = metric.BlockerCount
 			}
+			if metric.Priority > maxPriority {
+				maxPriority = metric.Priority
+			}
 		}
 
 		cache := &staticMetricsCache{
-			metrics:    metrics,
-			maxBlocker: maxBlocker,
+			metrics:     metrics,
+			maxBlocker:  maxBlocker,
+			maxPriority: maxPriority,
 		}
 		return HybridMetricsReadyMsg{Cache: cache}
 	}
@@ -532,8 +599,9 @@ func dotFloat32(a, b []float32) float64 {
 }
 
 type staticMetricsCache struct {
-	metrics    map[string]search.IssueMetrics
-	maxBlocker int
+	metrics     map[string]search.IssueMetrics
+	maxBlocker  int
+	maxPriority int
 }
 
 func (c *staticMetricsCache) Get(issueID string) (search.IssueMetrics, bool) {
@@ -562,3 +630,7 @@ func (c *staticMetricsCache) DataHash() string {
 func (c *staticMetricsCache) MaxBlockerCount() int {
 	return c.maxBlocker
 }
+
+func (c *staticMetricsCache) MaxPriority() int {
+	return c.maxPriority
+}
@@ -319,6 +319,54 @@ func TestSemanticSearchFilterSortsByScore(t *testing.T) {
 	}
 }
 
+func TestSemanticSearchScopeToSubtree(t *testing.T) {
+	ss := NewSemanticSearch()
+
+	idx := search.NewVectorIndex(3)
+	embedder := &mockEmbedder{
+		dim: 3,
+		embedFunc: func(ctx context.Context, texts []string) ([][]float32, error) {
+			result := make([][]float32, len(texts))
+			for i := range result {
+				result[i] = []float32{1.0, 0.0, 0.0}
+			}
+			return result, nil
+		},
+	}
+	ss.SetIndex(idx, embedder)
+
+	idx.Upsert("outside", search.ContentHash{}, []float32{1.0, 0.0, 0.0}) // Best match, wrong epic
+	idx.Upsert("child", search.ContentHash{}, []float32{0.5, 0.5, 0.0})
+	idx.Upsert("grandchild", search.ContentHash{}, []float32{0.0, 1.0, 0.0})
+	ss.SetIDs([]string{"outside", "child", "grandchild"})
+
+	if ranks := ss.ComputeSemanticResults("query"); len(ranks) != 3 || ranks[0].Index != 0 {
+		t.Fatalf("unscoped search should rank the outside match first, got %v", ranks)
+	}
+
+	ss.ScopeToSubtree("epic", map[string]bool{"child": true, "grandchild": true})
+	if got := ss.ScopeEpicID(); got != "epic" {
+		t.Errorf("ScopeEpicID() = %q, want epic", got)
+	}
+	ranks := ss.ComputeSemanticResults("query")
+	if len(ranks) != 2 {
+		t.Fatalf("expected 2 in-scope results, got %d", len(ranks))
+	}
+	if ranks[0].Index != 1 || ranks[1].Index != 2 {
+		t.Errorf("expected child then grandchild, got %v", ranks)
+	}
+	if scores, ok := ss.Scores("query"); !ok || len(scores) != 2 {
+		t.Errorf("out-of-scope issues should not be scored, got %v", scores)
+	} else if _, scored := scores["outside"]; scored {
+		t.Error("outside issue was scored")
+	}
+
+	ss.ClearScope()
+	if ranks := ss.ComputeSemanticResults("query"); len(ranks) != 3 {
+		t.Errorf("expected all 3 results after ClearScope, got %d", len(ranks))
+	}
+}
+
 func TestSemanticSearchFilterLimit(t *testing.T) {
 	ss := NewSemanticSearch()
 
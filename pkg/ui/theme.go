@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -35,10 +38,144 @@ type Theme struct {
 	Selected lipgloss.Style
 	Column   lipgloss.Style
 	Header   lipgloss.Style
+
+	// SelectionStyle is applied to every segment of the selected tree row,
+	// so its background spans the full line instead of showing only between
+	// the status, type and ID colors. Its foreground replaces colors that
+	// would vanish against the background (the ID).
+	SelectionStyle lipgloss.Style
+
+	// Glyphs used for tree indicators and status markers
+	Glyphs GlyphSet
+
+	// statusStyles holds styles registered with SetStatusStyle for custom
+	// statuses (or overrides of the built-in ones).
+	statusStyles map[model.Status]StatusStyle
+
+	// typeColors holds type accent colors set with WithTypeColors.
+	typeColors map[model.IssueType]lipgloss.Color
+}
+
+// ThemeOption configures a theme built by DefaultTheme.
+type ThemeOption func(*Theme)
+
+// WithTypeColors overrides the accent color TypeColor returns for issue types,
+// including custom types, on light and dark backgrounds alike. The Bug,
+// Feature, ... fields, which views also use as general accents, are left
+// alone.
+func WithTypeColors(colors map[model.IssueType]lipgloss.Color) ThemeOption {
+	return func(t *Theme) {
+		merged := make(map[model.IssueType]lipgloss.Color, len(t.typeColors)+len(colors))
+		for k, v := range t.typeColors {
+			merged[k] = v
+		}
+		for k, v := range colors {
+			merged[k] = v
+		}
+		t.typeColors = merged
+	}
+}
+
+// StatusStyle is how a status is drawn: its color and, optionally, the
+// marker glyph (the glyph set's unknown-status marker when empty).
+type StatusStyle struct {
+	Color lipgloss.AdaptiveColor
+	Glyph string
+}
+
+// SetStatusStyle registers how status is drawn, for custom workflow states
+// like "in-review". Unregistered unknown statuses use Subtext and the
+// unknown-status glyph. Register styles before handing the theme to views,
+// which keep their own copy.
+func (t *Theme) SetStatusStyle(status model.Status, style StatusStyle) {
+	styles := make(map[model.Status]StatusStyle, len(t.statusStyles)+1)
+	for k, v := range t.statusStyles {
+		styles[k] = v
+	}
+	styles[status] = style
+	t.statusStyles = styles
+}
+
+// StatusGlyph returns the status marker, preferring a glyph registered with
+// SetStatusStyle over the glyph set.
+func (t Theme) StatusGlyph(s string) string {
+	if style, ok := t.statusStyles[model.Status(s)]; ok && style.Glyph != "" {
+		return style.Glyph
+	}
+	return t.GlyphSet().StatusGlyph(s)
+}
+
+// GlyphSet holds the symbols drawn for tree expand state and issue status.
+// Swap it out to match the terminal font (e.g. ASCII-only or Nerd Font icons).
+type GlyphSet struct {
+	Expanded  string
+	Collapsed string
+	Leaf      string
+
+	StatusOpen       string
+	StatusInProgress string
+	StatusBlocked    string
+	StatusClosed     string
+	StatusUnknown    string
+}
+
+// DefaultGlyphs returns the standard Unicode/emoji glyph set.
+func DefaultGlyphs() GlyphSet {
+	return GlyphSet{
+		Expanded:  "▾",
+		Collapsed: "▸",
+		Leaf:      "•",
+
+		StatusOpen:       "🟢",
+		StatusInProgress: "🔵",
+		StatusBlocked:    "🔴",
+		StatusClosed:     "⚫",
+		StatusUnknown:    "⚪",
+	}
+}
+
+// ASCIIGlyphs returns a glyph set that renders on any terminal font.
+func ASCIIGlyphs() GlyphSet {
+	return GlyphSet{
+		Expanded:  "v",
+		Collapsed: ">",
+		Leaf:      "-",
+
+		StatusOpen:       "o",
+		StatusInProgress: "*",
+		StatusBlocked:    "x",
+		StatusClosed:     ".",
+		StatusUnknown:    "?",
+	}
+}
+
+// StatusGlyph returns the marker for a status string.
+func (g GlyphSet) StatusGlyph(s string) string {
+	switch s {
+	case "open":
+		return g.StatusOpen
+	case "in_progress":
+		return g.StatusInProgress
+	case "blocked":
+		return g.StatusBlocked
+	case "closed":
+		return g.StatusClosed
+	default:
+		return g.StatusUnknown
+	}
+}
+
+// GlyphSet returns the theme's glyphs, falling back to DefaultGlyphs for a
+// zero-value Theme.
+func (t Theme) GlyphSet() GlyphSet {
+	if t.Glyphs == (GlyphSet{}) {
+		return DefaultGlyphs()
+	}
+	return t.Glyphs
 }
 
 // DefaultTheme returns the standard Dracula-inspired theme (adaptive)
-func DefaultTheme(r *lipgloss.Renderer) Theme {
+func DefaultTheme(r *lipgloss.Renderer, opts ...ThemeOption) Theme {
 	t := Theme{
 		Renderer: r,
 
@@ -62,9 +199,119 @@ func DefaultTheme(r *lipgloss.Renderer) Theme {
 		Border:    lipgloss.AdaptiveColor{Light: "#AAAAAA", Dark: "#44475A"}, // Border (was #DDDDDD)
 		Highlight: lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#44475A"}, // Slightly darker
 		Muted:     lipgloss.AdaptiveColor{Light: "#555555", Dark: "#6272A4"}, // Dimmed text (was #888888, now ~7:1)
+
+		Glyphs: DefaultGlyphs(),
+	}
+
+	t.buildStyles(
+		lipgloss.AdaptiveColor{Light: "#000000", Dark: "#F8F8F2"},
+		lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"},
+	)
+
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	return t
+}
+
+// HighContrastTheme returns a theme with near-black text on light terminals
+// and bright colors on dark ones, for low-vision users and washed-out
+// terminals.
+func HighContrastTheme(r *lipgloss.Renderer, opts ...ThemeOption) Theme {
+	t := Theme{
+		Renderer: r,
+
+		Primary:   lipgloss.AdaptiveColor{Light: "#00008B", Dark: "#FFFF00"}, // Navy / yellow
+		Secondary: lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Subtext:   lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#E6E6E6"},
+
+		Open:       lipgloss.AdaptiveColor{Light: "#004D00", Dark: "#00FF00"},
+		InProgress: lipgloss.AdaptiveColor{Light: "#00005F", Dark: "#00FFFF"},
+		Blocked:    lipgloss.AdaptiveColor{Light: "#8B0000", Dark: "#FF6060"},
+		Closed:     lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+
+		Bug:     lipgloss.AdaptiveColor{Light: "#8B0000", Dark: "#FF6060"},
+		Feature: lipgloss.AdaptiveColor{Light: "#5C2E00", Dark: "#FFB000"},
+		Epic:    lipgloss.AdaptiveColor{Light: "#4B0082", Dark: "#FF80FF"},
+		Task:    lipgloss.AdaptiveColor{Light: "#404000", Dark: "#FFFF60"},
+		Chore:   lipgloss.AdaptiveColor{Light: "#00005F", Dark: "#00FFFF"},
+
+		Border:    lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Highlight: lipgloss.AdaptiveColor{Light: "#FFFF80", Dark: "#00005F"},
+		Muted:     lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+
+		Glyphs: DefaultGlyphs(),
+	}
+
+	t.buildStyles(
+		lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+	)
+
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	return t
+}
+
+// NoColorTheme returns a theme that emits no color at all, for NO_COLOR users
+// and CI logs. Status is carried by ASCII glyphs, and selection and headers by
+// bold and reverse video. Underline is avoided: lipgloss underlines rune by
+// rune, which breaks rows that already carry styled segments.
+func NoColorTheme(r *lipgloss.Renderer, opts ...ThemeOption) Theme {
+	t := Theme{
+		Renderer: r,
+		Glyphs:   ASCIIGlyphs(),
 	}
 
-	t.Base = r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#F8F8F2"})
+	t.Base = r.NewStyle()
+
+	t.Selected = r.NewStyle().
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		PaddingLeft(1).
+		Bold(true)
+
+	t.SelectionStyle = r.NewStyle().
+		Reverse(true).
+		Bold(true)
+
+	t.Header = r.NewStyle().
+		Reverse(true).
+		Bold(true).
+		Padding(0, 1)
+
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	return t
+}
+
+// ThemeFromEnv picks the theme for the environment: NoColorTheme when NO_COLOR
+// is set (https://no-color.org), else BV_THEME ("high-contrast", "no-color"),
+// else DefaultTheme.
+func ThemeFromEnv(r *lipgloss.Renderer, opts ...ThemeOption) Theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColorTheme(r, opts...)
+	}
+	switch os.Getenv("BV_THEME") {
+	case "high-contrast":
+		return HighContrastTheme(r, opts...)
+	case "no-color":
+		return NoColorTheme(r, opts...)
+	default:
+		return DefaultTheme(r, opts...)
+	}
+}
+
+// buildStyles derives the composite styles from the theme's colors. text is
+// the body text color and onPrimary the header text drawn over Primary.
+func (t *Theme) buildStyles(text, onPrimary lipgloss.AdaptiveColor) {
+	r := t.Renderer
+
+	t.Base = r.NewStyle().Foreground(text)
 
 	t.Selected = r.NewStyle().
 		Background(t.Highlight).
@@ -73,16 +320,22 @@ func DefaultTheme(r *lipgloss.Renderer) Theme {
 		PaddingLeft(1).
 		Bold(true)
 
+	t.SelectionStyle = r.NewStyle().
+		Background(t.Highlight).
+		Foreground(text).
+		Bold(true)
+
 	t.Header = r.NewStyle().
 		Background(t.Primary).
-		Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"}).
+		Foreground(onPrimary).
 		Bold(true).
 		Padding(0, 1)
-
-	return t
 }
 
 func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
+	if style, ok := t.statusStyles[model.Status(s)]; ok {
+		return style.Color
+	}
 	switch s {
 	case "open":
 		return t.Open
@@ -97,22 +350,59 @@ func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
 	}
 }
 
+// TypeColor returns the accent color for an issue type: a WithTypeColors
+// override, else the theme's type color (epic purple, bug red, ...) for the
+// renderer's background, else Subtext for unknown types.
+func (t Theme) TypeColor(typ model.IssueType) lipgloss.Color {
+	accent := t.typeAccent(typ)
+	dark := lipgloss.HasDarkBackground()
+	if t.Renderer != nil {
+		dark = t.Renderer.HasDarkBackground()
+	}
+	if dark {
+		return lipgloss.Color(accent.Dark)
+	}
+	return lipgloss.Color(accent.Light)
+}
+
+// typeAccent is TypeColor for both backgrounds.
+func (t Theme) typeAccent(typ model.IssueType) lipgloss.AdaptiveColor {
+	if color, ok := t.typeColors[typ]; ok {
+		return lipgloss.AdaptiveColor{Light: string(color), Dark: string(color)}
+	}
+	switch typ {
+	case model.TypeBug:
+		return t.Bug
+	case model.TypeFeature:
+		return t.Feature
+	case model.TypeTask:
+		return t.Task
+	case model.TypeEpic:
+		return t.Epic
+	case model.TypeChore:
+		return t.Chore
+	default:
+		return t.Subtext
+	}
+}
+
 func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
+	color := t.typeAccent(model.IssueType(typ))
 	switch typ {
 	case "bug":
-		return "🐛", t.Bug
+		return "🐛", color
 	case "feature":
-		return "✨", t.Feature
+		return "✨", color
 	case "task":
-		return "📋", t.Task
+		return "📋", color
 	case "epic":
 		// Use 🚀 instead of 🏔️ - the snow-capped mountain has a variation selector
 		// (U+FE0F) that causes inconsistent width calculations across terminals
-		return "🚀", t.Epic
+		return "🚀", color
 	case "chore":
-		return "🧹", t.Chore
+		return "🧹", color
 	default:
-		return "•", t.Subtext
+		return "•", color
 	}
 }
 
@@ -1,9 +1,15 @@
 package ui
 
 import (
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 func TestDefaultTheme(t *testing.T) {
@@ -50,6 +56,33 @@ func TestGetStatusColor(t *testing.T) {
 	}
 }
 
+func TestSetStatusStyle(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	before := theme
+	review := lipgloss.AdaptiveColor{Light: "#8000A0", Dark: "#FF79C6"}
+
+	if got := theme.GetStatusColor("in-review"); got != theme.Subtext {
+		t.Errorf("unregistered custom status color = %v, want Subtext", got)
+	}
+	if got := theme.StatusGlyph("in-review"); got != DefaultGlyphs().StatusUnknown {
+		t.Errorf("unregistered custom status glyph = %q, want the unknown glyph", got)
+	}
+
+	theme.SetStatusStyle("in-review", StatusStyle{Color: review, Glyph: "🟣"})
+	if got := theme.GetStatusColor("in-review"); got != review {
+		t.Errorf("GetStatusColor(in-review) = %v, want %v", got, review)
+	}
+	if got := theme.StatusGlyph("in-review"); got != "🟣" {
+		t.Errorf("StatusGlyph(in-review) = %q, want 🟣", got)
+	}
+	if got := theme.StatusGlyph("open"); got != DefaultGlyphs().StatusOpen {
+		t.Errorf("built-in glyphs should be unaffected, got %q", got)
+	}
+	if got := before.GetStatusColor("in-review"); got != theme.Subtext {
+		t.Errorf("copies taken before registration should not change, got %v", got)
+	}
+}
+
 func TestGetTypeIcon(t *testing.T) {
 	renderer := lipgloss.NewRenderer(nil)
 	theme := DefaultTheme(renderer)
@@ -77,3 +110,141 @@ func TestGetTypeIcon(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeColor(t *testing.T) {
+	for _, dark := range []bool{true, false} {
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetHasDarkBackground(dark)
+		theme := DefaultTheme(r)
+		resolve := func(c lipgloss.AdaptiveColor) lipgloss.Color {
+			if dark {
+				return lipgloss.Color(c.Dark)
+			}
+			return lipgloss.Color(c.Light)
+		}
+
+		tests := []struct {
+			typ  model.IssueType
+			want lipgloss.AdaptiveColor
+		}{
+			{model.TypeEpic, theme.Epic},
+			{model.TypeFeature, theme.Feature},
+			{model.TypeTask, theme.Task},
+			{model.TypeBug, theme.Bug},
+			{model.TypeChore, theme.Chore},
+			{"spike", theme.Subtext},
+		}
+		for _, tt := range tests {
+			if got := theme.TypeColor(tt.typ); got != resolve(tt.want) {
+				t.Errorf("dark=%v: TypeColor(%q) = %v, want %v", dark, tt.typ, got, resolve(tt.want))
+			}
+			if _, iconColor := theme.GetTypeIcon(string(tt.typ)); iconColor != tt.want {
+				t.Errorf("GetTypeIcon(%q) color = %v, want %v", tt.typ, iconColor, tt.want)
+			}
+		}
+	}
+}
+
+func TestWithTypeColors(t *testing.T) {
+	bug := lipgloss.Color("#A00000")
+	spike := lipgloss.Color("#005050")
+	theme := DefaultTheme(lipgloss.NewRenderer(nil), WithTypeColors(map[model.IssueType]lipgloss.Color{
+		model.TypeBug: bug,
+		"spike":       spike,
+	}))
+
+	if got := theme.TypeColor(model.TypeBug); got != bug {
+		t.Errorf("TypeColor(bug) = %v, want override", got)
+	}
+	if _, iconColor := theme.GetTypeIcon("spike"); iconColor != (lipgloss.AdaptiveColor{Light: "#005050", Dark: "#005050"}) {
+		t.Errorf("GetTypeIcon(spike) color = %v, want override", iconColor)
+	}
+	if got := theme.TypeColor(model.TypeEpic); got != lipgloss.Color(theme.Epic.Dark) {
+		t.Errorf("TypeColor(epic) = %v, want default", got)
+	}
+	// The Bug field doubles as a general accent and is not overridden
+	if theme.Bug.Dark == string(bug) || theme.Bug.Light == string(bug) {
+		t.Error("WithTypeColors should not change the Bug accent field")
+	}
+}
+
+func TestNoColorTheme_TreeViewHasNoColor(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{
+			ID: "task-1", Title: "Task", Status: model.StatusBlocked, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "task-1", DependsOnID: "epic-1", Type: model.DepParentChild}},
+		},
+		{ID: "bug-1", Title: "Bug", Status: model.StatusClosed, IssueType: model.TypeBug},
+	}
+	render := func(theme Theme) string {
+		tree := NewTreeModel(theme)
+		tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+		tree.SetSize(100, 20)
+		tree.Build(issues)
+		return tree.View()
+	}
+
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.TrueColor)
+
+	if colored := render(DefaultTheme(renderer)); !strings.Contains(colored, "38;2;") {
+		t.Fatalf("expected the default theme to emit colors with this renderer:\n%q", colored)
+	}
+
+	plain := render(NoColorTheme(renderer))
+	if strings.Contains(plain, "38;2;") || strings.Contains(plain, "48;2;") {
+		t.Errorf("NoColorTheme tree view contains color sequences:\n%q", plain)
+	}
+	// Expand state and status stay distinguishable through glyphs alone
+	text := ansi.Strip(plain)
+	for _, want := range []string{"v 🚀", "- 📋", "Epic o", "Task x", "Bug ."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("NoColorTheme tree view is missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestHighContrastTheme(t *testing.T) {
+	theme := HighContrastTheme(lipgloss.NewRenderer(nil))
+	base := DefaultTheme(lipgloss.NewRenderer(nil))
+
+	if theme.Primary == base.Primary || isColorEmpty(theme.Primary) {
+		t.Errorf("Primary = %v, want a distinct high-contrast color", theme.Primary)
+	}
+	if theme.TypeColor(model.TypeBug) != lipgloss.Color(theme.Bug.Dark) {
+		t.Error("expected TypeColor to use the high-contrast type colors")
+	}
+	if theme.Header.GetBackground() != lipgloss.TerminalColor(theme.Primary) {
+		t.Error("expected styles derived from the high-contrast palette")
+	}
+}
+
+func TestThemeFromEnv(t *testing.T) {
+	r := lipgloss.NewRenderer(nil)
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("BV_THEME", "")
+	if got := ThemeFromEnv(r); got.Primary != DefaultTheme(r).Primary {
+		t.Error("expected DefaultTheme without NO_COLOR or BV_THEME")
+	}
+
+	t.Setenv("BV_THEME", "high-contrast")
+	if got := ThemeFromEnv(r); got.Primary != HighContrastTheme(r).Primary {
+		t.Error("expected HighContrastTheme for BV_THEME=high-contrast")
+	}
+
+	// NO_COLOR wins over BV_THEME
+	t.Setenv("NO_COLOR", "1")
+	if got := ThemeFromEnv(r); got.Glyphs != ASCIIGlyphs() || !isColorEmpty(got.Primary) {
+		t.Error("expected NoColorTheme when NO_COLOR is set")
+	}
+}
+
+func TestNoColorTheme_MarkdownRenders(t *testing.T) {
+	md := NewMarkdownRendererWithTheme(60, NoColorTheme(lipgloss.NewRenderer(nil)))
+	out, err := md.Render("# Title\n\nSome *text* and `code`.")
+	if err != nil || !strings.Contains(out, "Title") {
+		t.Errorf("Render = %q, %v", out, err)
+	}
+}
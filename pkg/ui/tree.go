@@ -2,17 +2,24 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
 )
 
 // TreeState represents the persistent state of the tree view (bv-zv7p).
@@ -75,32 +82,7 @@ func (t *TreeModel) SetBeadsDir(dir string) {
 // Only stores explicit user changes; nodes not in the map use default behavior.
 // Errors are logged but do not interrupt the user experience.
 func (t *TreeModel) saveState() {
-	state := &TreeState{
-		Version:  TreeStateVersion,
-		Expanded: make(map[string]bool),
-	}
-
-	// Walk all nodes and record explicit expand state
-	var walk func(node *IssueTreeNode)
-	walk = func(node *IssueTreeNode) {
-		if node == nil || node.Issue == nil {
-			return
-		}
-
-		// Default: expanded for depth < 2, collapsed otherwise
-		defaultExpanded := node.Depth < 2
-		if node.Expanded != defaultExpanded {
-			state.Expanded[node.Issue.ID] = node.Expanded
-		}
-
-		for _, child := range node.Children {
-			walk(child)
-		}
-	}
-
-	for _, root := range t.roots {
-		walk(root)
-	}
+	state := t.captureState()
 
 	// Write to file
 	data, err := json.MarshalIndent(state, "", "  ")
@@ -122,6 +104,26 @@ func (t *TreeModel) saveState() {
 	}
 }
 
+// captureState records the expand state of every node that differs from
+// the depth-based default.
+func (t *TreeModel) captureState() *TreeState {
+	state := &TreeState{
+		Version:  TreeStateVersion,
+		Expanded: make(map[string]bool),
+	}
+
+	// Walk all nodes and record explicit expand state
+	counts := t.instanceCounts()
+	t.walkNodes(func(node *IssueTreeNode) {
+		// Default: expanded for depth < 2, collapsed otherwise
+		defaultExpanded := node.Depth < 2
+		if node.Expanded != defaultExpanded {
+			state.Expanded[treeStateKey(node, counts)] = node.Expanded
+		}
+	})
+	return state
+}
+
 // loadState restores expand/collapse state from disk (bv-afcm).
 // If the file doesn't exist or is corrupted, defaults are used silently.
 func (t *TreeModel) loadState() {
@@ -149,12 +151,56 @@ func (t *TreeModel) applyState(state *TreeState) {
 		return
 	}
 
-	for id, expanded := range state.Expanded {
-		if node, ok := t.issueMap[id]; ok {
+	counts := t.instanceCounts()
+	t.walkNodes(func(node *IssueTreeNode) {
+		if expanded, ok := state.Expanded[treeStateKey(node, counts)]; ok {
 			node.Expanded = expanded
 		}
-		// If ID not found, it's stale - ignore
+	})
+}
+
+// walkNodes calls fn for every node in the tree, parents before children.
+func (t *TreeModel) walkNodes(fn func(node *IssueTreeNode)) {
+	var walk func(node *IssueTreeNode)
+	walk = func(node *IssueTreeNode) {
+		if node == nil || node.Issue == nil {
+			return
+		}
+		fn(node)
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	for _, root := range t.roots {
+		walk(root)
+	}
+}
+
+// instanceCounts returns how many nodes show each issue; more than one under
+// MultiParentDuplicateUnderEach or in blocking mode.
+func (t *TreeModel) instanceCounts() map[string]int {
+	counts := make(map[string]int, len(t.issueMap))
+	t.walkNodes(func(node *IssueTreeNode) {
+		counts[node.Issue.ID]++
+	})
+	return counts
+}
+
+// treeStateKey is the persisted expand-state key for node: its issue ID, or
+// for an issue shown more than once, the ID path from its root ("epic/a/b")
+// so each copy keeps its own state.
+func treeStateKey(node *IssueTreeNode, counts map[string]int) string {
+	if counts[node.Issue.ID] <= 1 {
+		return node.Issue.ID
+	}
+	path := []string{node.Issue.ID}
+	for p := node.Parent; p != nil && p.Issue != nil; p = p.Parent {
+		path = append(path, p.Issue.ID)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
 	}
+	return strings.Join(path, "/")
 }
 
 // TreeViewMode determines what relationships are displayed
@@ -162,7 +208,27 @@ type TreeViewMode int
 
 const (
 	TreeModeHierarchy TreeViewMode = iota // parent-child deps (default)
-	TreeModeBlocking                      // blocking deps (future)
+	TreeModeBlocking                      // blockers with the issues they block beneath
+)
+
+// ClosedStyle determines how closed issues are drawn in the tree
+type ClosedStyle int
+
+const (
+	ClosedStyleNormal        ClosedStyle = iota // drawn like any other row (default)
+	ClosedStyleStrikethrough                    // title struck through
+	ClosedStyleDimmed                           // title in the muted color
+	ClosedStyleHidden                           // closed rows omitted; open descendants move up to the nearest listed ancestor
+)
+
+// MultiParentPolicy determines where an issue with several parents is placed
+// in the hierarchy. Blocking mode always lists an issue under every blocker.
+type MultiParentPolicy int
+
+const (
+	MultiParentFirstParentOnly    MultiParentPolicy = iota // under its first listed parent (default)
+	MultiParentDuplicateUnderEach                          // a separate node under every parent
+	MultiParentPromoteToRoot                               // shown as a root instead of under any parent
 )
 
 // IssueTreeNode represents a node in the hierarchical issue tree
@@ -172,6 +238,10 @@ type IssueTreeNode struct {
 	Expanded bool             // Is this node expanded?
 	Depth    int              // Nesting level (0 = root)
 	Parent   *IssueTreeNode   // Back-reference for navigation
+
+	// Duplicates holds same-titled siblings merged into this node for display
+	// when title dedupe is enabled. Rebuilt with the flat list.
+	Duplicates []*IssueTreeNode
 }
 
 // TreeModel manages the hierarchical tree view state
@@ -183,16 +253,114 @@ type TreeModel struct {
 	theme    Theme                      // Visual styling
 	mode     TreeViewMode               // Hierarchy vs blocking
 	issueMap map[string]*IssueTreeNode  // Quick lookup by issue ID
+	issues   []model.Issue              // Last built issues, for rebuilding on SetMode
+	report   *BuildReport               // Lazily computed by LastBuildReport; reset on build
 	width          int                  // Available width
 	height         int                  // Available height
 	viewportOffset int                  // Index of first visible node (bv-r4ng)
+	hScroll        int                  // Columns row content is panned right; prefixes stay pinned
 
 	// Build state
 	built    bool   // Has tree been built?
-	lastHash string // Hash of issues for cache invalidation
+	lastHash string // Hash of the built input (issuesHash, or the snapshot's DataHash)
 
 	// Persistence state (bv-19vz)
 	beadsDir string // Directory containing .beads (for tree-state.json)
+
+	// Cross-link annotations
+	showCrossLinks bool                // Annotate blocking deps that leave the node's subtree
+	showBadges     bool                // Append blocks/blocked-by counts to each row
+	dependents     map[string][]string // Blocker ID -> IDs it blocks (lazy, reset on rebuild)
+
+	dedupeByTitle bool // Merge same-titled siblings into one row
+	hideIslands   bool // Omit childless roots with no blocking dependencies
+	closedStyle   ClosedStyle
+	multiParent   MultiParentPolicy
+
+	// Text filter: when set, only matching issues and their ancestors are
+	// listed, regardless of expand state. Kept across rebuilds.
+	filter          string // Lowercased query
+	showBodyPreview bool   // View adds a description line under the selected row
+
+	// Issue predicate: when set, only passing issues and the ancestors of
+	// passing issues are listed; with hideEmptyAncestors those ancestors are
+	// dropped too and their rows drawn under the nearest listed ancestor.
+	// Kept across rebuilds.
+	issueFilter        func(*model.Issue) bool
+	hideEmptyAncestors bool
+	displayParent      map[*IssueTreeNode]*IssueTreeNode   // Listed parent of each row while rows are reparented (issueFilter or hidden closed rows)
+	displayChildren    map[*IssueTreeNode][]*IssueTreeNode // Listed children by listed parent (nil key: roots)
+
+	// Blocking-chain focus: when set, only these issues are listed,
+	// regardless of expand state. Cleared on rebuild.
+	focusIDs map[string]bool
+
+	// Snapshot diff highlight: per-issue change markers plus tombstone rows
+	// for removed issues, placed under their old parent. Cleared on rebuild.
+	diffStatus map[string]DiffStatus
+	tombstones []*IssueTreeNode
+
+	// Rendered-row cache: View reuses a node's line while its render key is
+	// unchanged, so moving the cursor only re-renders the two affected rows.
+	rowCache   map[*IssueTreeNode]treeRowCacheEntry
+	noRowCache bool // Disable the row cache (benchmarks)
+
+	showHelp bool // View shows the keybinding help instead of the tree
+}
+
+// TreeKeyBinding describes one tree-view command for the help overlay.
+type TreeKeyBinding struct {
+	Keys        string
+	Description string
+	Group       string
+}
+
+// treeKeyBindings lists the commands handled by Model.handleTreeKeys, in help
+// order. Add an entry here when adding a tree command so HelpView shows it.
+var treeKeyBindings = []TreeKeyBinding{
+	{"j / ↓", "Move down", "Move"},
+	{"k / ↑", "Move up", "Move"},
+	{"ctrl+d / pgdn", "Page down", "Move"},
+	{"ctrl+u / pgup", "Page up", "Move"},
+	{"enter / space", "Toggle expand", "Expand / collapse"},
+	{"l / →", "Expand or move to first child", "Expand / collapse"},
+	{"h / ←", "Collapse or jump to parent", "Expand / collapse"},
+	{"*", "Expand selected subtree", "Expand / collapse"},
+	{"o", "Expand all", "Expand / collapse"},
+	{"O", "Collapse all", "Expand / collapse"},
+	{"C", "Collapse completed subtrees", "Expand / collapse"},
+	{"< / >", "Scroll titles left / right", "Move"},
+	{"g", "Jump to top", "Jump"},
+	{"G", "Jump to bottom", "Jump"},
+	{"tab", "Show selected issue in detail panel", "View"},
+	{"B", "Show only the blocking chain (again to clear)", "View"},
+	{"v", "Toggle description preview", "View"},
+	{"K", "Toggle this help", "View"},
+	{"E / esc", "Back to list view", "View"},
+}
+
+// TreeKeyBindings returns the tree-view keybindings shown in the help overlay.
+func TreeKeyBindings() []TreeKeyBinding {
+	return append([]TreeKeyBinding(nil), treeKeyBindings...)
+}
+
+// treeRowKey identifies the inputs a cached row was rendered from.
+type treeRowKey struct {
+	content  uint64 // Hash of the node fields renderNode reads
+	selected bool
+	width    int
+	hScroll  int
+}
+
+type treeRowCacheEntry struct {
+	key  treeRowKey
+	line string
+
+	// The row's horizontal overflow for maxHScroll, valid while
+	// overflowKey matches; unlike the line it does not depend on selection
+	// or hScroll.
+	overflowKey treeRowKey
+	overflow    int
 }
 
 // NewTreeModel creates an empty tree model
@@ -212,11 +380,25 @@ func NewTreeModel(theme Theme) TreeModel {
 // Those remain view concerns handled by TreeModel (so user state can change without
 // requiring a snapshot rebuild).
 func buildIssueTreeNodes(issues []model.Issue) ([]*IssueTreeNode, map[string]*IssueTreeNode) {
+	roots, nodeMap, _ := buildIssueTreeNodesContext(context.Background(), issues, TreeModeHierarchy, MultiParentFirstParentOnly)
+	return roots, nodeMap
+}
+
+// treeBuildCheckInterval is how many issues/nodes are processed between
+// cancellation checks while building the tree.
+const treeBuildCheckInterval = 256
+
+// buildIssueTreeNodesContext is buildIssueTreeNodes with cancellation support
+// and a choice of mode. It checks ctx periodically and returns ctx.Err() if the
+// build was abandoned. In TreeModeBlocking the "parent" of an issue is each
+// issue blocking it, so roots are issues nothing blocks. policy places issues
+// with several parents (see treeParents).
+func buildIssueTreeNodesContext(ctx context.Context, issues []model.Issue, mode TreeViewMode, policy MultiParentPolicy) ([]*IssueTreeNode, map[string]*IssueTreeNode, error) {
 	t := TreeModel{
 		issueMap: make(map[string]*IssueTreeNode),
 	}
 	if len(issues) == 0 {
-		return nil, t.issueMap
+		return nil, t.issueMap, nil
 	}
 
 	// Step 1: Build parent→children index and track which issues have parents
@@ -225,55 +407,95 @@ func buildIssueTreeNodes(issues []model.Issue) ([]*IssueTreeNode, map[string]*Is
 	issueByID := make(map[string]*model.Issue)
 
 	for i := range issues {
+		issueByID[issues[i].ID] = &issues[i]
+	}
+	for i := range issues {
+		if i%treeBuildCheckInterval == 0 && ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
 		issue := &issues[i]
-		issueByID[issue.ID] = issue
-
-		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepParentChild {
-				parentID := dep.DependsOnID
-				childrenOf[parentID] = append(childrenOf[parentID], issue)
-				hasParent[issue.ID] = true
-			}
+		for _, parentID := range treeParents(issue, mode, policy, issueByID) {
+			childrenOf[parentID] = append(childrenOf[parentID], issue)
+			hasParent[issue.ID] = true
 		}
 	}
 
-	// Step 2: Identify root nodes (issues with no parent OR whose parent doesn't exist)
+	// Step 2: Identify root nodes (issues with no parent OR whose parent
+	// doesn't exist - treeParents only returns existing parents)
 	var rootIssues []*model.Issue
 	for i := range issues {
-		issue := &issues[i]
-		if !hasParent[issue.ID] {
-			rootIssues = append(rootIssues, issue)
-			continue
-		}
-
-		// Issue declares a parent - verify at least one referenced parent exists
-		hasValidParent := false
-		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepParentChild {
-				if _, exists := issueByID[dep.DependsOnID]; exists {
-					hasValidParent = true
-					break
-				}
-			}
-		}
-		if !hasValidParent {
-			rootIssues = append(rootIssues, issue)
+		if !hasParent[issues[i].ID] {
+			rootIssues = append(rootIssues, &issues[i])
 		}
 	}
 
 	// Step 3: Build tree recursively with cycle detection
 	visited := make(map[string]bool)
 	for _, issue := range rootIssues {
-		node := t.buildNode(issue, 0, childrenOf, nil, visited)
+		node := t.buildNode(ctx, issue, 0, childrenOf, nil, visited)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if node != nil {
 			t.roots = append(t.roots, node)
 		}
 	}
 
+	// Blocking cycles are common and, having no unblocked entry point, would
+	// vanish entirely; surface each unreached issue as a root instead.
+	if mode == TreeModeBlocking {
+		for i := range issues {
+			issue := &issues[i]
+			if _, reached := t.issueMap[issue.ID]; reached {
+				continue
+			}
+			node := t.buildNode(ctx, issue, 0, childrenOf, nil, visited)
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+			if node != nil {
+				t.roots = append(t.roots, node)
+			}
+		}
+	}
+
 	// Step 4: Sort roots by priority, type, then created date
 	t.sortNodes(t.roots)
 
-	return t.roots, t.issueMap
+	return t.roots, t.issueMap, nil
+}
+
+// treeParents returns the existing issues that issue is placed under. In the
+// hierarchy, an issue with several parents is handled per policy; blocking
+// mode lists it under every blocker.
+func treeParents(issue *model.Issue, mode TreeViewMode, policy MultiParentPolicy, issueByID map[string]*model.Issue) []string {
+	var parents []string
+	for _, dep := range issue.Dependencies {
+		if !isTreeLink(mode, dep) || issueByID[dep.DependsOnID] == nil {
+			continue
+		}
+		duplicate := false
+		for _, p := range parents {
+			if p == dep.DependsOnID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			parents = append(parents, dep.DependsOnID)
+		}
+	}
+	if len(parents) <= 1 || mode == TreeModeBlocking {
+		return parents
+	}
+	switch policy {
+	case MultiParentDuplicateUnderEach:
+		return parents
+	case MultiParentPromoteToRoot:
+		return nil
+	default:
+		return parents[:1]
+	}
 }
 
 // SetSize updates the available dimensions for the tree view
@@ -284,38 +506,131 @@ func (t *TreeModel) SetSize(width, height int) {
 	t.viewport.Height = height
 }
 
-// Build constructs the tree from issues using parent-child dependencies.
+// Build constructs the tree from issues using parent-child dependencies, or
+// blocking dependencies in TreeModeBlocking. Dangling parent references and
+// cycles are reported by LastBuildReport.
 // Implementation for bv-j3ck.
 func (t *TreeModel) Build(issues []model.Issue) {
+	_ = t.BuildContext(context.Background(), issues)
+}
+
+// BuildContext is Build with cancellation support. Construction checks ctx
+// periodically; if it is cancelled the build is abandoned, ctx.Err() is
+// returned, and the previously built tree is left untouched. This lets callers
+// drop a stale build when newer issues arrive.
+func (t *TreeModel) BuildContext(ctx context.Context, issues []model.Issue) error {
+	_, err := t.buildContext(ctx, issues)
+	return err
+}
+
+// RebuildIfChanged rebuilds the tree only if issues differ from the last
+// build in anything the tree shows or is shaped by, and reports whether it
+// did. An unchanged input keeps expand/collapse state, the cursor, focus and
+// diff highlights; nodes are just repointed at the new issue values.
+func (t *TreeModel) RebuildIfChanged(issues []model.Issue) bool {
+	rebuilt, _ := t.buildContext(context.Background(), issues)
+	return rebuilt
+}
+
+// buildContext implements BuildContext, skipping the rebuild when the input
+// hash matches the last build. It reports whether the tree was rebuilt.
+func (t *TreeModel) buildContext(ctx context.Context, issues []model.Issue) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	hash := t.issuesHash(issues)
+	if t.built && hash == t.lastHash {
+		t.rebindIssues(issues)
+		return false, nil
+	}
+
+	// Build tree structure (no state) before touching the current tree.
+	roots, nodeMap, err := buildIssueTreeNodesContext(ctx, issues, t.mode, t.multiParent)
+	if err != nil {
+		return false, err
+	}
+
+	// The live expand state outranks the file, which may be stale or
+	// unwritable; capture it before the old nodes are dropped.
+	var prevState *TreeState
+	if t.built {
+		prevState = t.captureState()
+	}
+
 	// Reset state
-	t.roots = nil
+	t.issues = issues
+	t.report = nil
+	t.roots = roots
 	t.flatList = nil
-	t.issueMap = make(map[string]*IssueTreeNode)
+	t.issueMap = nodeMap
+	t.dependents = nil
+	t.rowCache = nil
+	t.diffStatus = nil
+	t.tombstones = nil
+	t.focusIDs = nil
 	t.cursor = 0
+	t.lastHash = hash
 
 	if len(issues) == 0 {
 		t.built = true
-		return
+		return true, nil
 	}
 
-	// Build tree structure (no state) and then apply persisted expand/collapse state.
-	roots, nodeMap := buildIssueTreeNodes(issues)
-	t.roots = roots
-	t.issueMap = nodeMap
-
 	// Step 5: Handle empty tree (no parent-child relationships found)
 	// If all issues are roots (no hierarchy), that's fine - show them all
 	// The View() will handle displaying a helpful message if needed
 
-	// Step 6: Load persisted state (bv-afcm)
+	// Step 6: Load persisted state (bv-afcm), then reapply the state the
+	// user had before this rebuild. New nodes keep the depth default.
 	// This modifies node.Expanded values before we build the flat list
 	t.loadState()
+	t.applyState(prevState)
 
 	// Step 7: Build the flat list for navigation
 	// This must come after loadState so expand states are applied
 	t.rebuildFlatList()
 
 	t.built = true
+	return true, nil
+}
+
+// issuesHash hashes the build inputs: the mode, the multi-parent policy and,
+// per issue, the fields the tree sorts, renders or filters on plus every
+// dependency. Order matters, as it breaks sort ties.
+func (t *TreeModel) issuesHash(issues []model.Issue) string {
+	h := fnv.New64a()
+	h.Write([]byte{byte(t.mode), byte(t.multiParent)})
+	for i := range issues {
+		issue := &issues[i]
+		for _, field := range []string{issue.ID, issue.Title, issue.Description, string(issue.Status), string(issue.IssueType)} {
+			io.WriteString(h, field)
+			h.Write([]byte{0})
+		}
+		fmt.Fprintf(h, "%d|%d", issue.Priority, issue.CreatedAt.UnixNano())
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				fmt.Fprintf(h, "|%s>%s", dep.Type, dep.DependsOnID)
+			}
+		}
+		h.Write([]byte{'\n'})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// rebindIssues points the existing nodes at the matching elements of issues,
+// so an unchanged rebuild still serves the caller's current values.
+func (t *TreeModel) rebindIssues(issues []model.Issue) {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	t.walkNodes(func(node *IssueTreeNode) {
+		if issue, ok := byID[node.Issue.ID]; ok {
+			node.Issue = issue
+		}
+	})
+	t.issues = issues
 }
 
 // BuildFromSnapshot wires the tree view to precomputed tree data from a DataSnapshot.
@@ -337,9 +652,32 @@ func (t *TreeModel) BuildFromSnapshot(snapshot *DataSnapshot) {
 		prevSelectedID = issue.ID
 	}
 
+	// Snapshot tree data is the parent-child hierarchy with the default
+	// multi-parent policy; anything else is built here.
+	if t.mode != TreeModeHierarchy || t.multiParent != MultiParentFirstParentOnly {
+		t.Build(snapshot.Issues)
+		t.lastHash = snapshot.DataHash
+		if prevSelectedID != "" && t.SelectByID(prevSelectedID) {
+			t.ensureCursorVisible()
+		}
+		return
+	}
+
+	var prevState *TreeState
+	if t.built {
+		prevState = t.captureState()
+	}
+
 	// Reset view state, but keep dimensions/theme/beadsDir.
+	t.issues = snapshot.Issues
+	t.report = nil
 	t.roots = snapshot.TreeRoots
 	t.issueMap = snapshot.TreeNodeMap
+	t.dependents = nil
+	t.rowCache = nil
+	t.diffStatus = nil
+	t.tombstones = nil
+	t.focusIDs = nil
 
 	// If the snapshot didn't include tree data, fall back to building it now.
 	if len(t.roots) == 0 || t.issueMap == nil {
@@ -348,8 +686,10 @@ func (t *TreeModel) BuildFromSnapshot(snapshot *DataSnapshot) {
 		return
 	}
 
-	// Apply persisted expand/collapse state and rebuild visible list.
+	// Apply persisted expand/collapse state, then the live state from before
+	// this snapshot, and rebuild visible list.
 	t.loadState()
+	t.applyState(prevState)
 	t.rebuildFlatList()
 	t.built = true
 	t.lastHash = snapshot.DataHash
@@ -367,8 +707,9 @@ func (t *TreeModel) BuildFromSnapshot(snapshot *DataSnapshot) {
 }
 
 // buildNode recursively builds a tree node and its children.
-// Uses visited map for cycle detection.
-func (t *TreeModel) buildNode(issue *model.Issue, depth int,
+// Uses visited map for cycle detection. Returns nil once ctx is cancelled;
+// callers must check ctx.Err() to distinguish that from a nil issue.
+func (t *TreeModel) buildNode(ctx context.Context, issue *model.Issue, depth int,
 	childrenOf map[string][]*model.Issue,
 	parent *IssueTreeNode,
 	visited map[string]bool) *IssueTreeNode {
@@ -376,6 +717,9 @@ func (t *TreeModel) buildNode(issue *model.Issue, depth int,
 	if issue == nil {
 		return nil
 	}
+	if len(t.issueMap)%treeBuildCheckInterval == 0 && ctx.Err() != nil {
+		return nil
+	}
 
 	// Cycle detection - if we've already visited this node in current path
 	if visited[issue.ID] {
@@ -406,7 +750,7 @@ func (t *TreeModel) buildNode(issue *model.Issue, depth int,
 	// Build children recursively
 	children := childrenOf[issue.ID]
 	for _, child := range children {
-		childNode := t.buildNode(child, depth+1, childrenOf, node, visited)
+		childNode := t.buildNode(ctx, child, depth+1, childrenOf, node, visited)
 		if childNode != nil {
 			node.Children = append(node.Children, childNode)
 		}
@@ -418,6 +762,93 @@ func (t *TreeModel) buildNode(issue *model.Issue, depth int,
 	return node
 }
 
+// SetMode switches between the parent-child hierarchy and the blocking tree,
+// rebuilding from the last built issues. The selection is kept if the issue
+// is still visible.
+func (t *TreeModel) SetMode(mode TreeViewMode) {
+	if t.mode == mode {
+		return
+	}
+	t.mode = mode
+	if !t.built {
+		return
+	}
+	prevSelectedID := t.GetSelectedID()
+	t.Build(t.issues)
+	if prevSelectedID != "" && t.SelectByID(prevSelectedID) {
+		t.ensureCursorVisible()
+	}
+}
+
+// SetMultiParentPolicy sets how issues with several parents are placed and
+// rebuilds the tree. Under MultiParentDuplicateUnderEach every copy is its own
+// node, with its own expand state. The selection is kept if still visible.
+func (t *TreeModel) SetMultiParentPolicy(policy MultiParentPolicy) {
+	if t.multiParent == policy {
+		return
+	}
+	t.multiParent = policy
+	if !t.built {
+		return
+	}
+	prevSelectedID := t.GetSelectedID()
+	t.Build(t.issues)
+	if prevSelectedID != "" && t.SelectByID(prevSelectedID) {
+		t.ensureCursorVisible()
+	}
+}
+
+// MultiParentPolicy returns how issues with several parents are placed.
+func (t *TreeModel) MultiParentPolicy() MultiParentPolicy {
+	return t.multiParent
+}
+
+// Mode returns the current tree mode.
+func (t *TreeModel) Mode() TreeViewMode {
+	return t.mode
+}
+
+// SortRootsByRisk reorders the root nodes so the subtree with the highest total
+// risk comes first. risk maps issue IDs to scores (e.g. from analysis); a
+// root's subtree risk is the sum over itself and all descendants, with missing
+// IDs counting as zero. Ties keep their current order, children are not
+// reordered, and the order lasts until the tree is next built.
+func (t *TreeModel) SortRootsByRisk(risk map[string]float64) {
+	if len(t.roots) <= 1 {
+		return
+	}
+	totals := make(map[*IssueTreeNode]float64, len(t.roots))
+	for _, root := range t.roots {
+		totals[root] = subtreeRisk(root, risk)
+	}
+
+	// Copy before sorting: roots may be shared with a DataSnapshot.
+	roots := append([]*IssueTreeNode(nil), t.roots...)
+	sort.SliceStable(roots, func(i, j int) bool {
+		return totals[roots[i]] > totals[roots[j]]
+	})
+
+	selected := t.SelectedNode()
+	t.roots = roots
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// subtreeRisk sums risk over node and its descendants.
+func subtreeRisk(node *IssueTreeNode, risk map[string]float64) float64 {
+	if node == nil || node.Issue == nil {
+		return 0
+	}
+	total := risk[node.Issue.ID]
+	for _, child := range node.Children {
+		total += subtreeRisk(child, risk)
+	}
+	return total
+}
+
 // sortNodes sorts a slice of tree nodes by priority, issue type, then created date.
 func (t *TreeModel) sortNodes(nodes []*IssueTreeNode) {
 	if len(nodes) <= 1 {
@@ -475,6 +906,9 @@ func issueTypeOrder(t model.IssueType) int {
 // Only renders visible nodes based on viewportOffset and height for O(viewport)
 // performance instead of O(n) where n is total nodes.
 func (t *TreeModel) View() string {
+	if t.showHelp {
+		return t.HelpView()
+	}
 	if !t.built || len(t.flatList) == 0 {
 		return t.renderEmptyState()
 	}
@@ -484,6 +918,47 @@ func (t *TreeModel) View() string {
 	// Get visible range - O(1) calculation based on viewportOffset and height
 	start, end := t.visibleRange()
 
+	// Rows may have narrowed since the last scroll (resize, collapse)
+	if t.hScroll > 0 {
+		t.hScroll = min(t.hScroll, t.maxHScroll())
+	}
+
+	// The breadcrumb and the description preview each take one row of the
+	// window; give up the row farthest from the cursor so the selection stays
+	// in view.
+	reserved := 0
+	makeRoom := func() {
+		if t.height > 0 && end-start+reserved >= t.height {
+			if t.cursor == end-1 {
+				start++
+			} else {
+				end--
+			}
+		}
+		reserved++
+	}
+
+	breadcrumb := ""
+	if t.height != 1 {
+		breadcrumb = t.breadcrumbLine(t.AncestorPath())
+		if breadcrumb != "" {
+			makeRoom()
+		}
+	}
+
+	preview := ""
+	if t.showBodyPreview && t.cursor >= start && t.cursor < end {
+		preview = t.bodyPreviewLine(t.flatList[t.cursor])
+		if preview != "" {
+			makeRoom()
+		}
+	}
+
+	if breadcrumb != "" {
+		sb.WriteString(breadcrumb)
+		sb.WriteString("\n")
+	}
+
 	// Render only visible nodes (bv-db02: windowed rendering)
 	for i := start; i < end; i++ {
 		node := t.flatList[i]
@@ -491,16 +966,12 @@ func (t *TreeModel) View() string {
 			continue
 		}
 
-		isSelected := i == t.cursor
-		line := t.renderNode(node, isSelected)
-
-		if isSelected {
-			// Highlight selected row using theme's Selected style
-			line = t.theme.Selected.Render(line)
-		}
-
-		sb.WriteString(line)
+		sb.WriteString(t.renderRow(node, i == t.cursor))
 		sb.WriteString("\n")
+		if i == t.cursor && preview != "" {
+			sb.WriteString(preview)
+			sb.WriteString("\n")
+		}
 	}
 
 	// Add position indicator if scrolling is needed (bv-2nax)
@@ -513,35 +984,141 @@ func (t *TreeModel) View() string {
 	return sb.String()
 }
 
-// renderPositionIndicator renders the scroll position indicator (bv-2nax).
-// Shows the current visible range in the format "[start-end of total]".
-// Uses 1-indexed numbers for user-friendly display.
-func (t *TreeModel) renderPositionIndicator(start, end int) string {
-	total := len(t.flatList)
-	// Convert to 1-indexed for display
-	displayStart := start + 1
-	displayEnd := end
+// renderRow returns the full line for a visible node, selection highlight
+// included, reusing the cached line when nothing it depends on has changed.
+func (t *TreeModel) renderRow(node *IssueTreeNode, isSelected bool) string {
+	var key treeRowKey
+	if !t.noRowCache {
+		key = treeRowKey{content: t.rowContentHash(node), selected: isSelected, width: t.width, hScroll: t.hScroll}
+		if entry, ok := t.rowCache[node]; ok && entry.key == key {
+			return entry.line
+		}
+	}
 
-	indicator := fmt.Sprintf(" [%d-%d of %d]", displayStart, displayEnd, total)
-	return t.theme.Renderer.NewStyle().
-		Foreground(t.theme.Muted).
-		Render(indicator)
+	line := t.renderNode(node, isSelected)
+	if isSelected {
+		// Highlight selected row using theme's Selected style
+		line = t.theme.Selected.Render(line)
+	}
+
+	if !t.noRowCache {
+		if t.rowCache == nil {
+			t.rowCache = make(map[*IssueTreeNode]treeRowCacheEntry)
+		}
+		entry := t.rowCache[node]
+		entry.key, entry.line = key, line
+		t.rowCache[node] = entry
+	}
+	return line
 }
 
-// renderEmptyState renders the view when there are no issues.
-func (t *TreeModel) renderEmptyState() string {
-	r := t.theme.Renderer
+// rowContentHash hashes the per-node inputs of renderNode. The tree prefix is
+// covered by rebuildFlatList clearing the cache, and settings such as
+// cross-link annotations by their setters clearing it.
+func (t *TreeModel) rowContentHash(node *IssueTreeNode) uint64 {
+	h := fnv.New64a()
+	issue := node.Issue
+	for _, field := range []string{issue.ID, issue.Title, string(issue.Status), string(issue.IssueType)} {
+		io.WriteString(h, field)
+		h.Write([]byte{0})
+	}
+	h.Write([]byte{byte(issue.Priority), boolByte(node.Expanded), boolByte(isExpandable(node))})
+	for _, dup := range node.Duplicates {
+		h.Write([]byte{0})
+		io.WriteString(h, dup.Issue.ID)
+	}
+	return h.Sum64()
+}
 
-	titleStyle := r.NewStyle().
-		Foreground(t.theme.Primary).
-		Bold(true)
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
 
-	mutedStyle := r.NewStyle().
-		Foreground(t.theme.Muted)
+// ToggleHelp shows or hides the keybinding help overlay.
+func (t *TreeModel) ToggleHelp() {
+	t.showHelp = !t.showHelp
+}
+
+// ShowingHelp reports whether the help overlay is visible.
+func (t *TreeModel) ShowingHelp() bool {
+	return t.showHelp
+}
+
+// HelpView renders the tree keybindings as a themed table, grouped by kind.
+func (t *TreeModel) HelpView() string {
+	r := t.theme.Renderer
+	titleStyle := r.NewStyle().Foreground(t.theme.Primary).Bold(true)
+	groupStyle := r.NewStyle().Foreground(t.theme.Secondary).Bold(true)
+	keyStyle := r.NewStyle().Foreground(t.theme.Highlight)
+	mutedStyle := r.NewStyle().Foreground(t.theme.Muted)
+
+	keyWidth := 0
+	for _, b := range treeKeyBindings {
+		if w := lipgloss.Width(b.Keys); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Tree View Keys"))
+	sb.WriteString("\n")
+	group := ""
+	for _, b := range treeKeyBindings {
+		if b.Group != group {
+			group = b.Group
+			sb.WriteString("\n")
+			sb.WriteString(groupStyle.Render(group))
+			sb.WriteString("\n")
+		}
+		pad := strings.Repeat(" ", keyWidth-lipgloss.Width(b.Keys))
+		sb.WriteString("  ")
+		sb.WriteString(keyStyle.Render(b.Keys))
+		sb.WriteString(pad)
+		sb.WriteString("  ")
+		sb.WriteString(b.Description)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(mutedStyle.Render("Press K or esc to close."))
+	return sb.String()
+}
+
+// renderPositionIndicator renders the scroll position indicator (bv-2nax).
+// Shows the current visible range in the format "[start-end of total]".
+// Uses 1-indexed numbers for user-friendly display.
+func (t *TreeModel) renderPositionIndicator(start, end int) string {
+	total := len(t.flatList)
+	// Convert to 1-indexed for display
+	displayStart := start + 1
+	displayEnd := end
+
+	indicator := fmt.Sprintf(" [%d-%d of %d]", displayStart, displayEnd, total)
+	return t.theme.Renderer.NewStyle().
+		Foreground(t.theme.Muted).
+		Render(indicator)
+}
+
+// renderEmptyState renders the view when there are no issues.
+func (t *TreeModel) renderEmptyState() string {
+	r := t.theme.Renderer
+
+	titleStyle := r.NewStyle().
+		Foreground(t.theme.Primary).
+		Bold(true)
+
+	mutedStyle := r.NewStyle().
+		Foreground(t.theme.Muted)
 
 	var sb strings.Builder
 	sb.WriteString(titleStyle.Render("Tree View"))
 	sb.WriteString("\n\n")
+	if t.filter != "" && len(t.roots) > 0 {
+		sb.WriteString(mutedStyle.Render(fmt.Sprintf("No issues match %q.", t.filter)))
+		return sb.String()
+	}
 	sb.WriteString(mutedStyle.Render("No issues to display."))
 	sb.WriteString("\n\n")
 	sb.WriteString(mutedStyle.Render("To create hierarchy, add parent-child dependencies:"))
@@ -559,66 +1136,513 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 		return ""
 	}
 
-	issue := node.Issue
-	r := t.theme.Renderer
 	var sb strings.Builder
 
+	// Every segment starts from base, so on the selected row the selection
+	// background survives each segment's own color reset.
+	base := t.theme.Renderer.NewStyle()
+	if isSelected {
+		base = t.theme.SelectionStyle
+	}
+	space := base.Render(" ")
+
 	// Build the tree prefix (indentation + branch characters)
 	prefix := t.buildTreePrefix(node)
-	sb.WriteString(prefix)
+	if prefix != "" {
+		sb.WriteString(base.Render(prefix))
+	}
 
 	// Expand/collapse indicator
 	indicator := t.getExpandIndicator(node)
-	indicatorStyle := r.NewStyle().Foreground(t.theme.Secondary)
+	indicatorStyle := base.Foreground(t.theme.Secondary)
 	sb.WriteString(indicatorStyle.Render(indicator))
-	sb.WriteString(" ")
+	sb.WriteString(space)
+
+	// Everything after the indicator pans with the horizontal scroll; the
+	// title is shown in full once scrolled, so panning can reveal it.
+	maxTitleLen := 0
+	if t.hScroll == 0 {
+		// Use lipgloss.Width for proper display width (handles ANSI codes + Unicode)
+		maxTitleLen = t.width - lipgloss.Width(prefix) - 25 // Account for prefix, indicator, icon, priority, ID
+		if maxTitleLen < 20 {
+			maxTitleLen = 20
+		}
+	}
+	content := t.renderNodeContent(node, base, isSelected, maxTitleLen)
+	if t.hScroll > 0 {
+		pinned := lipgloss.Width(sb.String())
+		content = ansi.Cut(content, t.hScroll, t.hScroll+t.scrollWindow(pinned))
+	}
+	sb.WriteString(content)
+
+	// Carry the selection background to the edge of the row
+	if isSelected {
+		fill := t.width - t.theme.Selected.GetHorizontalFrameSize() - lipgloss.Width(sb.String())
+		if fill > 0 {
+			sb.WriteString(base.Render(strings.Repeat(" ", fill)))
+		}
+	}
+
+	return sb.String()
+}
+
+// renderNodeContent renders the part of a row after the expand indicator:
+// diff marker, type, priority, ID, title and annotations. A maxTitleLen of 0
+// leaves the title untruncated.
+func (t *TreeModel) renderNodeContent(node *IssueTreeNode, base lipgloss.Style, isSelected bool, maxTitleLen int) string {
+	issue := node.Issue
+	var cb strings.Builder
+	space := base.Render(" ")
+
+	// Snapshot diff marker; the title below takes the same style
+	diffStatus := t.diffStatus[issue.ID]
+	diffStyle, diffMarker := t.diffStyle(diffStatus)
+	diffStyle = diffStyle.Inherit(base)
+	if diffMarker != "" {
+		cb.WriteString(diffStyle.Render(diffMarker))
+		cb.WriteString(space)
+	}
 
 	// Type icon
 	icon, iconColor := t.theme.GetTypeIcon(string(issue.IssueType))
-	iconStyle := r.NewStyle().Foreground(iconColor)
-	sb.WriteString(iconStyle.Render(icon))
-	sb.WriteString(" ")
+	iconStyle := base.Foreground(iconColor)
+	cb.WriteString(iconStyle.Render(icon))
+	cb.WriteString(space)
 
 	// Priority badge (P0, P1, P2, etc.)
 	prioText := fmt.Sprintf("P%d", issue.Priority)
-	prioStyle := r.NewStyle().Bold(true)
+	prioStyle := base.Bold(true)
 	if issue.Priority <= 1 {
 		prioStyle = prioStyle.Foreground(t.theme.Primary)
 	} else {
 		prioStyle = prioStyle.Foreground(t.theme.Muted)
 	}
-	sb.WriteString(prioStyle.Render(prioText))
-	sb.WriteString(" ")
+	cb.WriteString(prioStyle.Render(prioText))
+	cb.WriteString(space)
 
-	// Issue ID
-	idStyle := r.NewStyle().Foreground(t.theme.Highlight)
-	sb.WriteString(idStyle.Render(issue.ID))
-	sb.WriteString(" ")
+	// Issue ID (the highlight color doubles as the selection background)
+	idStyle := base
+	if !isSelected {
+		idStyle = base.Foreground(t.theme.Highlight)
+	}
+	cb.WriteString(idStyle.Render(issue.ID))
+	cb.WriteString(space)
 
 	// Title (truncated if needed)
 	title := issue.Title
-	// Use lipgloss.Width for proper display width (handles ANSI codes + Unicode)
-	maxTitleLen := t.width - lipgloss.Width(prefix) - 25 // Account for prefix, indicator, icon, priority, ID
-	if maxTitleLen < 20 {
-		maxTitleLen = 20
+	if maxTitleLen > 0 {
+		title = t.truncateTitle(title, maxTitleLen)
+	}
+
+	// Title uses base style foreground unless highlighted by a diff or
+	// restyled as closed
+	titleStyle := base
+	if diffMarker != "" {
+		titleStyle = diffStyle
+	} else if issue.Status == model.StatusClosed {
+		if style, ok := t.closedTitleStyle(); ok {
+			titleStyle = style.Inherit(base)
+		}
 	}
-	title = t.truncateTitle(title, maxTitleLen)
+	cb.WriteString(titleStyle.Render(title))
 
-	// Title uses base style foreground
-	sb.WriteString(title)
+	// Merged duplicate count, with member IDs once expanded
+	if len(node.Duplicates) > 0 {
+		dupStyle := base.Foreground(t.theme.Secondary)
+		cb.WriteString(dupStyle.Render(fmt.Sprintf(" (%d)", len(node.Duplicates)+1)))
+		if node.Expanded {
+			ids := []string{issue.ID}
+			for _, dup := range node.Duplicates {
+				ids = append(ids, dup.Issue.ID)
+			}
+			cb.WriteString(dupStyle.Render(" [" + strings.Join(ids, ", ") + "]"))
+		}
+	}
 
 	// Status indicator (colored dot at end)
 	statusColor := t.theme.GetStatusColor(string(issue.Status))
-	statusDot := " " + GetStatusIcon(string(issue.Status))
-	statusStyle := r.NewStyle().Foreground(statusColor)
-	sb.WriteString(statusStyle.Render(statusDot))
+	statusDot := " " + t.theme.StatusGlyph(string(issue.Status))
+	statusStyle := base.Foreground(statusColor)
+	cb.WriteString(statusStyle.Render(statusDot))
+
+	// Blocking counts, e.g. "[3↓ 1↑]"
+	if t.showBadges {
+		if badge := t.nodeBadge(node); badge != "" {
+			cb.WriteString(space)
+			cb.WriteString(base.Foreground(t.theme.Secondary).Render(badge))
+		}
+	}
 
-	return sb.String()
+	// Blocking relationships that cross the hierarchy
+	if t.showCrossLinks {
+		if note := t.crossLinkAnnotation(node); note != "" {
+			cb.WriteString(space)
+			cb.WriteString(base.Foreground(t.theme.Blocked).Render(note))
+		}
+	}
+
+	return cb.String()
+}
+
+// treeHScrollStep is how many columns ScrollLeft and ScrollRight pan by.
+const treeHScrollStep = 8
+
+// ScrollRight pans row content right, to read titles cut off on deep or
+// narrow rows. The tree prefix and expand indicator stay pinned. Scrolling
+// stops once the widest visible row fits.
+func (t *TreeModel) ScrollRight() {
+	t.hScroll = min(t.hScroll+treeHScrollStep, t.maxHScroll())
+}
+
+// ScrollLeft pans row content back left.
+func (t *TreeModel) ScrollLeft() {
+	t.hScroll = max(t.hScroll-treeHScrollStep, 0)
+}
+
+// HScroll returns the horizontal scroll offset in columns.
+func (t *TreeModel) HScroll() int {
+	return t.hScroll
+}
+
+// scrollWindow is the width left for row content after pinned columns.
+func (t *TreeModel) scrollWindow(pinned int) int {
+	return max(t.width-t.theme.Selected.GetHorizontalFrameSize()-pinned, 0)
+}
+
+// maxHScroll is the offset at which the widest visible row's full content
+// just fits in its window. Each row's overflow is cached with its rendered
+// line, so View only measures rows whose content or width changed.
+func (t *TreeModel) maxHScroll() int {
+	if t.width <= 0 {
+		return 0
+	}
+	widest := 0
+	start, end := t.visibleRange()
+	for i := start; i < end; i++ {
+		node := t.flatList[i]
+		if node == nil || node.Issue == nil {
+			continue
+		}
+		widest = max(widest, t.rowOverflow(node))
+	}
+	return widest
+}
+
+// rowOverflow is how many columns node's full content overruns its scroll
+// window (negative when it fits).
+func (t *TreeModel) rowOverflow(node *IssueTreeNode) int {
+	var key treeRowKey
+	if !t.noRowCache {
+		key = treeRowKey{content: t.rowContentHash(node), width: t.width}
+		if entry, ok := t.rowCache[node]; ok && entry.overflowKey == key {
+			return entry.overflow
+		}
+	}
+
+	pinned := lipgloss.Width(t.buildTreePrefix(node)+t.getExpandIndicator(node)) + 1
+	full := lipgloss.Width(t.renderNodeContent(node, t.theme.Renderer.NewStyle(), false, 0))
+	overflow := full - t.scrollWindow(pinned)
+
+	if !t.noRowCache {
+		if t.rowCache == nil {
+			t.rowCache = make(map[*IssueTreeNode]treeRowCacheEntry)
+		}
+		entry := t.rowCache[node]
+		entry.overflowKey, entry.overflow = key, overflow
+		t.rowCache[node] = entry
+	}
+	return overflow
+}
+
+// SetClosedStyle sets how closed issues are drawn. Unlike filtering, hiding
+// only drops the closed rows: a parent whose children are all closed still
+// shows, and the open descendants of a hidden issue are drawn under its
+// nearest listed ancestor (or as roots). The selection is kept if it is still
+// visible.
+func (t *TreeModel) SetClosedStyle(style ClosedStyle) {
+	if t.closedStyle == style {
+		return
+	}
+	selected := t.SelectedNode()
+	t.closedStyle = style
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// ClosedStyle returns how closed issues are drawn.
+func (t *TreeModel) ClosedStyle() ClosedStyle {
+	return t.closedStyle
+}
+
+// closedTitleStyle returns the title style for closed issues, if the
+// current ClosedStyle restyles them.
+func (t *TreeModel) closedTitleStyle() (lipgloss.Style, bool) {
+	r := t.theme.Renderer
+	switch t.closedStyle {
+	case ClosedStyleStrikethrough:
+		return r.NewStyle().Strikethrough(true), true
+	case ClosedStyleDimmed:
+		return r.NewStyle().Foreground(t.theme.Muted), true
+	}
+	return r.NewStyle(), false
+}
+
+// SetShowCrossLinks toggles inline annotations listing blocking relationships
+// to issues outside a node's own subtree. These expose coupling between epics
+// that the parent/child hierarchy otherwise hides.
+func (t *TreeModel) SetShowCrossLinks(show bool) {
+	t.showCrossLinks = show
+	t.rowCache = nil
+}
+
+// ShowCrossLinks reports whether cross-link annotations are enabled.
+func (t *TreeModel) ShowCrossLinks() bool {
+	return t.showCrossLinks
+}
+
+// crossLinkAnnotation returns e.g. "[blocked by: a-1 | blocks: b-2]" for the
+// blocking dependencies of node that point outside its subtree, or "".
+func (t *TreeModel) crossLinkAnnotation(node *IssueTreeNode) string {
+	var blockedBy, blocks []string
+
+	for _, dep := range node.Issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if other, ok := t.issueMap[dep.DependsOnID]; ok && !isInSubtree(node, other) {
+			blockedBy = append(blockedBy, dep.DependsOnID)
+		}
+	}
+
+	for _, id := range t.dependentsOf(node.Issue.ID) {
+		if other, ok := t.issueMap[id]; ok && !isInSubtree(node, other) {
+			blocks = append(blocks, id)
+		}
+	}
+
+	var parts []string
+	if len(blockedBy) > 0 {
+		sort.Strings(blockedBy)
+		parts = append(parts, "blocked by: "+strings.Join(blockedBy, ", "))
+	}
+	if len(blocks) > 0 {
+		sort.Strings(blocks)
+		parts = append(parts, "blocks: "+strings.Join(blocks, ", "))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " | ") + "]"
+}
+
+// SetShowBadges toggles a badge after each row's status glyph counting the
+// issues the node blocks (↓) and is blocked by (↑).
+func (t *TreeModel) SetShowBadges(show bool) {
+	t.showBadges = show
+	t.rowCache = nil
+}
+
+// ShowBadges reports whether dependency-count badges are enabled.
+func (t *TreeModel) ShowBadges() bool {
+	return t.showBadges
+}
+
+// nodeBadge returns e.g. "[3↓ 1↑]": the number of issues n blocks and the
+// number of its blockers, counting only issues in the tree. Zero counts are
+// left out; a node with neither gets "".
+func (t *TreeModel) nodeBadge(n *IssueTreeNode) string {
+	if n == nil || n.Issue == nil {
+		return ""
+	}
+	blocks := len(t.dependentsOf(n.Issue.ID))
+	blockedBy := 0
+	for _, dep := range n.Issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if _, ok := t.issueMap[dep.DependsOnID]; ok {
+			blockedBy++
+		}
+	}
+
+	var parts []string
+	if blocks > 0 {
+		parts = append(parts, fmt.Sprintf("%d↓", blocks))
+	}
+	if blockedBy > 0 {
+		parts = append(parts, fmt.Sprintf("%d↑", blockedBy))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// dependentsOf returns the IDs of issues blocked by id, building the reverse
+// index on first use.
+func (t *TreeModel) dependentsOf(id string) []string {
+	if t.dependents == nil {
+		t.dependents = make(map[string][]string)
+		for issueID, n := range t.issueMap {
+			if n == nil || n.Issue == nil {
+				continue
+			}
+			for _, dep := range n.Issue.Dependencies {
+				if dep != nil && dep.Type.IsBlocking() {
+					t.dependents[dep.DependsOnID] = append(t.dependents[dep.DependsOnID], issueID)
+				}
+			}
+		}
+	}
+	return t.dependents[id]
+}
+
+// isInSubtree reports whether other is root itself or one of its descendants.
+func isInSubtree(root, other *IssueTreeNode) bool {
+	for n := other; n != nil; n = n.Parent {
+		if n == root {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyDiffHighlight marks the issues a snapshot diff touches: new issues,
+// modified ones (including closed and reopened), and removed ones, which are
+// shown as tombstone rows under their former parent (or as roots when that
+// parent is gone too). A later call replaces the previous highlight.
+func (t *TreeModel) ApplyDiffHighlight(diff analysis.SnapshotDiff) {
+	t.clearTombstones()
+	t.diffStatus = make(map[string]DiffStatus)
+
+	for _, issue := range diff.NewIssues {
+		t.diffStatus[issue.ID] = DiffStatusNew
+	}
+	for _, issue := range diff.ClosedIssues {
+		t.diffStatus[issue.ID] = DiffStatusModified
+	}
+	for _, issue := range diff.ReopenedIssues {
+		t.diffStatus[issue.ID] = DiffStatusModified
+	}
+	for _, mod := range diff.ModifiedIssues {
+		t.diffStatus[mod.IssueID] = DiffStatusModified
+	}
+
+	// Tombstones: create all nodes first so removed children can hang off
+	// removed parents.
+	removed := make(map[string]*IssueTreeNode, len(diff.RemovedIssues))
+	for i := range diff.RemovedIssues {
+		issue := diff.RemovedIssues[i]
+		if _, exists := t.issueMap[issue.ID]; exists || removed[issue.ID] != nil {
+			continue
+		}
+		node := &IssueTreeNode{Issue: &issue, Expanded: true}
+		removed[issue.ID] = node
+		t.tombstones = append(t.tombstones, node)
+		t.diffStatus[issue.ID] = DiffStatusRemoved
+	}
+	for _, node := range t.tombstones {
+		for _, dep := range node.Issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if parent, ok := t.issueMap[dep.DependsOnID]; ok {
+				node.Parent = parent
+			} else if parent, ok := removed[dep.DependsOnID]; ok && parent != node {
+				node.Parent = parent
+			}
+			if node.Parent != nil {
+				break
+			}
+		}
+	}
+	for _, node := range t.tombstones {
+		// Break parent cycles among removed issues by promoting to root.
+		for p, steps := node.Parent, 0; p != nil; p, steps = p.Parent, steps+1 {
+			if p == node || steps > len(t.tombstones) {
+				node.Parent = nil
+				break
+			}
+		}
+	}
+	for _, node := range t.tombstones {
+		if node.Parent == nil {
+			// Copy so a snapshot-shared roots slice is never written through.
+			t.roots = append(t.roots[:len(t.roots):len(t.roots)], node)
+		} else {
+			node.Parent.Children = append(node.Parent.Children[:len(node.Parent.Children):len(node.Parent.Children)], node)
+		}
+	}
+	for _, node := range t.tombstones {
+		for p := node.Parent; p != nil; p = p.Parent {
+			node.Depth++
+		}
+	}
+
+	t.rebuildFlatList()
+	t.ensureCursorVisible()
+}
+
+// ClearDiffHighlight removes diff markers and tombstone rows.
+func (t *TreeModel) ClearDiffHighlight() {
+	t.clearTombstones()
+	t.diffStatus = nil
+	t.rebuildFlatList()
+	t.ensureCursorVisible()
+}
+
+// DiffStatusOf returns the highlighted diff state of an issue.
+func (t *TreeModel) DiffStatusOf(id string) DiffStatus {
+	return t.diffStatus[id]
+}
+
+// clearTombstones detaches tombstone rows from the tree.
+func (t *TreeModel) clearTombstones() {
+	if len(t.tombstones) == 0 {
+		return
+	}
+	isTombstone := make(map[*IssueTreeNode]bool, len(t.tombstones))
+	for _, node := range t.tombstones {
+		isTombstone[node] = true
+	}
+	without := func(nodes []*IssueTreeNode) []*IssueTreeNode {
+		kept := make([]*IssueTreeNode, 0, len(nodes))
+		for _, n := range nodes {
+			if !isTombstone[n] {
+				kept = append(kept, n)
+			}
+		}
+		return kept
+	}
+	t.roots = without(t.roots)
+	for _, node := range t.tombstones {
+		if p := node.Parent; p != nil && !isTombstone[p] {
+			p.Children = without(p.Children)
+		}
+	}
+	t.tombstones = nil
+}
+
+// diffStyle returns the style and marker for a diff state ("" when none).
+func (t *TreeModel) diffStyle(status DiffStatus) (lipgloss.Style, string) {
+	r := t.theme.Renderer
+	switch status {
+	case DiffStatusNew:
+		return r.NewStyle().Foreground(t.theme.Open).Bold(true), "+"
+	case DiffStatusModified:
+		return r.NewStyle().Foreground(t.theme.InProgress).Italic(true), "~"
+	case DiffStatusRemoved:
+		return r.NewStyle().Foreground(t.theme.Muted).Strikethrough(true), "-"
+	}
+	return r.NewStyle(), ""
 }
 
 // buildTreePrefix builds the indentation and branch characters for a node.
 func (t *TreeModel) buildTreePrefix(node *IssueTreeNode) string {
-	if node.Depth == 0 {
+	if node.Depth == 0 || (t.displayParent != nil && t.displayParent[node] == nil) {
 		return "" // Root nodes have no prefix
 	}
 
@@ -655,17 +1679,35 @@ func (t *TreeModel) buildTreePrefix(node *IssueTreeNode) string {
 // The last element is the node - used by buildTreePrefix which iterates to len-1.
 func (t *TreeModel) getAncestors(node *IssueTreeNode) []*IssueTreeNode {
 	var ancestors []*IssueTreeNode
-	current := node.Parent
+	current := t.treeParent(node)
 	for current != nil {
 		ancestors = append([]*IssueTreeNode{current}, ancestors...)
-		current = current.Parent
+		current = t.treeParent(current)
 	}
 	ancestors = append(ancestors, node) // Include the node at the end
 	return ancestors
 }
 
+// treeParent returns the parent node is drawn under: its listed parent while
+// an issue filter is set, otherwise node.Parent.
+func (t *TreeModel) treeParent(node *IssueTreeNode) *IssueTreeNode {
+	if t.displayParent != nil {
+		return t.displayParent[node]
+	}
+	return node.Parent
+}
+
 // hasSiblingsBelow checks if a node has siblings below it in the tree.
 func (t *TreeModel) hasSiblingsBelow(node *IssueTreeNode) bool {
+	if t.displayChildren != nil {
+		siblings := t.displayChildren[t.displayParent[node]]
+		for i, sibling := range siblings {
+			if sibling == node {
+				return i < len(siblings)-1
+			}
+		}
+		return false
+	}
 	if node.Parent == nil {
 		// For root nodes, check if there are more roots after this one
 		for i, root := range t.roots {
@@ -687,6 +1729,10 @@ func (t *TreeModel) hasSiblingsBelow(node *IssueTreeNode) bool {
 
 // isLastChild checks if a node is the last child of its parent.
 func (t *TreeModel) isLastChild(node *IssueTreeNode) bool {
+	if t.displayChildren != nil {
+		siblings := t.displayChildren[t.displayParent[node]]
+		return len(siblings) > 0 && siblings[len(siblings)-1] == node
+	}
 	if node.Parent == nil {
 		// For root nodes, check if it's the last root
 		return len(t.roots) > 0 && t.roots[len(t.roots)-1] == node
@@ -698,27 +1744,31 @@ func (t *TreeModel) isLastChild(node *IssueTreeNode) bool {
 
 // getExpandIndicator returns the expand/collapse indicator for a node.
 func (t *TreeModel) getExpandIndicator(node *IssueTreeNode) string {
-	if len(node.Children) == 0 {
-		return "•" // Leaf node
+	glyphs := t.theme.GlyphSet()
+	if !isExpandable(node) {
+		return glyphs.Leaf
 	}
 	if node.Expanded {
-		return "▾" // Expanded
+		return glyphs.Expanded
 	}
-	return "▸" // Collapsed
+	return glyphs.Collapsed
 }
 
-// truncateTitle truncates a title to the given max length with ellipsis.
+// truncateTitle truncates a title to the given max display width with ellipsis.
+// Width is measured in terminal cells (not bytes or runes) so CJK and emoji
+// titles are never split mid-codepoint and never overflow the row.
 func (t *TreeModel) truncateTitle(title string, maxLen int) string {
 	if maxLen <= 3 {
 		return "..."
 	}
 
-	runes := []rune(title)
-	if len(runes) <= maxLen {
+	if lipgloss.Width(title) <= maxLen {
 		return title
 	}
 
-	return string(runes[:maxLen-1]) + "…"
+	// Reserve one cell for the ellipsis; runewidth.Truncate drops whole runes,
+	// so a double-width rune that would straddle the limit is omitted entirely.
+	return runewidth.Truncate(title, maxLen-1, "") + "…"
 }
 
 // GetPriorityColor returns the color for a priority level.
@@ -772,7 +1822,7 @@ func (t *TreeModel) MoveUp() {
 // ToggleExpand expands or collapses the currently selected node.
 func (t *TreeModel) ToggleExpand() {
 	node := t.SelectedNode()
-	if node != nil && len(node.Children) > 0 {
+	if node != nil && isExpandable(node) {
 		node.Expanded = !node.Expanded
 		t.rebuildFlatList()
 		t.saveState() // Persist expand/collapse state (bv-19vz)
@@ -790,6 +1840,20 @@ func (t *TreeModel) ExpandAll() {
 	t.ensureCursorVisible()
 }
 
+// ExpandSubtree expands the selected node and all of its descendants, leaving
+// the rest of the tree as it was. The cursor stays on the selected node.
+func (t *TreeModel) ExpandSubtree() {
+	node := t.SelectedNode()
+	if node == nil || !isExpandable(node) {
+		return
+	}
+	t.setExpandedRecursive(node, true)
+	t.rebuildFlatList()
+	t.selectNode(node)
+	t.saveState() // Persist expand/collapse state (bv-19vz)
+	t.ensureCursorVisible()
+}
+
 // CollapseAll collapses all nodes in the tree.
 func (t *TreeModel) CollapseAll() {
 	for _, root := range t.roots {
@@ -800,6 +1864,45 @@ func (t *TreeModel) CollapseAll() {
 	t.ensureCursorVisible()
 }
 
+// CollapseCompleted collapses every node whose descendants are all closed,
+// leaving subtrees that still contain open work untouched. Collapsing only
+// hides children, so the node's own status does not matter.
+func (t *TreeModel) CollapseCompleted() {
+	for _, root := range t.roots {
+		t.collapseCompletedRecursive(root)
+	}
+	t.rebuildFlatList()
+	t.saveState() // Persist expand/collapse state (bv-19vz)
+	t.ensureCursorVisible()
+}
+
+// collapseCompletedRecursive performs a bottom-up pass and reports whether the
+// node and all of its descendants are closed.
+func (t *TreeModel) collapseCompletedRecursive(node *IssueTreeNode) bool {
+	if node == nil || node.Issue == nil {
+		return true
+	}
+
+	descendantsDone := true
+	for _, child := range node.Children {
+		// Visit every child so nested completed subtrees collapse too
+		if !t.collapseCompletedRecursive(child) {
+			descendantsDone = false
+		}
+	}
+
+	if len(node.Children) > 0 && descendantsDone {
+		node.Expanded = false
+	}
+
+	return descendantsDone && isIssueDone(node.Issue)
+}
+
+// isIssueDone reports whether an issue needs no further attention.
+func isIssueDone(issue *model.Issue) bool {
+	return issue.Status.IsClosed() || issue.Status.IsTombstone()
+}
+
 // JumpToTop moves cursor to the first node.
 func (t *TreeModel) JumpToTop() {
 	t.cursor = 0
@@ -838,7 +1941,7 @@ func (t *TreeModel) JumpToParent() {
 // - If node is a leaf: do nothing
 func (t *TreeModel) ExpandOrMoveToChild() {
 	node := t.SelectedNode()
-	if node == nil || len(node.Children) == 0 {
+	if node == nil || !isExpandable(node) {
 		return // No node selected or leaf node
 	}
 
@@ -848,7 +1951,7 @@ func (t *TreeModel) ExpandOrMoveToChild() {
 		t.rebuildFlatList()
 		t.saveState() // Persist expand/collapse state (bv-19vz)
 		t.ensureCursorVisible()
-	} else {
+	} else if len(node.Children) > 0 {
 		// Move to first child
 		// Find first child in flatList (should be right after current node)
 		for i, n := range t.flatList {
@@ -870,7 +1973,7 @@ func (t *TreeModel) CollapseOrJumpToParent() {
 		return
 	}
 
-	if len(node.Children) > 0 && node.Expanded {
+	if isExpandable(node) && node.Expanded {
 		// Collapse the node
 		node.Expanded = false
 		t.rebuildFlatList()
@@ -959,6 +2062,106 @@ func (t *TreeModel) SelectByID(id string) bool {
 	return false
 }
 
+// selectNode moves the cursor to node if it is visible.
+func (t *TreeModel) selectNode(node *IssueTreeNode) {
+	for i, n := range t.flatList {
+		if n == node {
+			t.cursor = i
+			return
+		}
+	}
+}
+
+// Find returns the flatList indices of visible nodes whose issue ID or title
+// contains query (case-insensitive). An empty query matches nothing.
+func (t *TreeModel) Find(query string) []int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	for i, node := range t.flatList {
+		if nodeMatchesQuery(node, query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// JumpToMatch moves the cursor to the next node after flatList index
+// startAfter whose issue ID or title contains query (case-insensitive),
+// wrapping around to the top. Nodes inside collapsed subtrees are found too:
+// their ancestors are expanded so the match becomes visible. Nodes hidden for
+// other reasons (closed-hidden, islands, focus, filter) are skipped. Returns
+// the new cursor index, or -1 if nothing matches.
+func (t *TreeModel) JumpToMatch(query string, startAfter int) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return -1
+	}
+
+	// All nodes in display order, so hidden matches can be found.
+	var order []*IssueTreeNode
+	t.walkNodes(func(node *IssueTreeNode) {
+		order = append(order, node)
+	})
+	start := 0
+	if startAfter >= 0 && startAfter < len(t.flatList) {
+		from := t.flatList[startAfter]
+		for i, node := range order {
+			if node == from {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	for k := range order {
+		node := order[(start+k)%len(order)]
+		if !nodeMatchesQuery(node, query) {
+			continue
+		}
+		var opened []*IssueTreeNode
+		for p := node.Parent; p != nil; p = p.Parent {
+			if !p.Expanded {
+				p.Expanded = true
+				opened = append(opened, p)
+			}
+		}
+		if len(opened) > 0 {
+			t.rebuildFlatList()
+		}
+		for i, n := range t.flatList {
+			if n == node {
+				t.cursor = i
+				if len(opened) > 0 {
+					t.saveState() // Persist expand/collapse state (bv-19vz)
+				}
+				t.ensureCursorVisible()
+				return i
+			}
+		}
+		// Still hidden; undo the expansion and keep looking.
+		for _, p := range opened {
+			p.Expanded = false
+		}
+		if len(opened) > 0 {
+			t.rebuildFlatList()
+		}
+	}
+	return -1
+}
+
+// nodeMatchesQuery reports whether node's issue ID or title contains the
+// lowercased query.
+func nodeMatchesQuery(node *IssueTreeNode, query string) bool {
+	if node == nil || node.Issue == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(node.Issue.ID), query) ||
+		strings.Contains(strings.ToLower(node.Issue.Title), query)
+}
+
 // GetSelectedID returns the ID of the currently selected issue, or empty string.
 func (t *TreeModel) GetSelectedID() string {
 	if issue := t.SelectedIssue(); issue != nil {
@@ -978,13 +2181,78 @@ func (t *TreeModel) setExpandedRecursive(node *IssueTreeNode, expanded bool) {
 	}
 }
 
-// rebuildFlatList rebuilds the flattened list of visible nodes.
+// rebuildFlatList rebuilds the flattened list of visible nodes. Any change to
+// the list can move a row's branch characters, which the row cache does not
+// key on, so the cache is dropped here.
 func (t *TreeModel) rebuildFlatList() {
 	t.flatList = t.flatList[:0]
-	for _, root := range t.roots {
-		t.appendVisible(root)
+	t.rowCache = nil
+	t.displayParent, t.displayChildren = nil, nil
+	if t.focusIDs != nil {
+		for _, root := range t.roots {
+			t.appendFocused(root)
+		}
+		t.clampCursor()
+		return
+	}
+	if t.closedStyle == ClosedStyleHidden {
+		t.displayParent = make(map[*IssueTreeNode]*IssueTreeNode)
+		t.displayChildren = make(map[*IssueTreeNode][]*IssueTreeNode)
 	}
-	// Ensure cursor stays in bounds
+	if t.filter != "" {
+		for _, root := range t.roots {
+			if t.hideIslands && t.isIsland(root) {
+				continue
+			}
+			t.appendFiltered(root, nil)
+		}
+		t.clampCursor()
+		return
+	}
+	if t.issueFilter != nil {
+		t.displayParent = make(map[*IssueTreeNode]*IssueTreeNode)
+		t.displayChildren = make(map[*IssueTreeNode][]*IssueTreeNode)
+		keep := make(map[*IssueTreeNode]bool)
+		for _, root := range t.roots {
+			if t.hideIslands && t.isIsland(root) {
+				continue
+			}
+			t.appendIssueFiltered(root, nil, keep)
+		}
+		t.clampCursor()
+		return
+	}
+	roots := t.roots
+	if t.dedupeByTitle {
+		roots = dedupeSiblings(roots)
+	}
+	for _, root := range roots {
+		if t.hideIslands && t.isIsland(root) {
+			continue
+		}
+		t.appendVisible(root, nil)
+	}
+	t.clampCursor()
+}
+
+// listNode appends node to flatList and, while rows are reparented, records
+// parent as the row it is drawn under (nil: a root).
+func (t *TreeModel) listNode(node, parent *IssueTreeNode) {
+	t.flatList = append(t.flatList, node)
+	if t.displayParent != nil {
+		t.displayParent[node] = parent
+		t.displayChildren[parent] = append(t.displayChildren[parent], node)
+	}
+}
+
+// hiddenClosed reports whether node is a closed issue omitted by
+// ClosedStyleHidden.
+func (t *TreeModel) hiddenClosed(node *IssueTreeNode) bool {
+	return t.closedStyle == ClosedStyleHidden && node.Issue != nil && node.Issue.Status == model.StatusClosed
+}
+
+// clampCursor keeps the cursor within flatList.
+func (t *TreeModel) clampCursor() {
 	if t.cursor >= len(t.flatList) {
 		t.cursor = len(t.flatList) - 1
 	}
@@ -993,17 +2261,424 @@ func (t *TreeModel) rebuildFlatList() {
 	}
 }
 
-// appendVisible adds a node and its visible descendants to flatList.
-func (t *TreeModel) appendVisible(node *IssueTreeNode) {
+// appendVisible adds a node and its visible descendants to flatList, under
+// parent.
+func (t *TreeModel) appendVisible(node, parent *IssueTreeNode) {
 	if node == nil {
 		return
 	}
-	t.flatList = append(t.flatList, node)
-	if node.Expanded {
+	if t.hiddenClosed(node) {
+		// Hidden rows cannot be collapsed, so their children always show.
+		for _, child := range node.Children {
+			t.appendVisible(child, parent)
+		}
+		return
+	}
+	t.listNode(node, parent)
+	if !node.Expanded {
+		return
+	}
+	if !t.dedupeByTitle {
 		for _, child := range node.Children {
-			t.appendVisible(child)
+			t.appendVisible(child, node)
+		}
+		return
+	}
+
+	// Children of merged duplicates are shown under the representative row.
+	children := node.Children
+	if len(node.Duplicates) > 0 {
+		children = append([]*IssueTreeNode(nil), node.Children...)
+		for _, dup := range node.Duplicates {
+			children = append(children, dup.Children...)
+		}
+	}
+	for _, child := range dedupeSiblings(children) {
+		t.appendVisible(child, node)
+	}
+}
+
+// SetDedupeByTitle toggles merging of sibling nodes whose normalized titles
+// match. The first sibling in sort order represents the group, shows a "(n)"
+// count, and lists member IDs when expanded. Dedupe never crosses parents, so
+// the hierarchy is preserved.
+func (t *TreeModel) SetDedupeByTitle(enabled bool) {
+	if t.dedupeByTitle == enabled {
+		return
+	}
+	t.dedupeByTitle = enabled
+	if !enabled {
+		for _, node := range t.issueMap {
+			if node != nil {
+				node.Duplicates = nil
+			}
+		}
+	}
+	t.rebuildFlatList()
+	t.ensureCursorVisible()
+}
+
+// SetHideIslands toggles hiding of islands: root issues with no children and
+// no blocking dependencies in either direction. Hiding them keeps the tree on
+// connected work. The selection is kept if it is still visible.
+func (t *TreeModel) SetHideIslands(hide bool) {
+	if t.hideIslands == hide {
+		return
+	}
+	selected := t.SelectedNode()
+	t.hideIslands = hide
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// HideIslands reports whether islands are hidden.
+func (t *TreeModel) HideIslands() bool {
+	return t.hideIslands
+}
+
+// isIsland reports whether node is a childless root that neither depends on
+// nor blocks any other issue in the tree.
+func (t *TreeModel) isIsland(node *IssueTreeNode) bool {
+	if node == nil || node.Issue == nil || len(node.Children) > 0 {
+		return false
+	}
+	for _, dep := range node.Issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if _, ok := t.issueMap[dep.DependsOnID]; ok {
+			return false
 		}
 	}
+	return len(t.dependentsOf(node.Issue.ID)) == 0
+}
+
+// FocusBlockingChain narrows the tree to the selected issue and everything
+// transitively blocking it (its open blockers, per Analyzer.GetBlockerChain),
+// hiding every other row. Rows keep their hierarchy order. ClearFocus restores
+// the full tree. It returns false if nothing is selected.
+func (t *TreeModel) FocusBlockingChain() bool {
+	selected := t.SelectedNode()
+	if selected == nil || selected.Issue == nil {
+		return false
+	}
+
+	issues := make([]model.Issue, 0, len(t.issueMap))
+	for _, node := range t.issueMap {
+		if node != nil && node.Issue != nil {
+			issues = append(issues, *node.Issue)
+		}
+	}
+	chain := analysis.NewAnalyzer(issues).GetBlockerChain(selected.Issue.ID)
+
+	t.focusIDs = map[string]bool{selected.Issue.ID: true}
+	if chain != nil {
+		for _, entry := range chain.Chain {
+			t.focusIDs[entry.ID] = true
+		}
+	}
+	t.rebuildFlatList()
+	t.selectNode(selected)
+	t.ensureCursorVisible()
+	return true
+}
+
+// ClearFocus leaves the blocking-chain focus, keeping the selection.
+func (t *TreeModel) ClearFocus() {
+	if t.focusIDs == nil {
+		return
+	}
+	selected := t.SelectedNode()
+	t.focusIDs = nil
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// IsFocused reports whether the tree is narrowed to a blocking chain.
+func (t *TreeModel) IsFocused() bool {
+	return t.focusIDs != nil
+}
+
+// appendFocused adds the focused nodes under node to flatList in tree order.
+func (t *TreeModel) appendFocused(node *IssueTreeNode) {
+	if node == nil || node.Issue == nil {
+		return
+	}
+	if t.focusIDs[node.Issue.ID] && t.matchesFilter(node.Issue) {
+		t.flatList = append(t.flatList, node)
+	}
+	for _, child := range node.Children {
+		t.appendFocused(child)
+	}
+}
+
+// SetFilter narrows the tree to issues whose ID, title or description
+// contains query (case-insensitive), plus their ancestors so each match keeps
+// its place in the hierarchy. Title dedupe is not applied while filtering. An
+// empty query restores the full tree. The selection is kept if still listed.
+func (t *TreeModel) SetFilter(query string) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if t.filter == query {
+		return
+	}
+	selected := t.SelectedNode()
+	t.filter = query
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// Filter returns the active filter query, lowercased.
+func (t *TreeModel) Filter() string {
+	return t.filter
+}
+
+// matchesFilter reports whether issue matches the active filter.
+func (t *TreeModel) matchesFilter(issue *model.Issue) bool {
+	if t.filter == "" {
+		return true
+	}
+	if issue == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(issue.ID), t.filter) ||
+		strings.Contains(strings.ToLower(issue.Title), t.filter) ||
+		strings.Contains(strings.ToLower(issue.Description), t.filter)
+}
+
+// appendFiltered adds node to flatList under parent if it or any descendant
+// matches the filter, followed by its listed descendants. It reports whether
+// it added anything.
+func (t *TreeModel) appendFiltered(node, parent *IssueTreeNode) bool {
+	if node == nil || node.Issue == nil {
+		return false
+	}
+	if t.hiddenClosed(node) {
+		found := false
+		for _, child := range node.Children {
+			if t.appendFiltered(child, parent) {
+				found = true
+			}
+		}
+		return found
+	}
+	mark := len(t.flatList)
+	siblings := len(t.displayChildren[parent])
+	t.listNode(node, parent)
+	found := t.matchesFilter(node.Issue) && t.passesIssueFilter(node.Issue)
+	for _, child := range node.Children {
+		if t.appendFiltered(child, node) {
+			found = true
+		}
+	}
+	if !found {
+		t.flatList = t.flatList[:mark]
+		if t.displayChildren != nil {
+			t.displayChildren[parent] = t.displayChildren[parent][:siblings]
+			delete(t.displayParent, node)
+		}
+	}
+	return found
+}
+
+// SetIssueFilter lists only issues for which pred returns true, plus the
+// ancestors they need, e.g. to hide closed work. Unlike the text filter, expand
+// state is respected. Title dedupe is not applied while it is set. A nil pred
+// restores the full tree. The selection is kept if still listed.
+func (t *TreeModel) SetIssueFilter(pred func(*model.Issue) bool) {
+	selected := t.SelectedNode()
+	t.issueFilter = pred
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// SetHideEmptyAncestors chooses how SetIssueFilter treats an ancestor that
+// fails the predicate but has passing descendants: shown as a pass-through
+// row (the default), or hidden with its listed descendants drawn under the
+// nearest listed ancestor.
+func (t *TreeModel) SetHideEmptyAncestors(hide bool) {
+	if t.hideEmptyAncestors == hide {
+		return
+	}
+	selected := t.SelectedNode()
+	t.hideEmptyAncestors = hide
+	t.rebuildFlatList()
+	if selected != nil {
+		t.selectNode(selected)
+	}
+	t.ensureCursorVisible()
+}
+
+// HideEmptyAncestors reports whether failing ancestors are hidden.
+func (t *TreeModel) HideEmptyAncestors() bool {
+	return t.hideEmptyAncestors
+}
+
+// passesIssueFilter reports whether issue passes the issue predicate.
+func (t *TreeModel) passesIssueFilter(issue *model.Issue) bool {
+	return t.issueFilter == nil || (issue != nil && t.issueFilter(issue))
+}
+
+// appendIssueFiltered adds node to flatList under the listed parent if it or
+// a descendant passes the issue predicate, followed by its listed
+// descendants. keep memoizes the subtree check across the pass.
+func (t *TreeModel) appendIssueFiltered(node, parent *IssueTreeNode, keep map[*IssueTreeNode]bool) {
+	if node == nil || node.Issue == nil {
+		return
+	}
+	if !t.subtreePasses(node, keep) {
+		return
+	}
+	if t.hiddenClosed(node) || (t.hideEmptyAncestors && !t.passesIssueFilter(node.Issue)) {
+		// Hidden rows cannot be collapsed, so their children always show.
+		for _, child := range node.Children {
+			t.appendIssueFiltered(child, parent, keep)
+		}
+		return
+	}
+	t.listNode(node, parent)
+	if !node.Expanded {
+		return
+	}
+	for _, child := range node.Children {
+		t.appendIssueFiltered(child, node, keep)
+	}
+}
+
+// subtreePasses reports whether node or any descendant passes the issue
+// predicate and is not a hidden closed row, memoized in keep.
+func (t *TreeModel) subtreePasses(node *IssueTreeNode, keep map[*IssueTreeNode]bool) bool {
+	if passes, ok := keep[node]; ok {
+		return passes
+	}
+	passes := !t.hiddenClosed(node) && t.passesIssueFilter(node.Issue)
+	for _, child := range node.Children {
+		if t.subtreePasses(child, keep) {
+			passes = true
+		}
+	}
+	keep[node] = passes
+	return passes
+}
+
+// SetShowBodyPreview toggles a one-line description preview under the
+// selected row, for issues whose titles say less than their bodies.
+func (t *TreeModel) SetShowBodyPreview(show bool) {
+	t.showBodyPreview = show
+}
+
+// ShowBodyPreview reports whether the description preview is shown.
+func (t *TreeModel) ShowBodyPreview() bool {
+	return t.showBodyPreview
+}
+
+// bodyPreviewLine renders the description preview for node, indented under
+// its title, or "" if the issue has no description.
+func (t *TreeModel) bodyPreviewLine(node *IssueTreeNode) string {
+	if node == nil || node.Issue == nil {
+		return ""
+	}
+	body := strings.Join(strings.Fields(node.Issue.Description), " ")
+	if body == "" {
+		return ""
+	}
+	indent := strings.Repeat("  ", node.Depth+2)
+	width := t.width
+	if width <= 0 {
+		width = 80
+	}
+	maxLen := width - lipgloss.Width(indent)
+	if maxLen < 10 {
+		maxLen = 10
+	}
+	return indent + t.theme.Renderer.NewStyle().
+		Foreground(t.theme.Muted).
+		Italic(true).
+		Render(t.truncateTitle(body, maxLen))
+}
+
+// AncestorPath returns the selected node's ancestors from its root down to
+// its parent. It is nil when nothing is selected or the selection is a root.
+func (t *TreeModel) AncestorPath() []*IssueTreeNode {
+	node := t.SelectedNode()
+	if node == nil {
+		return nil
+	}
+	var path []*IssueTreeNode
+	for p := node.Parent; p != nil; p = p.Parent {
+		path = append(path, p)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// breadcrumbLine renders ancestor titles as "Epic A › Feature B", truncated
+// to the view width. It returns "" for an empty path.
+func (t *TreeModel) breadcrumbLine(path []*IssueTreeNode) string {
+	titles := make([]string, 0, len(path))
+	for _, node := range path {
+		if node == nil || node.Issue == nil {
+			continue
+		}
+		titles = append(titles, strings.Join(strings.Fields(node.Issue.Title), " "))
+	}
+	if len(titles) == 0 {
+		return ""
+	}
+	width := t.width
+	if width <= 0 {
+		width = 80
+	}
+	return t.theme.Renderer.NewStyle().
+		Foreground(t.theme.Muted).
+		Render(t.truncateTitle(strings.Join(titles, " › "), width))
+}
+
+// DedupeByTitle reports whether sibling title dedupe is enabled.
+func (t *TreeModel) DedupeByTitle() bool {
+	return t.dedupeByTitle
+}
+
+// dedupeSiblings returns one representative per normalized title, recording
+// the other members in the representative's Duplicates.
+func dedupeSiblings(nodes []*IssueTreeNode) []*IssueTreeNode {
+	reps := make([]*IssueTreeNode, 0, len(nodes))
+	byTitle := make(map[string]*IssueTreeNode, len(nodes))
+	for _, node := range nodes {
+		if node == nil || node.Issue == nil {
+			continue
+		}
+		node.Duplicates = nil
+		key := normalizeTreeTitle(node.Issue.Title)
+		if rep, ok := byTitle[key]; ok && key != "" {
+			rep.Duplicates = append(rep.Duplicates, node)
+			continue
+		}
+		byTitle[key] = node
+		reps = append(reps, node)
+	}
+	return reps
+}
+
+// normalizeTreeTitle folds case and whitespace so near-identical titles match.
+func normalizeTreeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// isExpandable reports whether a node has anything to reveal when expanded.
+func isExpandable(node *IssueTreeNode) bool {
+	return len(node.Children) > 0 || len(node.Duplicates) > 0
 }
 
 // IsBuilt returns whether the tree has been built.
@@ -3,6 +3,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -108,9 +109,11 @@ func BenchmarkTreeBuild(b *testing.B) {
 
 	for _, bm := range benchmarks {
 		b.Run(bm.name, func(b *testing.B) {
+			beadsDir := filepath.Join(b.TempDir(), ".beads")
 			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
 				tree := NewTreeModel(theme)
+				tree.SetBeadsDir(beadsDir)
 				tree.Build(bm.issues)
 			}
 		})
@@ -121,12 +124,14 @@ func BenchmarkTreeBuild(b *testing.B) {
 func BenchmarkTreeBuild1000(b *testing.B) {
 	issues := generateHierarchyIssues(20, 4, 4) // Creates ~1000 issues
 	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	beadsDir := filepath.Join(b.TempDir(), ".beads")
 
 	b.ReportAllocs()
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
 		tree := NewTreeModel(theme)
+		tree.SetBeadsDir(beadsDir)
 		tree.Build(issues)
 	}
 }
@@ -153,6 +158,7 @@ func BenchmarkTreeRender(b *testing.B) {
 		b.Run(bm.name, func(b *testing.B) {
 			issues := generateHierarchyIssues(bm.roots, bm.perNode, bm.depth)
 			tree := NewTreeModel(theme)
+			tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 			tree.Build(issues)
 			tree.SetSize(bm.width, bm.height)
 			tree.ExpandAll()
@@ -173,6 +179,7 @@ func BenchmarkTreeRender100(b *testing.B) {
 	issues := generateHierarchyIssues(20, 3, 3)
 
 	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(120, 40)
 	tree.ExpandAll()
@@ -208,6 +215,7 @@ func BenchmarkFlatListRebuild(b *testing.B) {
 		b.Run(bm.name, func(b *testing.B) {
 			issues := generateHierarchyIssues(bm.count/10, 3, 3)
 			tree := NewTreeModel(theme)
+			tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 			tree.Build(issues)
 			tree.ExpandAll()
 
@@ -227,6 +235,7 @@ func BenchmarkTreeNavigation(b *testing.B) {
 	issues := generateHierarchyIssues(20, 3, 4)
 
 	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.ExpandAll()
 
@@ -283,6 +292,7 @@ func BenchmarkTreeExpandCollapse(b *testing.B) {
 	issues := generateHierarchyIssues(20, 4, 4)
 
 	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	b.Run("ExpandAll", func(b *testing.B) {
@@ -308,6 +318,7 @@ func BenchmarkSelectByID(b *testing.B) {
 	issues := generateHierarchyIssues(20, 4, 4)
 
 	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.ExpandAll()
 
@@ -336,6 +347,7 @@ func BenchmarkBuildTreePrefix(b *testing.B) {
 	issues := generateHierarchyIssues(10, 4, 5) // Deep tree
 
 	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.ExpandAll()
 
@@ -357,3 +369,39 @@ func BenchmarkBuildTreePrefix(b *testing.B) {
 		_ = tree.buildTreePrefix(deepNode)
 	}
 }
+
+// BenchmarkTreeViewAfterMoveDown measures View() after a single cursor move on
+// a fully visible ~2000-node tree, with and without the rendered-row cache.
+func BenchmarkTreeViewAfterMoveDown(b *testing.B) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := generateHierarchyIssues(20, 4, 4) // ~2000 issues
+
+	for _, bm := range []struct {
+		name    string
+		noCache bool
+	}{
+		{"cached", false},
+		{"uncached", true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			tree := NewTreeModel(theme)
+			tree.SetBeadsDir(filepath.Join(b.TempDir(), ".beads"))
+			tree.Build(issues)
+			tree.ExpandAll()
+			tree.SetSize(160, tree.NodeCount()+10)
+			tree.noRowCache = bm.noCache
+			_ = tree.View() // Warm the cache
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				tree.MoveDown()
+				if tree.cursor >= tree.NodeCount()-1 {
+					tree.cursor = 0
+				}
+				_ = tree.View()
+			}
+		})
+	}
+}
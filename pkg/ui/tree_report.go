@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BuildReport lists structural problems found in the issues a tree was built
+// from. "Parent" follows the tree mode: the parent-child target in the
+// hierarchy, the blocker in TreeModeBlocking.
+type BuildReport struct {
+	// OrphanedParentRefs holds the IDs of issues with a parent reference to
+	// an issue that does not exist. Such issues are shown as roots. Sorted.
+	OrphanedParentRefs []string
+	// CycleMembers holds each parent cycle's issue IDs, sorted, with the
+	// cycles ordered by their first ID. The tree cuts each cycle where the
+	// build first revisits an issue.
+	CycleMembers [][]string
+}
+
+// HasProblems reports whether the build found orphans or cycles.
+func (r BuildReport) HasProblems() bool {
+	return len(r.OrphanedParentRefs) > 0 || len(r.CycleMembers) > 0
+}
+
+// LastBuildReport returns the diagnostics for the most recent build. It is
+// computed on first use and cached until the next build.
+func (t *TreeModel) LastBuildReport() BuildReport {
+	if t.report == nil {
+		report := buildTreeReport(t.issues, t.mode)
+		t.report = &report
+	}
+	return *t.report
+}
+
+// isTreeLink reports whether dep makes its target the issue's parent in mode.
+func isTreeLink(mode TreeViewMode, dep *model.Dependency) bool {
+	if dep == nil {
+		return false
+	}
+	if mode == TreeModeBlocking {
+		return dep.Type.IsBlocking()
+	}
+	return dep.Type == model.DepParentChild
+}
+
+// buildTreeReport finds dangling parent references and parent cycles
+// (strongly connected components, via Tarjan's algorithm) in issues.
+func buildTreeReport(issues []model.Issue, mode TreeViewMode) BuildReport {
+	var report BuildReport
+
+	exists := make(map[string]bool, len(issues))
+	for i := range issues {
+		exists[issues[i].ID] = true
+	}
+
+	parents := make(map[string][]string, len(issues))
+	for i := range issues {
+		issue := &issues[i]
+		orphaned := false
+		for _, dep := range issue.Dependencies {
+			if !isTreeLink(mode, dep) {
+				continue
+			}
+			if !exists[dep.DependsOnID] {
+				orphaned = true
+				continue
+			}
+			parents[issue.ID] = append(parents[issue.ID], dep.DependsOnID)
+		}
+		if orphaned {
+			report.OrphanedParentRefs = append(report.OrphanedParentRefs, issue.ID)
+		}
+	}
+	sort.Strings(report.OrphanedParentRefs)
+
+	index := make(map[string]int, len(issues))
+	lowlink := make(map[string]int, len(issues))
+	onStack := make(map[string]bool)
+	var stack []string
+	next := 0
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		index[id] = next
+		lowlink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, p := range parents[id] {
+			if p == id {
+				selfLoop = true
+			}
+			if _, seen := index[p]; !seen {
+				strongConnect(p)
+				lowlink[id] = min(lowlink[id], lowlink[p])
+			} else if onStack[p] {
+				lowlink[id] = min(lowlink[id], index[p])
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+		var members []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == id {
+				break
+			}
+		}
+		if len(members) > 1 || selfLoop {
+			sort.Strings(members)
+			report.CycleMembers = append(report.CycleMembers, members)
+		}
+	}
+
+	for i := range issues {
+		if _, seen := index[issues[i].ID]; !seen {
+			strongConnect(issues[i].ID)
+		}
+	}
+	sort.Slice(report.CycleMembers, func(i, j int) bool {
+		return report.CycleMembers[i][0] < report.CycleMembers[j][0]
+	})
+	return report
+}
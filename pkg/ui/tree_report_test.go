@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestTreeLastBuildReport(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "root", Title: "Root", Priority: 1, IssueType: model.TypeEpic},
+		{ID: "orphan", Title: "Orphan", Priority: 2, IssueType: model.TypeTask, Dependencies: childOf("orphan", "missing")},
+		{ID: "half", Title: "Half orphan", Priority: 2, IssueType: model.TypeTask, Dependencies: childOf("half", "root", "gone")},
+		{ID: "cyc-a", Title: "Cycle A", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("cyc-a", "cyc-b")},
+		{ID: "cyc-b", Title: "Cycle B", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("cyc-b", "cyc-c")},
+		{ID: "cyc-c", Title: "Cycle C", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("cyc-c", "cyc-a")},
+		{ID: "self", Title: "Self", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("self", "self")},
+		// Blocking edges are not parent links in the hierarchy
+		{ID: "blk", Title: "Blocked", Priority: 1, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "blk", DependsOnID: "absent", Type: model.DepBlocks}}},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+
+	report := tree.LastBuildReport()
+	if !report.HasProblems() {
+		t.Fatal("expected orphans and cycles to be reported")
+	}
+	if got, want := fmt.Sprint(report.OrphanedParentRefs), "[half orphan]"; got != want {
+		t.Errorf("OrphanedParentRefs = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(report.CycleMembers), "[[cyc-a cyc-b cyc-c] [self]]"; got != want {
+		t.Errorf("CycleMembers = %s, want %s", got, want)
+	}
+
+	// In blocking mode the missing blocker is the dangling reference
+	tree.SetMode(TreeModeBlocking)
+	if got, want := fmt.Sprint(tree.LastBuildReport().OrphanedParentRefs), "[blk]"; got != want {
+		t.Errorf("blocking-mode OrphanedParentRefs = %s, want %s", got, want)
+	}
+
+	tree.SetMode(TreeModeHierarchy)
+	tree.Build(issues[:1])
+	if report := tree.LastBuildReport(); report.HasProblems() {
+		t.Errorf("report should be reset by a clean build, got %+v", report)
+	}
+}
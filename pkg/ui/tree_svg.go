@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	svg "github.com/ajstarks/svgo"
+)
+
+// SVG layout, in pixels.
+const (
+	treeSVGNodeW  = 180
+	treeSVGNodeH  = 44
+	treeSVGHGap   = 20 // Between sibling boxes
+	treeSVGVGap   = 40 // Between depth levels
+	treeSVGMargin = 20
+)
+
+// treeSVGStatusFills are the box fills ExportSVG falls back to when the theme
+// has no color for a status, as with NoColorTheme.
+var treeSVGStatusFills = map[model.Status]string{
+	model.StatusOpen:       "#c8e6c9",
+	model.StatusInProgress: "#b3e5fc",
+	model.StatusBlocked:    "#ffcdd2",
+	model.StatusClosed:     "#e0e0e0",
+}
+
+// treeSVGDefaultFill fills boxes whose status has neither a theme color nor an
+// entry in treeSVGStatusFills.
+const treeSVGDefaultFill = "#f5f5f5"
+
+// treeSVGNode is a node placed by ExportSVG's layout.
+type treeSVGNode struct {
+	node     *IssueTreeNode
+	x, y     int // Top-left corner of the box
+	children []*treeSVGNode
+}
+
+// ExportSVG writes the whole hierarchy as a standalone SVG: one box per issue,
+// laid out top-down by depth, with lines from each parent to its children and
+// boxes filled with the theme's status colors. Every node is drawn regardless
+// of expand state; diff tombstones are left out.
+func (t *TreeModel) ExportSVG(w io.Writer) error {
+	skip := make(map[*IssueTreeNode]bool, len(t.tombstones))
+	for _, node := range t.tombstones {
+		skip[node] = true
+	}
+
+	// Leaves take successive columns; parents are centered over their children.
+	nextColumn := 0
+	maxDepth := 0
+	var place func(node *IssueTreeNode, depth int) *treeSVGNode
+	place = func(node *IssueTreeNode, depth int) *treeSVGNode {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		placed := &treeSVGNode{
+			node: node,
+			y:    treeSVGMargin + depth*(treeSVGNodeH+treeSVGVGap),
+		}
+		for _, child := range node.Children {
+			if child == nil || child.Issue == nil || skip[child] {
+				continue
+			}
+			placed.children = append(placed.children, place(child, depth+1))
+		}
+		if len(placed.children) == 0 {
+			placed.x = treeSVGMargin + nextColumn*(treeSVGNodeW+treeSVGHGap)
+			nextColumn++
+		} else {
+			first, last := placed.children[0], placed.children[len(placed.children)-1]
+			placed.x = (first.x + last.x) / 2
+		}
+		return placed
+	}
+
+	var roots []*treeSVGNode
+	for _, root := range t.roots {
+		if root == nil || root.Issue == nil || skip[root] {
+			continue
+		}
+		roots = append(roots, place(root, 0))
+	}
+
+	width := 2 * treeSVGMargin
+	height := 2 * treeSVGMargin
+	if nextColumn > 0 {
+		width += nextColumn*(treeSVGNodeW+treeSVGHGap) - treeSVGHGap
+		height += (maxDepth+1)*(treeSVGNodeH+treeSVGVGap) - treeSVGVGap
+	}
+
+	ew := &errWriter{w: w}
+	canvas := svg.New(ew)
+	canvas.Start(width, height)
+	canvas.Rect(0, 0, width, height, "fill:#ffffff")
+
+	var draw func(n *treeSVGNode)
+	draw = func(n *treeSVGNode) {
+		for _, child := range n.children {
+			canvas.Line(n.x+treeSVGNodeW/2, n.y+treeSVGNodeH, child.x+treeSVGNodeW/2, child.y,
+				"stroke:#888888;stroke-width:1.5")
+		}
+
+		issue := n.node.Issue
+		fill := t.svgStatusFill(issue.Status)
+		canvas.Roundrect(n.x, n.y, treeSVGNodeW, treeSVGNodeH, 6, 6,
+			fmt.Sprintf("fill:%s;stroke:#333333;stroke-width:1", fill))
+		canvas.Text(n.x+8, n.y+18, issue.ID,
+			"fill:#111111;font-size:12px;font-family:monospace;font-weight:bold")
+		canvas.Text(n.x+8, n.y+35, truncate(issue.Title, 24),
+			"fill:#111111;font-size:11px;font-family:monospace")
+
+		for _, child := range n.children {
+			draw(child)
+		}
+	}
+	for _, root := range roots {
+		draw(root)
+	}
+
+	canvas.End()
+	return ew.err
+}
+
+// svgStatusFill is the theme's light status color, or the fixed fallback
+// palette when the theme leaves it empty.
+func (t *TreeModel) svgStatusFill(status model.Status) string {
+	if fill := t.theme.GetStatusColor(string(status)).Light; fill != "" {
+		return fill
+	}
+	if fill, ok := treeSVGStatusFills[status]; ok {
+		return fill
+	}
+	return treeSVGDefaultFill
+}
+
+// errWriter remembers the first write error so callers of writers that do not
+// report errors (like svgo) can still surface it.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTreeExportSVG(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic <one>", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "feat", Title: "Feature", Priority: 1, IssueType: model.TypeFeature, Status: model.StatusInProgress, CreatedAt: now, Dependencies: childOf("feat", "epic")},
+		{ID: "task", Title: "Task", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("task", "feat")},
+		{ID: "loose", Title: "Loose", Priority: 2, IssueType: model.TypeTask, Status: model.StatusBlocked, CreatedAt: now},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.CollapseAll() // Export ignores expand state
+
+	var buf bytes.Buffer
+	if err := tree.ExportSVG(&buf); err != nil {
+		t.Fatalf("ExportSVG failed: %v", err)
+	}
+
+	rects, lines, texts := 0, 0, 0
+	decoder := xml.NewDecoder(&buf)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("output is not valid XML: %v", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "rect":
+				rects++
+			case "line":
+				lines++
+			case "text":
+				texts++
+			}
+		}
+	}
+
+	// One background rect plus one box per issue.
+	if rects != len(issues)+1 {
+		t.Errorf("expected %d rects, got %d", len(issues)+1, rects)
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 branch lines, got %d", lines)
+	}
+	if texts != 2*len(issues) {
+		t.Errorf("expected %d text elements, got %d", 2*len(issues), texts)
+	}
+}
+
+func TestTreeExportSVGEmpty(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	var buf bytes.Buffer
+	if err := tree.ExportSVG(&buf); err != nil {
+		t.Fatalf("ExportSVG failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "</svg>") {
+		t.Error("expected a complete SVG document")
+	}
+}
+
+func TestTreeExportSVGNoColorTheme(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "open", Title: "Open", IssueType: model.TypeTask, Status: model.StatusOpen},
+		{ID: "closed", Title: "Closed", IssueType: model.TypeTask, Status: model.StatusClosed},
+		{ID: "odd", Title: "Custom status", IssueType: model.TypeTask, Status: "review"},
+	}
+
+	tree := NewTreeModel(NoColorTheme(lipgloss.NewRenderer(io.Discard)))
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+
+	var buf bytes.Buffer
+	if err := tree.ExportSVG(&buf); err != nil {
+		t.Fatalf("ExportSVG failed: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "fill:;") {
+		t.Error("expected every box to have a fill, got an empty fill")
+	}
+	for _, fill := range []string{treeSVGStatusFills[model.StatusOpen], treeSVGStatusFills[model.StatusClosed], treeSVGDefaultFill} {
+		if !strings.Contains(out, "fill:"+fill+";") {
+			t.Errorf("expected fallback fill %s in output", fill)
+		}
+	}
+}
@@ -1,25 +1,50 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func newTreeTestTheme() Theme {
 	return DefaultTheme(lipgloss.NewRenderer(nil))
 }
 
+// childOf returns id's parent-child dependencies on each of parents.
+func childOf(id string, parents ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, parent := range parents {
+		deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: parent, Type: model.DepParentChild})
+	}
+	return deps
+}
+
+// blockedBy returns id's blocking dependencies on each of blockers.
+func blockedBy(id string, blockers ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, blocker := range blockers {
+		deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: blocker, Type: model.DepBlocks})
+	}
+	return deps
+}
+
 // TestTreeBuildEmpty verifies Build() handles empty issues slice
 func TestTreeBuildEmpty(t *testing.T) {
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(nil)
 
 	if !tree.IsBuilt() {
@@ -42,6 +67,7 @@ func TestTreeBuildNoHierarchy(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	if tree.RootCount() != 3 {
@@ -72,6 +98,7 @@ func TestTreeBuildParentChild(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Should have 1 root (epic-1)
@@ -116,6 +143,7 @@ func TestTreeBuildOrphanParent(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// orphan-1 declares a parent that doesn't exist in the issue set.
@@ -152,6 +180,7 @@ func TestTreeBuildCycleDetection(t *testing.T) {
 
 	// This should not hang or panic
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Both issues have parents, so neither is a root in the normal sense
@@ -183,6 +212,7 @@ func TestTreeBuildChildSorting(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	if tree.RootCount() != 1 {
@@ -216,6 +246,7 @@ func TestTreeBuildBlockingDepsIgnored(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Blocking deps shouldn't create hierarchy - both should be roots
@@ -237,6 +268,7 @@ func TestTreeBuildRelatedDepsIgnored(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Related deps shouldn't create hierarchy - both should be roots
@@ -258,6 +290,7 @@ func TestTreeNavigation(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Initial selection should be first node (root-1)
@@ -308,6 +341,7 @@ func TestTreeExpandCollapse(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Initially auto-expanded (depth < 2)
@@ -340,161 +374,1464 @@ func TestTreeExpandCollapse(t *testing.T) {
 	}
 }
 
-// TestTreeIssueMap verifies the issueMap lookup is populated
-func TestTreeIssueMap(t *testing.T) {
+// TestTreeCollapseCompleted verifies only fully closed subtrees are collapsed
+func TestTreeCollapseCompleted(t *testing.T) {
+	now := time.Now()
 	issues := []model.Issue{
-		{ID: "test-1", Title: "Test 1", Priority: 1, IssueType: model.TypeTask},
-		{ID: "test-2", Title: "Test 2", Priority: 2, IssueType: model.TypeTask},
+		{ID: "done-epic", Title: "Done Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusClosed, CreatedAt: now},
+		{ID: "done-1", Title: "Done 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("done-1", "done-epic")},
+		{ID: "done-2", Title: "Done 2", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("done-2", "done-epic")},
+		{ID: "open-epic", Title: "Open Epic", Priority: 2, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "open-1", Title: "Open 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("open-1", "open-epic")},
+		{ID: "open-2", Title: "Open 2", Priority: 1, IssueType: model.TypeTask, Status: model.StatusInProgress, CreatedAt: now, Dependencies: childOf("open-2", "open-epic")},
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
-	// Verify issueMap contains all nodes
-	if len(tree.issueMap) != 2 {
-		t.Errorf("expected issueMap to have 2 entries, got %d", len(tree.issueMap))
+	if tree.NodeCount() != 6 {
+		t.Fatalf("expected 6 visible nodes before collapse, got %d", tree.NodeCount())
 	}
 
-	if _, ok := tree.issueMap["test-1"]; !ok {
-		t.Error("expected test-1 in issueMap")
+	tree.CollapseCompleted()
+
+	if tree.issueMap["done-epic"].Expanded {
+		t.Error("expected done-epic to be collapsed")
 	}
-	if _, ok := tree.issueMap["test-2"]; !ok {
-		t.Error("expected test-2 in issueMap")
+	if !tree.issueMap["open-epic"].Expanded {
+		t.Error("expected open-epic to remain expanded")
+	}
+	if tree.NodeCount() != 4 {
+		t.Errorf("expected 4 visible nodes after CollapseCompleted, got %d", tree.NodeCount())
 	}
 }
 
-// TestIssueTypeOrder verifies the ordering of issue types
-func TestIssueTypeOrder(t *testing.T) {
-	tests := []struct {
-		issueType model.IssueType
-		expected  int
-	}{
-		{model.TypeEpic, 0},
-		{model.TypeFeature, 1},
-		{model.TypeTask, 2},
-		{model.TypeBug, 3},
-		{model.TypeChore, 4},
-		{"unknown", 5},
+func TestTreeExpandSubtree(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic-a", Title: "Epic A", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "a-1", Title: "A 1", Priority: 1, IssueType: model.TypeFeature, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("a-1", "epic-a")},
+		{ID: "a-1-1", Title: "A 1 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("a-1-1", "a-1")},
+		{ID: "epic-b", Title: "Epic B", Priority: 2, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "b-1", Title: "B 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("b-1", "epic-b")},
 	}
 
-	for _, tt := range tests {
-		got := issueTypeOrder(tt.issueType)
-		if got != tt.expected {
-			t.Errorf("issueTypeOrder(%s) = %d, want %d", tt.issueType, got, tt.expected)
-		}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.CollapseAll()
+	if tree.NodeCount() != 2 {
+		t.Fatalf("expected 2 visible roots after CollapseAll, got %d", tree.NodeCount())
+	}
+
+	if !tree.SelectByID("epic-a") {
+		t.Fatal("epic-a should be selectable")
+	}
+	tree.ExpandSubtree()
+
+	if got := tree.GetSelectedID(); got != "epic-a" {
+		t.Errorf("cursor moved to %q, want epic-a", got)
+	}
+	if !tree.SelectByID("a-1-1") {
+		t.Error("deep descendant a-1-1 should be visible after ExpandSubtree")
+	}
+	if tree.SelectByID("b-1") || tree.issueMap["epic-b"].Expanded {
+		t.Error("unrelated epic-b should stay collapsed")
+	}
+	if tree.NodeCount() != 4 {
+		t.Errorf("expected 4 visible nodes, got %d", tree.NodeCount())
 	}
 }
 
-// TestTreeViewEmpty verifies View() output for empty tree
-func TestTreeViewEmpty(t *testing.T) {
+func TestTreeHideIslands(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "a", Title: "A", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "a", DependsOnID: "b", Type: model.DepBlocks}}},
+		{ID: "b", Title: "B", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "island", Title: "Island", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now},
+	}
+
 	tree := NewTreeModel(newTreeTestTheme())
-	tree.Build(nil)
-	tree.SetSize(80, 20)
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	if tree.NodeCount() != 3 {
+		t.Fatalf("expected 3 visible nodes, got %d", tree.NodeCount())
+	}
 
-	view := tree.View()
-	if !strings.Contains(view, "No issues to display") {
-		t.Errorf("expected empty state message, got:\n%s", view)
+	tree.SetHideIslands(true)
+	if tree.NodeCount() != 2 {
+		t.Errorf("expected 2 visible nodes with islands hidden, got %d", tree.NodeCount())
 	}
-	if !strings.Contains(view, "Press E to return") {
-		t.Errorf("expected return hint in empty state, got:\n%s", view)
+	if tree.SelectByID("island") {
+		t.Error("island should be hidden")
+	}
+	if !tree.SelectByID("a") || !tree.SelectByID("b") {
+		t.Error("connected issues should stay visible")
+	}
+
+	tree.SetHideIslands(false)
+	if !tree.SelectByID("island") {
+		t.Error("island should reappear when hiding is turned off")
 	}
 }
 
-// TestTreeViewRendering verifies View() renders tree structure correctly
-func TestTreeViewRendering(t *testing.T) {
+func TestTreeSortRootsByRisk(t *testing.T) {
 	now := time.Now()
 	issues := []model.Issue{
-		{ID: "epic-1", Title: "Epic Issue", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
-		{
-			ID: "task-1", Title: "Task under Epic", Priority: 2, IssueType: model.TypeTask, Status: model.StatusInProgress, CreatedAt: now.Add(time.Hour),
-			Dependencies: []*model.Dependency{{IssueID: "task-1", DependsOnID: "epic-1", Type: model.DepParentChild}},
-		},
+		{ID: "calm", Title: "Calm Epic", Priority: 0, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "calm-1", Title: "Calm 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("calm-1", "calm")},
+		{ID: "risky", Title: "Risky Epic", Priority: 2, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "risky-1", Title: "Risky 1", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("risky-1", "risky")},
+		{ID: "risky-2", Title: "Risky 2", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("risky-2", "risky")},
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
-	tree.SetSize(100, 30)
+	if got := tree.roots[0].Issue.ID; got != "calm" {
+		t.Fatalf("expected P0 epic first by default, got %s", got)
+	}
+	childOrder := tree.issueMap["risky"].Children[0].Issue.ID
 
-	view := tree.View()
+	tree.SortRootsByRisk(map[string]float64{"calm": 0.2, "calm-1": 0.1, "risky-1": 0.9})
 
-	// Should contain both issue IDs
-	if !strings.Contains(view, "epic-1") {
-		t.Errorf("expected epic-1 in view, got:\n%s", view)
+	if got := tree.roots[0].Issue.ID; got != "risky" {
+		t.Errorf("expected risky epic first, got %s", got)
 	}
-	if !strings.Contains(view, "task-1") {
-		t.Errorf("expected task-1 in view, got:\n%s", view)
+	if got := tree.flatList[0].Issue.ID; got != "risky" {
+		t.Errorf("expected risky epic at top of visible list, got %s", got)
+	}
+	if got := tree.issueMap["risky"].Children[0].Issue.ID; got != childOrder {
+		t.Errorf("child order changed: first child %s, want %s", got, childOrder)
 	}
+	if got := tree.GetSelectedID(); got != "calm" {
+		t.Errorf("selection should follow the node, got %s", got)
+	}
+}
 
-	// Should contain titles
-	if !strings.Contains(view, "Epic Issue") {
-		t.Errorf("expected 'Epic Issue' in view, got:\n%s", view)
+func TestTreeFocusBlockingChain(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "target", Title: "Target", Priority: 1, IssueType: model.TypeTask, Status: model.StatusBlocked, CreatedAt: now, Dependencies: blockedBy("target", "b1")},
+		{ID: "b1", Title: "Blocker 1", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: blockedBy("b1", "b2")},
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "f1", Title: "Feature", Priority: 1, IssueType: model.TypeFeature, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("f1", "epic")},
+		{ID: "f2", Title: "Sub-feature", Priority: 1, IssueType: model.TypeFeature, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("f2", "f1")},
+		{ID: "b2", Title: "Blocker 2", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: append(childOf("b2", "f2"), blockedBy("b2", "done")...)},
+		{ID: "done", Title: "Done", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now},
+		{ID: "other", Title: "Other", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now},
 	}
 
-	// Should contain tree characters (for child node)
-	if !strings.Contains(view, "└") && !strings.Contains(view, "├") {
-		t.Errorf("expected tree branch characters in view, got:\n%s", view)
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	fullCount := tree.NodeCount()
+	if tree.SelectByID("b2") {
+		t.Fatal("b2 should start hidden under a collapsed sub-feature")
 	}
 
-	// Should contain expand/collapse indicators
-	if !strings.Contains(view, "▾") && !strings.Contains(view, "▸") && !strings.Contains(view, "•") {
-		t.Errorf("expected expand/collapse indicators in view, got:\n%s", view)
+	if !tree.SelectByID("target") || !tree.FocusBlockingChain() {
+		t.Fatal("expected to focus the blocking chain of target")
+	}
+	if !tree.IsFocused() {
+		t.Error("IsFocused should report the focus")
+	}
+	visible := make(map[string]bool)
+	for _, node := range tree.flatList {
+		visible[node.Issue.ID] = true
+	}
+	if got, want := fmt.Sprint(visible), "map[b1:true b2:true target:true]"; got != want {
+		t.Errorf("visible = %s, want the transitive open blocking chain %s", got, want)
+	}
+	if got := tree.GetSelectedID(); got != "target" {
+		t.Errorf("selection should stay on target, got %s", got)
+	}
+
+	tree.ClearFocus()
+	if tree.IsFocused() || tree.NodeCount() != fullCount {
+		t.Errorf("ClearFocus should restore %d rows, got %d", fullCount, tree.NodeCount())
+	}
+	if got := tree.GetSelectedID(); got != "target" {
+		t.Errorf("selection should survive ClearFocus, got %s", got)
 	}
 }
 
-// TestTreeViewIndicators verifies expand/collapse indicators
-func TestTreeViewIndicators(t *testing.T) {
+func TestTreeClosedStyle(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "done", Title: "Finished work", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("done", "epic")},
+	}
+
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.ANSI)
+	tree := NewTreeModel(DefaultTheme(renderer))
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(120, 20)
+	tree.Build(issues)
+
+	done := tree.issueMap["done"]
+	if row := tree.renderNode(done, false); strings.Contains(row, "\x1b[9m") {
+		t.Fatalf("closed issue should not be struck through by default: %q", row)
+	}
+
+	tree.SetClosedStyle(ClosedStyleStrikethrough)
+	if row := tree.renderNode(done, false); !strings.Contains(row, "\x1b[9mF\x1b[0m\x1b[9mi") {
+		t.Errorf("expected struck-through title, got %q", row)
+	}
+	if row := tree.renderNode(tree.issueMap["epic"], false); strings.Contains(row, "\x1b[9m") {
+		t.Errorf("open issue should not be struck through: %q", row)
+	}
+
+	tree.SetClosedStyle(ClosedStyleHidden)
+	if tree.NodeCount() != 1 || tree.SelectByID("done") {
+		t.Errorf("closed row should be hidden, got %d rows", tree.NodeCount())
+	}
+	if !tree.SelectByID("epic") {
+		t.Error("parent of a hidden closed child should still show")
+	}
+
+	tree.SetClosedStyle(ClosedStyleNormal)
+	if tree.NodeCount() != 2 {
+		t.Errorf("expected both rows back, got %d", tree.NodeCount())
+	}
+}
+
+func TestTreeClosedStyleHiddenReparentsOpenDescendants(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "done", Title: "Finished feature", Priority: 1, IssueType: model.TypeFeature, Status: model.StatusClosed, CreatedAt: now, Dependencies: childOf("done", "epic")},
+		{ID: "open", Title: "Follow-up", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("open", "done")},
+		{ID: "old", Title: "Old epic", Priority: 2, IssueType: model.TypeEpic, Status: model.StatusClosed, CreatedAt: now},
+		{ID: "stray", Title: "Leftover", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now, Dependencies: childOf("stray", "old")},
+	}
+
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(120, 20)
+	tree.Build(issues)
+	tree.SetClosedStyle(ClosedStyleHidden)
+
+	ids := func() []string {
+		var out []string
+		for _, node := range tree.flatList {
+			out = append(out, node.Issue.ID)
+		}
+		return out
+	}
+	if got := fmt.Sprint(ids()); got != "[epic open stray]" {
+		t.Fatalf("visible rows = %s, want the open issues [epic open stray]", got)
+	}
+	if parent := tree.treeParent(tree.issueMap["open"]); parent != tree.issueMap["epic"] {
+		t.Errorf("open child of a hidden issue should be drawn under epic, got %v", parent)
+	}
+	if parent := tree.treeParent(tree.issueMap["stray"]); parent != nil {
+		t.Errorf("open child of a hidden root should be drawn as a root, got %v", parent)
+	}
+	if prefix := tree.buildTreePrefix(tree.issueMap["open"]); !strings.Contains(prefix, "└── ") || lipgloss.Width(prefix) != 8 {
+		t.Errorf("reparented child should be drawn one level under epic, got prefix %q", prefix)
+	}
+	if prefix := tree.buildTreePrefix(tree.issueMap["stray"]); prefix != "" {
+		t.Errorf("reparented root should have no prefix, got %q", prefix)
+	}
 
-	// Test leaf node indicator
-	leafNode := &IssueTreeNode{
-		Issue:    &model.Issue{ID: "leaf"},
-		Children: nil,
+	tree.SetFilter("follow")
+	if got := fmt.Sprint(ids()); got != "[epic open]" {
+		t.Errorf("filtered rows = %s, want [epic open]", got)
 	}
-	if got := tree.getExpandIndicator(leafNode); got != "•" {
-		t.Errorf("leaf indicator = %q, want %q", got, "•")
+	if parent := tree.treeParent(tree.issueMap["open"]); parent != tree.issueMap["epic"] {
+		t.Errorf("filtered open child should stay under epic, got %v", parent)
 	}
 
-	// Test expanded node indicator
-	expandedNode := &IssueTreeNode{
-		Issue:    &model.Issue{ID: "expanded"},
-		Children: []*IssueTreeNode{{Issue: &model.Issue{ID: "child"}}},
-		Expanded: true,
+	tree.SetFilter("")
+	tree.SetClosedStyle(ClosedStyleNormal)
+	if parent := tree.treeParent(tree.issueMap["open"]); parent != tree.issueMap["done"] {
+		t.Errorf("open child should be back under done, got %v", parent)
+	}
+}
+
+func TestTreeCustomStatus(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "rev", Title: "Awaiting review", Priority: 1, IssueType: model.TypeTask, Status: "in-review", CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "rev", DependsOnID: "epic", Type: model.DepParentChild}}},
 	}
-	if got := tree.getExpandIndicator(expandedNode); got != "▾" {
-		t.Errorf("expanded indicator = %q, want %q", got, "▾")
+	for _, issue := range issues {
+		if err := issue.Validate(); err != nil {
+			t.Fatalf("custom status should validate: %v", err)
+		}
 	}
 
-	// Test collapsed node indicator
-	collapsedNode := &IssueTreeNode{
-		Issue:    &model.Issue{ID: "collapsed"},
-		Children: []*IssueTreeNode{{Issue: &model.Issue{ID: "child"}}},
-		Expanded: false,
+	theme := newTreeTestTheme()
+	theme.SetStatusStyle("in-review", StatusStyle{Color: theme.Primary, Glyph: "R"})
+	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(120, 20)
+	tree.Build(issues)
+
+	if !tree.SelectByID("rev") {
+		t.Fatal("in-review issue should be listed under its epic")
 	}
-	if got := tree.getExpandIndicator(collapsedNode); got != "▸" {
-		t.Errorf("collapsed indicator = %q, want %q", got, "▸")
+	if row := tree.renderNode(tree.issueMap["rev"], false); !strings.HasSuffix(row, " R") {
+		t.Errorf("expected the registered in-review glyph at the end of the row, got %q", row)
 	}
 }
 
-// TestTreeTruncateTitle verifies title truncation
-func TestTreeTruncateTitle(t *testing.T) {
+func TestTreeFilterMatchesDescription(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "fix", Title: "Fix it", Description: "Login fails when the session token expires", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "fix", DependsOnID: "epic", Type: model.DepParentChild}}},
+		{ID: "misc", Title: "Misc cleanup", Description: "Remove dead code", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now},
+	}
+
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(80, 20)
+	tree.Build(issues)
+	tree.CollapseAll()
 
-	tests := []struct {
-		title  string
-		maxLen int
-		want   string
-	}{
-		{"Short", 20, "Short"},
-		{"This is a very long title that should be truncated", 20, "This is a very long…"},
-		{"ABC", 3, "..."},
-		{"A", 10, "A"},
+	// "token" appears only in fix's description; its collapsed parent is
+	// listed too so the match keeps its place in the hierarchy.
+	tree.SetFilter("Session TOKEN")
+	var ids []string
+	for _, node := range tree.flatList {
+		ids = append(ids, node.Issue.ID)
+	}
+	if got, want := fmt.Sprint(ids), "[epic fix]"; got != want {
+		t.Fatalf("filtered rows = %s, want %s", got, want)
 	}
 
-	for _, tt := range tests {
-		got := tree.truncateTitle(tt.title, tt.maxLen)
-		if got != tt.want {
-			t.Errorf("truncateTitle(%q, %d) = %q, want %q", tt.title, tt.maxLen, got, tt.want)
+	if !tree.SelectByID("fix") {
+		t.Fatal("fix should be selectable while filtered")
+	}
+	if strings.Contains(tree.View(), "Login fails") {
+		t.Error("description preview should be off by default")
+	}
+	tree.SetShowBodyPreview(true)
+	if !strings.Contains(tree.View(), "Login fails when the session token expires") {
+		t.Error("expected the description preview under the selected row")
+	}
+
+	tree.SetFilter("no such text")
+	if !strings.Contains(tree.View(), "No issues match") {
+		t.Error("expected the no-match message")
+	}
+
+	tree.SetFilter("")
+	if got := tree.NodeCount(); got != 2 {
+		t.Errorf("clearing the filter should restore the collapsed tree, got %d rows", got)
+	}
+}
+
+func TestTreeAncestorBreadcrumb(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic A", Priority: 1, IssueType: model.TypeEpic, CreatedAt: now},
+		{ID: "feat", Title: "Feature B", Priority: 1, IssueType: model.TypeFeature, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "feat", DependsOnID: "epic", Type: model.DepParentChild}}},
+		{ID: "task", Title: "Task C", Priority: 1, IssueType: model.TypeTask, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "task", DependsOnID: "feat", Type: model.DepParentChild}}},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(80, 3)
+	tree.Build(issues)
+	tree.ExpandAll()
+
+	if path := tree.AncestorPath(); path != nil {
+		t.Errorf("root selection should have no ancestors, got %d", len(path))
+	}
+	if strings.Contains(tree.View(), "›") {
+		t.Error("no breadcrumb expected for a root selection")
+	}
+
+	if !tree.SelectByID("task") {
+		t.Fatal("task should be selectable")
+	}
+	var ids []string
+	for _, node := range tree.AncestorPath() {
+		ids = append(ids, node.Issue.ID)
+	}
+	if got, want := fmt.Sprint(ids), "[epic feat]"; got != want {
+		t.Fatalf("AncestorPath = %s, want %s", got, want)
+	}
+
+	// The breadcrumb is the first line and takes a row of the full window
+	lines := strings.Split(strings.TrimRight(tree.View(), "\n"), "\n")
+	if got := lines[0]; !strings.Contains(got, "Epic A › Feature B") {
+		t.Errorf("first line = %q, want the breadcrumb", got)
+	}
+	if len(lines) != 3 {
+		t.Errorf("View has %d lines, want 3 for height 3", len(lines))
+	}
+	if !strings.Contains(tree.View(), "Task C") {
+		t.Error("selected row should stay in view below the breadcrumb")
+	}
+
+	tree.SetSize(14, 3)
+	crumb := tree.breadcrumbLine(tree.AncestorPath())
+	if !strings.Contains(crumb, "Epic A › Feat…") || lipgloss.Width(crumb) != 14 {
+		t.Errorf("breadcrumb = %q, want it truncated to 14 cells", crumb)
+	}
+}
+
+func TestTreeSelectionStyleSpansRow(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "bug", Title: "Crash", Priority: 0, IssueType: model.TypeBug, Status: model.StatusBlocked, CreatedAt: now},
+		{ID: "done", Title: "Shipped", Priority: 1, IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: now},
+	}
+
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.ANSI)
+	renderer.SetHasDarkBackground(true)
+	tree := NewTreeModel(DefaultTheme(renderer))
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(60, 20)
+	tree.SetClosedStyle(ClosedStyleStrikethrough)
+	tree.Build(issues)
+
+	// SGR parameter of the selection background, e.g. "100"
+	bgOnly := renderer.NewStyle().Background(tree.theme.Highlight).Render("x")
+	bg := strings.TrimSuffix(strings.TrimPrefix(bgOnly[:strings.Index(bgOnly, "x")], "\x1b["), "m")
+	sgr := regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+	hasBg := func(params string) bool {
+		for _, p := range strings.Split(params, ";") {
+			if p == bg {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, id := range []string{"bug", "done"} {
+		selected := tree.renderNode(tree.issueMap[id], true)
+		for _, m := range sgr.FindAllStringSubmatch(selected, -1) {
+			if m[1] != "0" && !hasBg(m[1]) {
+				t.Errorf("%s: selected segment %q lacks the selection background in %q", id, m[0], selected)
+			}
+		}
+		if got := lipgloss.Width(selected) + tree.theme.Selected.GetHorizontalFrameSize(); got != 60 {
+			t.Errorf("%s: selected row spans %d cells, want the full 60", id, got)
+		}
+
+		unselected := tree.renderNode(tree.issueMap[id], false)
+		for _, m := range sgr.FindAllStringSubmatch(unselected, -1) {
+			if hasBg(m[1]) {
+				t.Errorf("%s: unselected row carries the selection background: %q", id, unselected)
+			}
+		}
+	}
+
+	// Strikethrough composes with, rather than replaces, the background.
+	if row := tree.renderNode(tree.issueMap["done"], true); !strings.Contains(row, bg+";9mS") {
+		t.Errorf("selected closed title should keep its strikethrough: %q", row)
+	}
+}
+
+func TestTreeBlockingMode(t *testing.T) {
+	now := time.Now()
+	dep := func(id, on string, typ model.DependencyType) *model.Dependency {
+		return &model.Dependency{IssueID: id, DependsOnID: on, Type: typ}
+	}
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "a", Title: "A", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{dep("a", "epic", model.DepParentChild)}},
+		{ID: "b", Title: "B", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{dep("b", "a", model.DepBlocks)}},
+		{ID: "c", Title: "C", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{dep("c", "b", model.DepBlocks)}},
+		{ID: "x", Title: "X", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{dep("x", "y", model.DepBlocks)}},
+		{ID: "y", Title: "Y", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{dep("y", "x", model.DepBlocks)}},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	if tree.RootCount() != 5 {
+		t.Fatalf("hierarchy roots = %d, want 5 (epic, b, c, x, y)", tree.RootCount())
+	}
+	tree.SelectByID("c")
+
+	tree.SetMode(TreeModeBlocking)
+	if tree.Mode() != TreeModeBlocking {
+		t.Fatal("SetMode should switch to blocking mode")
+	}
+	if got := tree.GetSelectedID(); got != "c" {
+		t.Errorf("selection should survive the mode switch, got %q", got)
+	}
+	parentOf := func(id string) string {
+		node := tree.issueMap[id]
+		if node == nil || node.Parent == nil {
+			return ""
+		}
+		return node.Parent.Issue.ID
+	}
+	// a blocks b blocks c; epic and a are unblocked; the x<->y cycle has no
+	// unblocked entry but must still show.
+	got := fmt.Sprintf("a<-%s b<-%s c<-%s epic<-%s", parentOf("a"), parentOf("b"), parentOf("c"), parentOf("epic"))
+	if want := "a<- b<-a c<-b epic<-"; got != want {
+		t.Errorf("blocking parents = %q, want %q", got, want)
+	}
+	if _, ok := tree.issueMap["x"]; !ok {
+		t.Error("issues in a blocking cycle should still appear")
+	}
+	if _, ok := tree.issueMap["y"]; !ok {
+		t.Error("issues in a blocking cycle should still appear")
+	}
+
+	tree.SetMode(TreeModeHierarchy)
+	if parentOf("a") != "epic" || parentOf("b") != "" {
+		t.Errorf("hierarchy not restored: a<-%s b<-%s", parentOf("a"), parentOf("b"))
+	}
+}
+
+func TestTreeMultiParentPolicy(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic 1", Priority: 1, IssueType: model.TypeEpic, CreatedAt: now},
+		{ID: "epic-2", Title: "Epic 2", Priority: 2, IssueType: model.TypeEpic, CreatedAt: now},
+		{ID: "shared", Title: "Shared", Priority: 1, IssueType: model.TypeFeature, CreatedAt: now, Dependencies: childOf("shared", "epic-2", "epic-1")},
+		{ID: "leaf", Title: "Leaf", Priority: 1, IssueType: model.TypeTask, CreatedAt: now, Dependencies: childOf("leaf", "shared")},
+	}
+	rows := func(tree *TreeModel) string {
+		var ids []string
+		for _, node := range tree.flatList {
+			ids = append(ids, strings.Repeat(".", node.Depth)+node.Issue.ID)
 		}
+		return strings.Join(ids, " ")
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(80, 20)
+	tree.Build(issues)
+	tree.ExpandAll()
+	if got, want := rows(&tree), "epic-1 epic-2 .shared ..leaf"; got != want {
+		t.Errorf("first parent only: rows = %q, want %q", got, want)
+	}
+
+	tree.SetMultiParentPolicy(MultiParentPromoteToRoot)
+	tree.ExpandAll()
+	if got, want := rows(&tree), "epic-1 shared .leaf epic-2"; got != want {
+		t.Errorf("promote to root: rows = %q, want %q", got, want)
+	}
+
+	tree.SetMultiParentPolicy(MultiParentDuplicateUnderEach)
+	tree.ExpandAll()
+	if got, want := rows(&tree), "epic-1 .shared ..leaf epic-2 .shared ..leaf"; got != want {
+		t.Fatalf("duplicate under each: rows = %q, want %q", got, want)
+	}
+	first, second := tree.flatList[1], tree.flatList[4]
+	if first == second || first.Issue != second.Issue {
+		t.Fatal("copies should be distinct nodes sharing one *model.Issue")
+	}
+
+	// Collapsing one copy leaves the other expanded, also after a rebuild
+	tree.cursor = 1
+	tree.ToggleExpand()
+	if got, want := rows(&tree), "epic-1 .shared epic-2 .shared ..leaf"; got != want {
+		t.Errorf("after collapsing the first copy: rows = %q, want %q", got, want)
+	}
+	tree.Build(issues)
+	if got, want := rows(&tree), "epic-1 .shared epic-2 .shared ..leaf"; got != want {
+		t.Errorf("per-copy expand state should persist: rows = %q, want %q", got, want)
+	}
+}
+
+func TestTreeRebuildIfChanged(t *testing.T) {
+	now := time.Now()
+	newIssues := func() []model.Issue {
+		return []model.Issue{
+			{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+			{ID: "a", Title: "A", Priority: 1, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+				Dependencies: []*model.Dependency{{IssueID: "a", DependsOnID: "epic", Type: model.DepParentChild}}},
+			{ID: "b", Title: "B", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now},
+		}
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	if !tree.RebuildIfChanged(newIssues()) {
+		t.Fatal("first build should report a rebuild")
+	}
+	tree.CollapseAll()
+	tree.SelectByID("b")
+
+	// Same content in a fresh slice: state survives, nodes see the new values
+	refresh := newIssues()
+	refresh[2].Assignee = "someone"
+	if tree.RebuildIfChanged(refresh) {
+		t.Error("unchanged issues should not rebuild")
+	}
+	if got := tree.GetSelectedID(); got != "b" {
+		t.Errorf("cursor should stay on b, got %q", got)
+	}
+	if tree.NodeCount() != 2 {
+		t.Errorf("collapsed state should survive, got %d rows", tree.NodeCount())
+	}
+	if got := tree.SelectedIssue(); got != &refresh[2] {
+		t.Error("nodes should point at the latest issue values")
+	}
+
+	// A status change rebuilds
+	changed := newIssues()
+	changed[1].Status = model.StatusClosed
+	if !tree.RebuildIfChanged(changed) {
+		t.Error("a status change should rebuild")
+	}
+
+	// Switching mode must rebuild even though the issues are unchanged
+	tree.SetMode(TreeModeBlocking)
+	if tree.issueMap["a"].Parent != nil {
+		t.Error("SetMode should rebuild despite unchanged issues")
+	}
+}
+
+func TestTreeRebuildKeepsExpandState(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, CreatedAt: now},
+		{ID: "a", Title: "A", Priority: 1, IssueType: model.TypeTask, CreatedAt: now, Dependencies: childOf("a", "epic")},
+		{ID: "other", Title: "Other", Priority: 2, IssueType: model.TypeEpic, CreatedAt: now},
+	}
+
+	// An unwritable state location, so only the in-memory state can carry over
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(blocker, ".beads"))
+	tree.Build(issues)
+	tree.SelectByID("epic")
+	tree.ToggleExpand()
+	if tree.issueMap["epic"].Expanded {
+		t.Fatal("epic should be collapsed")
+	}
+
+	more := append(append([]model.Issue(nil), issues...),
+		model.Issue{ID: "b", Title: "B", Priority: 1, IssueType: model.TypeTask, CreatedAt: now, Dependencies: childOf("b", "other")})
+	tree.Build(more)
+
+	if tree.issueMap["epic"].Expanded {
+		t.Error("collapsed root should stay collapsed after a rebuild")
+	}
+	if !tree.issueMap["other"].Expanded {
+		t.Error("untouched root should keep the depth default")
+	}
+	if _, ok := tree.issueMap["b"]; !ok || !tree.SelectByID("b") {
+		t.Error("new issue under an expanded root should be visible")
+	}
+}
+
+func TestTreeFindAndJumpToMatch(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic},
+		{ID: "feat", Title: "Feature", Priority: 1, IssueType: model.TypeFeature, Dependencies: childOf("feat", "epic")},
+		{ID: "story", Title: "Story", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("story", "feat")},
+		{ID: "task", Title: "Fix login Bug", Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("task", "story")},
+		{ID: "bug-2", Title: "Other", Priority: 2, IssueType: model.TypeBug},
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+
+	// "task" sits at depth 3, under the collapsed "story"
+	if got := fmt.Sprint(tree.Find("BUG")); got != "[3]" {
+		t.Fatalf("Find(BUG) = %s, want only the visible bug-2 row", got)
+	}
+
+	idx := tree.JumpToMatch("bug", -1)
+	if idx < 0 || tree.GetSelectedID() != "task" || idx != tree.cursor {
+		t.Fatalf("JumpToMatch = %d on %q, want the hidden task", idx, tree.GetSelectedID())
+	}
+	if !tree.issueMap["story"].Expanded {
+		t.Error("ancestors of the match should be expanded")
+	}
+	if got := fmt.Sprint(tree.Find("bug")); got != fmt.Sprint([]int{idx, idx + 1}) {
+		t.Errorf("Find(bug) after jump = %s", got)
+	}
+
+	if tree.JumpToMatch("bug", idx); tree.GetSelectedID() != "bug-2" {
+		t.Errorf("next match = %q, want bug-2", tree.GetSelectedID())
+	}
+	if tree.JumpToMatch("bug", tree.cursor); tree.GetSelectedID() != "task" {
+		t.Errorf("search should wrap around, got %q", tree.GetSelectedID())
+	}
+	if got := tree.JumpToMatch("nothing", 0); got != -1 {
+		t.Errorf("JumpToMatch with no match = %d, want -1", got)
+	}
+}
+
+func TestTreeHorizontalScroll(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic},
+		{ID: "task", Title: "A rather long task title that ends with NEEDLE", Priority: 1, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "task", DependsOnID: "epic", Type: model.DepParentChild}}},
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(40, 10)
+
+	taskRow := func() string {
+		for _, line := range strings.Split(tree.View(), "\n") {
+			if strings.Contains(line, "└") {
+				return line
+			}
+		}
+		t.Fatalf("task row not found in:\n%s", tree.View())
+		return ""
+	}
+	before := taskRow()
+	if strings.Contains(before, "NEEDLE") {
+		t.Fatalf("title should be truncated at width 40: %q", before)
+	}
+
+	for i := 0; i < 20; i++ {
+		tree.ScrollRight()
+	}
+	limit := tree.HScroll()
+	if limit == 0 {
+		t.Fatal("ScrollRight should pan a row that does not fit")
+	}
+	after := taskRow()
+	if !strings.Contains(after, "NEEDLE") {
+		t.Errorf("panned row should reveal the end of the title: %q", after)
+	}
+	prefixLen := strings.Index(before, "└") + len("└")
+	if after[:prefixLen] != before[:prefixLen] {
+		t.Errorf("tree prefix should stay pinned: %q vs %q", after, before)
+	}
+	if strings.Contains(after, "task") {
+		t.Errorf("content left of the window should scroll out of view: %q", after)
+	}
+	if w := lipgloss.Width(after); w > 40 {
+		t.Errorf("panned row is %d columns wide, want at most 40", w)
+	}
+
+	tree.ScrollRight()
+	if tree.HScroll() != limit {
+		t.Errorf("HScroll went past the widest row: %d > %d", tree.HScroll(), limit)
+	}
+	for tree.HScroll() > 0 {
+		tree.ScrollLeft()
+	}
+	if got := taskRow(); got != before {
+		t.Errorf("scrolling back should restore the row: %q vs %q", got, before)
+	}
+}
+
+func TestTreeNodeBadge(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "hub", Title: "Hub", Priority: 1, IssueType: model.TypeTask},
+		{ID: "a", Title: "A", Priority: 1, IssueType: model.TypeTask, Dependencies: blockedBy("a", "hub")},
+		{ID: "b", Title: "B", Priority: 1, IssueType: model.TypeTask, Dependencies: blockedBy("b", "hub", "a", "missing")},
+		{ID: "lone", Title: "Lone", Priority: 1, IssueType: model.TypeTask},
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(120, 10)
+
+	for id, want := range map[string]string{"hub": "[2↓]", "a": "[1↓ 1↑]", "b": "[2↑]", "lone": ""} {
+		if got := tree.nodeBadge(tree.issueMap[id]); got != want {
+			t.Errorf("nodeBadge(%s) = %q, want %q", id, got, want)
+		}
+	}
+
+	if strings.Contains(tree.View(), "[2↓]") {
+		t.Error("badges should be off by default")
+	}
+	tree.SetShowBadges(true)
+	if view := tree.View(); !strings.Contains(view, "[2↓]") || !strings.Contains(view, "[1↓ 1↑]") {
+		t.Errorf("View should show badges once enabled:\n%s", view)
+	}
+}
+
+func TestTreeIssueFilter(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic", Title: "Done epic", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeEpic},
+		{ID: "feat", Title: "Open feature", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature, Dependencies: childOf("feat", "epic")},
+		{ID: "task", Title: "Done task", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("task", "feat")},
+		{ID: "old", Title: "Old epic", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeEpic},
+		{ID: "old-1", Title: "Old task", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask, Dependencies: childOf("old-1", "old")},
+		{ID: "solo", Title: "Open solo", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask},
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(100, 20)
+
+	listed := func() string {
+		var ids []string
+		for _, node := range tree.flatList {
+			ids = append(ids, node.Issue.ID)
+		}
+		return fmt.Sprint(ids)
+	}
+
+	tree.SetIssueFilter(func(issue *model.Issue) bool { return !issue.Status.IsClosed() })
+	if got, want := listed(), "[epic feat solo]"; got != want {
+		t.Errorf("pass-through rows = %s, want %s", got, want)
+	}
+	if tree.NodeCount() != 3 {
+		t.Errorf("NodeCount = %d, want 3", tree.NodeCount())
+	}
+	if !strings.Contains(tree.View(), "└── ") {
+		t.Error("feat should be drawn as the closed epic's only listed child")
+	}
+
+	tree.SetHideEmptyAncestors(true)
+	if got, want := listed(), "[feat solo]"; got != want {
+		t.Errorf("rows with hidden ancestors = %s, want %s", got, want)
+	}
+	if tree.NodeCount() != 2 {
+		t.Errorf("NodeCount = %d, want 2", tree.NodeCount())
+	}
+	if view := tree.View(); strings.Contains(view, "└── ") || strings.Contains(view, "├── ") {
+		t.Errorf("feat should be drawn as a root once its parent is hidden:\n%s", view)
+	}
+
+	// The predicate survives a rebuild
+	tree.Build(issues)
+	if got, want := listed(), "[feat solo]"; got != want {
+		t.Errorf("rows after rebuild = %s, want %s", got, want)
+	}
+
+	tree.SetIssueFilter(nil)
+	if tree.NodeCount() != len(issues) {
+		t.Errorf("NodeCount without filter = %d, want %d", tree.NodeCount(), len(issues))
+	}
+}
+
+func TestTreeIssueFilterReparentsUnderHiddenClosed(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeEpic},
+		{ID: "done", Title: "Done feature", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeFeature, Dependencies: childOf("done", "epic")},
+		{ID: "open", Title: "Open task", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Dependencies: childOf("open", "done")},
+		{ID: "shelved", Title: "Shelved feature", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeFeature, Dependencies: childOf("shelved", "epic")},
+	}
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(100, 20)
+	tree.SetClosedStyle(ClosedStyleHidden)
+
+	// Every issue passes, so only the closed style hides rows
+	tree.SetIssueFilter(func(*model.Issue) bool { return true })
+	var ids []string
+	for _, node := range tree.flatList {
+		ids = append(ids, node.Issue.ID)
+	}
+	if got, want := fmt.Sprint(ids), "[epic open]"; got != want {
+		t.Fatalf("rows = %s, want %s", got, want)
+	}
+	if parent := tree.treeParent(tree.issueMap["open"]); parent != tree.issueMap["epic"] {
+		t.Errorf("open child of a hidden closed issue should be drawn under epic, got %v", parent)
+	}
+}
+
+// cancelAfterContext reports cancellation after Err has been polled n times,
+// letting tests cancel deterministically in the middle of a build.
+type cancelAfterContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.remaining <= 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+// TestTreeBuildContextCancelled verifies a cancelled build leaves the previous tree intact
+func TestTreeBuildContextCancelled(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build([]model.Issue{
+		{ID: "old-1", Title: "Old 1", IssueType: model.TypeTask},
+		{ID: "old-2", Title: "Old 2", IssueType: model.TypeTask},
+	})
+
+	// Large input: 50 epics with 100 children each
+	var issues []model.Issue
+	for e := 0; e < 50; e++ {
+		epicID := fmt.Sprintf("epic-%d", e)
+		issues = append(issues, model.Issue{ID: epicID, Title: epicID, IssueType: model.TypeEpic})
+		for c := 0; c < 100; c++ {
+			childID := fmt.Sprintf("%s-task-%d", epicID, c)
+			issues = append(issues, model.Issue{
+				ID: childID, Title: childID, IssueType: model.TypeTask,
+				Dependencies: []*model.Dependency{{IssueID: childID, DependsOnID: epicID, Type: model.DepParentChild}},
+			})
+		}
+	}
+
+	ctx := &cancelAfterContext{Context: context.Background(), remaining: 30}
+	err := tree.BuildContext(ctx, issues)
+	if err != context.Canceled {
+		t.Fatalf("BuildContext error = %v, want context.Canceled", err)
+	}
+	if ctx.remaining != 0 {
+		t.Fatalf("expected cancellation mid-build, %d polls remaining", ctx.remaining)
+	}
+
+	if tree.RootCount() != 2 || tree.GetSelectedID() != "old-1" {
+		t.Errorf("previous tree not preserved: roots=%d selected=%q", tree.RootCount(), tree.GetSelectedID())
+	}
+
+	// An uncancelled build replaces the tree
+	if err := tree.BuildContext(context.Background(), issues); err != nil {
+		t.Fatalf("BuildContext failed: %v", err)
+	}
+	if tree.RootCount() != 50 {
+		t.Errorf("expected 50 roots after full build, got %d", tree.RootCount())
+	}
+}
+
+// TestTreeDedupeByTitle verifies same-titled siblings merge into one row
+func TestTreeDedupeByTitle(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, CreatedAt: now},
+		{ID: "dup-1", Title: "Fix login", Priority: 2, IssueType: model.TypeTask, CreatedAt: now, Dependencies: childOf("dup-1", "epic")},
+		{ID: "dup-2", Title: "fix  Login", Priority: 2, IssueType: model.TypeTask, CreatedAt: now.Add(time.Minute), Dependencies: childOf("dup-2", "epic")},
+		{ID: "dup-3", Title: "Fix login ", Priority: 2, IssueType: model.TypeTask, CreatedAt: now.Add(2 * time.Minute), Dependencies: childOf("dup-3", "epic")},
+		{ID: "other", Title: "Other work", Priority: 2, IssueType: model.TypeTask, CreatedAt: now.Add(3 * time.Minute), Dependencies: childOf("other", "epic")},
+		// Same title but a different parent level: must not merge
+		{ID: "root-dup", Title: "Fix login", Priority: 3, IssueType: model.TypeTask, CreatedAt: now},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(200, 30)
+
+	if tree.NodeCount() != 6 {
+		t.Fatalf("expected 6 visible nodes without dedupe, got %d", tree.NodeCount())
+	}
+
+	tree.SetDedupeByTitle(true)
+	// epic, merged dup-1 (3), other, root-dup
+	if tree.NodeCount() != 4 {
+		t.Fatalf("expected 4 visible nodes with dedupe, got %d", tree.NodeCount())
+	}
+
+	rep := tree.issueMap["dup-1"]
+	if len(rep.Duplicates) != 2 {
+		t.Fatalf("expected dup-1 to represent 2 duplicates, got %d", len(rep.Duplicates))
+	}
+	if len(tree.issueMap["root-dup"].Duplicates) != 0 {
+		t.Error("dedupe must not merge across parents")
+	}
+
+	rep.Expanded = false
+	tree.rebuildFlatList()
+	if !tree.SelectByID("dup-1") {
+		t.Fatal("expected merged row to be selectable")
+	}
+	view := tree.View()
+	if !strings.Contains(view, "Fix login (3)") {
+		t.Errorf("expected merged count in view, got:\n%s", view)
+	}
+	if strings.Contains(view, "dup-2") {
+		t.Errorf("member IDs should be hidden while collapsed, got:\n%s", view)
+	}
+
+	tree.ToggleExpand()
+	view = tree.View()
+	if !strings.Contains(view, "[dup-1, dup-2, dup-3]") {
+		t.Errorf("expected member IDs listed on expand, got:\n%s", view)
+	}
+
+	tree.SetDedupeByTitle(false)
+	if tree.NodeCount() != 6 {
+		t.Errorf("expected 6 visible nodes after disabling dedupe, got %d", tree.NodeCount())
+	}
+}
+
+// TestTreeIssueMap verifies the issueMap lookup is populated
+func TestTreeIssueMap(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "test-1", Title: "Test 1", Priority: 1, IssueType: model.TypeTask},
+		{ID: "test-2", Title: "Test 2", Priority: 2, IssueType: model.TypeTask},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+
+	// Verify issueMap contains all nodes
+	if len(tree.issueMap) != 2 {
+		t.Errorf("expected issueMap to have 2 entries, got %d", len(tree.issueMap))
+	}
+
+	if _, ok := tree.issueMap["test-1"]; !ok {
+		t.Error("expected test-1 in issueMap")
+	}
+	if _, ok := tree.issueMap["test-2"]; !ok {
+		t.Error("expected test-2 in issueMap")
+	}
+}
+
+// TestIssueTypeOrder verifies the ordering of issue types
+func TestIssueTypeOrder(t *testing.T) {
+	tests := []struct {
+		issueType model.IssueType
+		expected  int
+	}{
+		{model.TypeEpic, 0},
+		{model.TypeFeature, 1},
+		{model.TypeTask, 2},
+		{model.TypeBug, 3},
+		{model.TypeChore, 4},
+		{"unknown", 5},
+	}
+
+	for _, tt := range tests {
+		got := issueTypeOrder(tt.issueType)
+		if got != tt.expected {
+			t.Errorf("issueTypeOrder(%s) = %d, want %d", tt.issueType, got, tt.expected)
+		}
+	}
+}
+
+// TestTreeViewEmpty verifies View() output for empty tree
+func TestTreeViewEmpty(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(nil)
+	tree.SetSize(80, 20)
+
+	view := tree.View()
+	if !strings.Contains(view, "No issues to display") {
+		t.Errorf("expected empty state message, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Press E to return") {
+		t.Errorf("expected return hint in empty state, got:\n%s", view)
+	}
+}
+
+// TestTreeViewRendering verifies View() renders tree structure correctly
+func TestTreeViewRendering(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic Issue", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{
+			ID: "task-1", Title: "Task under Epic", Priority: 2, IssueType: model.TypeTask, Status: model.StatusInProgress, CreatedAt: now.Add(time.Hour),
+			Dependencies: []*model.Dependency{{IssueID: "task-1", DependsOnID: "epic-1", Type: model.DepParentChild}},
+		},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(100, 30)
+
+	view := tree.View()
+
+	// Should contain both issue IDs
+	if !strings.Contains(view, "epic-1") {
+		t.Errorf("expected epic-1 in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "task-1") {
+		t.Errorf("expected task-1 in view, got:\n%s", view)
+	}
+
+	// Should contain titles
+	if !strings.Contains(view, "Epic Issue") {
+		t.Errorf("expected 'Epic Issue' in view, got:\n%s", view)
+	}
+
+	// Should contain tree characters (for child node)
+	if !strings.Contains(view, "└") && !strings.Contains(view, "├") {
+		t.Errorf("expected tree branch characters in view, got:\n%s", view)
+	}
+
+	// Should contain expand/collapse indicators
+	if !strings.Contains(view, "▾") && !strings.Contains(view, "▸") && !strings.Contains(view, "•") {
+		t.Errorf("expected expand/collapse indicators in view, got:\n%s", view)
+	}
+}
+
+// TestTreeViewIndicators verifies expand/collapse indicators come from the theme's glyph set
+func TestTreeViewIndicators(t *testing.T) {
+	for name, glyphs := range map[string]GlyphSet{"default": DefaultGlyphs(), "ascii": ASCIIGlyphs()} {
+		t.Run(name, func(t *testing.T) {
+			theme := newTreeTestTheme()
+			theme.Glyphs = glyphs
+			tree := NewTreeModel(theme)
+			tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+
+			// Test leaf node indicator
+			leafNode := &IssueTreeNode{
+				Issue:    &model.Issue{ID: "leaf"},
+				Children: nil,
+			}
+			if got := tree.getExpandIndicator(leafNode); got != glyphs.Leaf {
+				t.Errorf("leaf indicator = %q, want %q", got, glyphs.Leaf)
+			}
+
+			// Test expanded node indicator
+			expandedNode := &IssueTreeNode{
+				Issue:    &model.Issue{ID: "expanded"},
+				Children: []*IssueTreeNode{{Issue: &model.Issue{ID: "child"}}},
+				Expanded: true,
+			}
+			if got := tree.getExpandIndicator(expandedNode); got != glyphs.Expanded {
+				t.Errorf("expanded indicator = %q, want %q", got, glyphs.Expanded)
+			}
+
+			// Test collapsed node indicator
+			collapsedNode := &IssueTreeNode{
+				Issue:    &model.Issue{ID: "collapsed"},
+				Children: []*IssueTreeNode{{Issue: &model.Issue{ID: "child"}}},
+				Expanded: false,
+			}
+			if got := tree.getExpandIndicator(collapsedNode); got != glyphs.Collapsed {
+				t.Errorf("collapsed indicator = %q, want %q", got, glyphs.Collapsed)
+			}
+		})
+	}
+}
+
+// TestTreeRendersThemeStatusGlyphs verifies status markers follow the glyph set
+func TestTreeRendersThemeStatusGlyphs(t *testing.T) {
+	theme := newTreeTestTheme()
+	theme.Glyphs = ASCIIGlyphs()
+	tree := NewTreeModel(theme)
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(100, 10)
+	tree.Build([]model.Issue{{ID: "bv-1", Title: "Blocked one", Status: model.StatusBlocked, IssueType: model.TypeTask}})
+
+	view := tree.View()
+	if !strings.Contains(view, ASCIIGlyphs().StatusBlocked) || strings.Contains(view, "🔴") {
+		t.Errorf("expected ASCII status glyph in view, got:\n%s", view)
+	}
+}
+
+func TestTreeRowCacheMatchesUncachedRender(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "task-1", Title: "First", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "task-1", DependsOnID: "epic-1", Type: model.DepParentChild}}},
+		{ID: "task-2", Title: "Second", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "task-2", DependsOnID: "epic-1", Type: model.DepParentChild}}},
+	}
+
+	newTree := func(noCache bool) *TreeModel {
+		tree := NewTreeModel(newTreeTestTheme())
+		tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+		tree.SetSize(100, 10)
+		tree.Build(issues)
+		tree.ExpandAll()
+		tree.noRowCache = noCache
+		return &tree
+	}
+	cached, uncached := newTree(false), newTree(true)
+
+	check := func(step string) {
+		t.Helper()
+		if got, want := cached.View(), uncached.View(); got != want {
+			t.Fatalf("%s: cached view differs from uncached\ncached:\n%s\nuncached:\n%s", step, got, want)
+		}
+	}
+
+	check("initial")
+	cached.MoveDown()
+	uncached.MoveDown()
+	check("after MoveDown")
+
+	// In-place content edits change the row hash and must not serve stale rows.
+	cached.issueMap["task-2"].Issue.Title = "Renamed"
+	uncached.issueMap["task-2"].Issue.Title = "Renamed"
+	check("after title edit")
+	if !strings.Contains(cached.View(), "Renamed") {
+		t.Fatal("expected edited title to be rendered")
+	}
+
+	// Horizontal scrolling measures rows from the cache too, and a content
+	// edit must still change the scroll limit.
+	long := strings.Repeat("wide ", 40)
+	cached.issueMap["task-1"].Issue.Title = long
+	uncached.issueMap["task-1"].Issue.Title = long
+	for i := 0; i < 4; i++ {
+		cached.ScrollRight()
+		uncached.ScrollRight()
+	}
+	check("after ScrollRight")
+	if cached.HScroll() == 0 || cached.HScroll() != uncached.HScroll() {
+		t.Fatalf("HScroll = %d, uncached %d", cached.HScroll(), uncached.HScroll())
+	}
+	for _, node := range cached.flatList {
+		if entry := cached.rowCache[node]; entry.overflowKey.width != cached.width {
+			t.Errorf("%s: row overflow not cached", node.Issue.ID)
+		}
+	}
+	cached.issueMap["task-1"].Issue.Title = "Short"
+	uncached.issueMap["task-1"].Issue.Title = "Short"
+	check("after shortening the wide row")
+	if cached.HScroll() != 0 {
+		t.Errorf("HScroll should clamp to 0 once every row fits, got %d", cached.HScroll())
+	}
+
+	cached.JumpToTop()
+	uncached.JumpToTop()
+	cached.ToggleExpand()
+	uncached.ToggleExpand()
+	check("after collapse")
+}
+
+func TestTreeRowCacheFollowsLayoutChanges(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "calm", Title: "Calm", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "calm-1", Title: "Calm task", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "calm-1", DependsOnID: "calm", Type: model.DepParentChild}}},
+		{ID: "risky", Title: "Risky", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "risky-1", Title: "Risky task", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "risky-1", DependsOnID: "risky", Type: model.DepParentChild}}},
+		{ID: "risky-2", Title: "Risky done", Priority: 2, IssueType: model.TypeTask, Status: model.StatusClosed,
+			Dependencies: []*model.Dependency{{IssueID: "risky-2", DependsOnID: "risky", Type: model.DepParentChild}}},
+		{ID: "risky-3", Title: "Risky chore", Priority: 2, IssueType: model.TypeChore, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "risky-3", DependsOnID: "risky", Type: model.DepParentChild}}},
+	}
+
+	newTree := func(noCache bool) *TreeModel {
+		tree := NewTreeModel(newTreeTestTheme())
+		tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+		tree.SetSize(100, 10)
+		tree.Build(issues)
+		tree.ExpandAll()
+		tree.noRowCache = noCache
+		return &tree
+	}
+	cached, uncached := newTree(false), newTree(true)
+
+	// Every step moves branch characters without touching row content.
+	steps := []struct {
+		name  string
+		apply func(tree *TreeModel)
+	}{
+		{"initial", func(tree *TreeModel) {}},
+		{"SortRootsByRisk", func(tree *TreeModel) {
+			tree.SortRootsByRisk(map[string]float64{"calm": 0.1, "risky": 0.9})
+		}},
+		{"ClosedStyleHidden", func(tree *TreeModel) { tree.SetClosedStyle(ClosedStyleHidden) }},
+		{"SetFilter", func(tree *TreeModel) { tree.SetFilter("task") }},
+		{"clear filter", func(tree *TreeModel) { tree.SetFilter("") }},
+		{"SetHideIslands", func(tree *TreeModel) { tree.SetHideIslands(true) }},
+	}
+	for _, step := range steps {
+		step.apply(cached)
+		step.apply(uncached)
+		if got, want := cached.View(), uncached.View(); got != want {
+			t.Fatalf("%s: cached view differs from uncached\ncached:\n%s\nuncached:\n%s", step.name, got, want)
+		}
+	}
+}
+
+func TestTreeApplyDiffHighlight(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "task-1", Title: "Task task-1", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, Dependencies: childOf("task-1", "epic-1")},
+		{ID: "task-2", Title: "Task task-2", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, Dependencies: childOf("task-2", "epic-1")},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(120, 20)
+	tree.Build(issues)
+	tree.ExpandAll()
+
+	rowFor := func(view, id string) string {
+		for _, line := range strings.Split(view, "\n") {
+			if strings.Contains(line, id+" ") {
+				return line
+			}
+		}
+		t.Fatalf("no row for %s in view:\n%s", id, view)
+		return ""
+	}
+	before := tree.View()
+
+	tree.ApplyDiffHighlight(analysis.SnapshotDiff{
+		NewIssues:      []model.Issue{issues[2]},
+		ModifiedIssues: []analysis.ModifiedIssue{{IssueID: "task-1"}},
+		RemovedIssues:  []model.Issue{{ID: "task-old", Title: "Task task-old", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, Dependencies: childOf("task-old", "epic-1")}},
+	})
+	after := tree.View()
+
+	if rowFor(after, "task-1") == rowFor(before, "task-1") {
+		t.Error("modified node should be styled differently")
+	}
+	if !strings.Contains(rowFor(after, "task-1"), "~ ") || !strings.Contains(rowFor(after, "task-2"), "+ ") {
+		t.Errorf("expected modified and added markers, got:\n%s", after)
+	}
+	if rowFor(after, "epic-1") != rowFor(before, "epic-1") {
+		t.Error("unchanged node should render as before")
+	}
+
+	// The removed issue is a tombstone row under its old parent.
+	if !strings.Contains(rowFor(after, "task-old"), "- ") {
+		t.Errorf("expected tombstone marker for removed issue, got:\n%s", after)
+	}
+	last := tree.flatList[len(tree.flatList)-1]
+	if last.Issue.ID != "task-old" || last.Parent == nil || last.Parent.Issue.ID != "epic-1" || last.Depth != 1 {
+		t.Errorf("tombstone should be the epic's last child, got %+v", last)
+	}
+	if tree.DiffStatusOf("task-old") != DiffStatusRemoved {
+		t.Errorf("DiffStatusOf(task-old) = %v", tree.DiffStatusOf("task-old"))
+	}
+
+	tree.ClearDiffHighlight()
+	if got := tree.View(); got != before {
+		t.Errorf("ClearDiffHighlight should restore the original view, got:\n%s", got)
+	}
+}
+
+func TestTreeHelpViewListsCoreKeys(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.SetSize(100, 30)
+	tree.Build([]model.Issue{{ID: "bv-1", Title: "Only", Status: model.StatusOpen, IssueType: model.TypeTask}})
+
+	help := tree.HelpView()
+	for _, want := range []string{"j / ↓", "k / ↑", "enter / space", "h / ←", "l / →", "g ", "G ", "o ", "O ", "Move down", "Jump to top"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help view missing %q:\n%s", want, help)
+		}
+	}
+
+	if strings.Contains(tree.View(), "Tree View Keys") {
+		t.Fatal("help should be hidden by default")
+	}
+	tree.ToggleHelp()
+	if !tree.ShowingHelp() || tree.View() != help {
+		t.Error("View should show the help overlay after ToggleHelp")
+	}
+	tree.ToggleHelp()
+	if !strings.Contains(tree.View(), "bv-1") {
+		t.Error("View should show the tree again after a second ToggleHelp")
+	}
+}
+
+// TestTreeCrossLinkAnnotation verifies blocking deps across epics are annotated
+func TestTreeCrossLinkAnnotation(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "epic-a", Title: "Epic A", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now},
+		{ID: "epic-b", Title: "Epic B", Priority: 1, IssueType: model.TypeEpic, Status: model.StatusOpen, CreatedAt: now.Add(time.Minute)},
+		{
+			ID: "a-1", Title: "Blocked task", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{
+				{IssueID: "a-1", DependsOnID: "epic-a", Type: model.DepParentChild},
+				{IssueID: "a-1", DependsOnID: "b-1", Type: model.DepBlocks},
+			},
+		},
+		{
+			ID: "b-1", Title: "Blocking task", Priority: 2, IssueType: model.TypeTask, Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "b-1", DependsOnID: "epic-b", Type: model.DepParentChild}},
+		},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+	tree.Build(issues)
+	tree.SetSize(200, 30)
+
+	if strings.Contains(tree.View(), "blocked by") {
+		t.Fatal("expected no cross-link annotations when disabled")
+	}
+
+	tree.SetShowCrossLinks(true)
+	var blockedLine, blockerLine string
+	for _, line := range strings.Split(tree.View(), "\n") {
+		switch {
+		case strings.Contains(line, "Blocked task"):
+			blockedLine = line
+		case strings.Contains(line, "Blocking task"):
+			blockerLine = line
+		}
+	}
+
+	if !strings.Contains(blockedLine, "[blocked by: b-1]") {
+		t.Errorf("expected blocked-by annotation on a-1, got: %q", blockedLine)
+	}
+	if !strings.Contains(blockerLine, "[blocks: a-1]") {
+		t.Errorf("expected blocks annotation on b-1, got: %q", blockerLine)
+	}
+}
+
+// TestTreeTruncateTitle verifies title truncation
+func TestTreeTruncateTitle(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+
+	tests := []struct {
+		title  string
+		maxLen int
+		want   string
+	}{
+		{"Short", 20, "Short"},
+		{"This is a very long title that should be truncated", 20, "This is a very long…"},
+		{"ABC", 3, "..."},
+		{"A", 10, "A"},
+	}
+
+	for _, tt := range tests {
+		got := tree.truncateTitle(tt.title, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("truncateTitle(%q, %d) = %q, want %q", tt.title, tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+// TestTreeTruncateTitleWideChars verifies truncation measures display width
+// and never splits multi-byte characters.
+func TestTreeTruncateTitleWideChars(t *testing.T) {
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
+
+	tests := []struct {
+		name   string
+		title  string
+		maxLen int
+	}{
+		{"CJK", "修复登录页面的用户认证错误并添加测试", 11},
+		{"Emoji", "🚀🔥 Launch the rocket 🎉🎉🎉 and celebrate", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tree.truncateTitle(tt.title, tt.maxLen)
+			if !utf8.ValidString(got) {
+				t.Fatalf("truncateTitle produced invalid UTF-8: %q", got)
+			}
+			if !strings.HasSuffix(got, "…") {
+				t.Errorf("truncateTitle(%q, %d) = %q, want ellipsis suffix", tt.title, tt.maxLen, got)
+			}
+			if w := lipgloss.Width(got); w > tt.maxLen {
+				t.Errorf("truncateTitle(%q, %d) visible width = %d, want <= %d", tt.title, tt.maxLen, w, tt.maxLen)
+			}
+			if w := lipgloss.Width(got); w < tt.maxLen-1 {
+				t.Errorf("truncateTitle(%q, %d) visible width = %d, truncated too aggressively", tt.title, tt.maxLen, w)
+			}
+		})
 	}
 }
 
@@ -510,6 +1847,7 @@ func TestTreeJumpToParent(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Move to child
@@ -543,6 +1881,7 @@ func TestTreeExpandOrMoveToChild(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Root is initially expanded (auto-expand depth < 2)
@@ -584,6 +1923,7 @@ func TestTreeCollapseOrJumpToParent(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Root is expanded - CollapseOrJumpToParent should collapse
@@ -626,6 +1966,7 @@ func TestTreePageNavigation(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10) // Height of 10 -> page size of 5
 
@@ -664,6 +2005,7 @@ func TestTreeSelectByID(t *testing.T) {
 	}
 
 	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 
 	// Select middle issue
@@ -773,6 +2115,7 @@ func TestVisibleRange(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create tree model with test nodes
 			tree := NewTreeModel(testTheme())
+			tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 			tree.height = tt.height
 			tree.viewportOffset = tt.offset
 
@@ -810,6 +2153,7 @@ func TestVisibleRange(t *testing.T) {
 func TestVisibleRangePerformance(t *testing.T) {
 	// Verify O(1) behavior - should complete quickly regardless of tree size
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.height = 20
 
 	// Large tree
@@ -1200,6 +2544,7 @@ func TestEnsureCursorVisible(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tree := NewTreeModel(testTheme())
+			tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 			tree.height = tt.height
 			tree.cursor = tt.initialCursor
 			tree.viewportOffset = tt.initialOffset
@@ -1239,6 +2584,7 @@ func TestEnsureCursorVisible(t *testing.T) {
 // TestEnsureCursorVisibleNegativeOffset tests clamping of negative offset
 func TestEnsureCursorVisibleNegativeOffset(t *testing.T) {
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.height = 10
 	tree.cursor = 0
 	tree.viewportOffset = -5 // Invalid negative offset
@@ -1271,6 +2617,7 @@ func TestNavigationCallsEnsureCursorVisible(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10) // Viewport of 10 lines
 
@@ -1320,6 +2667,7 @@ func TestNavigationCallsEnsureCursorVisible(t *testing.T) {
 // TestGetViewportOffset tests the accessor method
 func TestGetViewportOffset(t *testing.T) {
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.viewportOffset = 42
 
 	if got := tree.GetViewportOffset(); got != 42 {
@@ -1345,6 +2693,7 @@ func TestViewRendersOnlyVisible(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10) // Viewport of 10 lines
 
@@ -1395,6 +2744,7 @@ func TestViewRendersSmallTree(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 20) // Viewport larger than tree
 
@@ -1426,6 +2776,7 @@ func TestViewSelectionHighlightWithOffset(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10)
 
@@ -1459,6 +2810,7 @@ func TestViewAtEndOfList(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10)
 
@@ -1500,6 +2852,7 @@ func TestPositionIndicatorShown(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10) // Viewport of 10, 100 nodes
 
@@ -1524,6 +2877,7 @@ func TestPositionIndicatorMiddle(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10)
 
@@ -1549,6 +2903,7 @@ func TestPositionIndicatorNotShownSmallTree(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 20) // Viewport larger than tree
 
@@ -1573,6 +2928,7 @@ func TestPositionIndicatorAtEnd(t *testing.T) {
 	}
 
 	tree := NewTreeModel(testTheme())
+	tree.SetBeadsDir(filepath.Join(t.TempDir(), ".beads"))
 	tree.Build(issues)
 	tree.SetSize(80, 10)
 
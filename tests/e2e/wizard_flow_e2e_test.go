@@ -177,7 +177,7 @@ func TestWizard_ExportConfigPrompts(t *testing.T) {
 
 	// Verify export configuration prompts appear
 	configPrompts := []string{
-		"Include closed",
+		"Which issues should be published",
 		"title",
 		"subtitle",
 	}